@@ -1,19 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"strconv"
 
+	"mcp-system-info/internal/config"
 	"mcp-system-info/internal/handlers"
 	"mcp-system-info/internal/logger"
 	"mcp-system-info/internal/middleware"
+	"mcp-system-info/internal/sysinfo"
 	"mcp-system-info/internal/tools"
 	"mcp-system-info/internal/types"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -21,70 +21,91 @@ func main() {
 	// Инициализируем логгер в самом начале
 	logger.InitLogger()
 
-	systemInfoTool := mcp.NewTool("get_system_info",
-		mcp.WithDescription("Gets system information: CPU and memory"),
-		mcp.WithString("random_string",
-			mcp.Required(),
-			mcp.Description("Dummy parameter for no-parameter tools"),
-		),
-	)
-
-	systemMonitorStreamTool := mcp.NewTool("system_monitor_stream",
-		mcp.WithDescription("Streams real-time system information: CPU and memory monitoring"),
-		mcp.WithString("duration",
-			mcp.Description("Monitoring duration (e.g., '30s', '5m')"),
-		),
-		mcp.WithString("interval",
-			mcp.Description("Update interval (e.g., '1s', '2s')"),
-		),
-	)
-
-	mcpServer := server.NewMCPServer("mcp-system-info", "1.0.0")
-	mcpServer.AddTool(systemInfoTool, tools.GetSystemInfoHandler)
-	mcpServer.AddTool(systemMonitorStreamTool, tools.SystemMonitorStreamHandler)
+	// Проверяем всю конфигурацию до старта, чтобы не работать с неожиданными значениями
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Main.Fatal().
+			Err(err).
+			Msg("Invalid configuration, refusing to start")
+	}
+	sysinfo.SetCollectionTimeout(cfg.CollectionTimeout)
+	sysinfo.SetModelNameMaxLength(cfg.CPUModelMaxLength)
+	sysinfo.SetRedactHostname(cfg.RedactHostname)
+	sysinfo.SetMountUsageCacheTTL(cfg.DiskUsageCacheTTL)
+	if cfg.CPUWarmup > 0 {
+		sysinfo.WarmupCPU(context.Background(), cfg.CPUWarmup)
+	}
+	if cfg.MetricHistoryInterval > 0 {
+		sysinfo.StartMetricHistory(context.Background(), cfg.MetricHistoryInterval, cfg.MetricHistoryWindow)
+	}
+	// Сообщаем один раз, какие данные будут недоступны без повышенных прав. Проба обходит все процессы,
+	// на больших хостах это долго, поэтому она не задерживает старт
+	go sysinfo.LogPermissionsReport(context.Background())
+
+	heavy := tools.NewLimiter(cfg.ToolMaxConcurrency, cfg.ToolQueueTimeout)
+	toolset := tools.Definitions(cfg, heavy)
+
+	var serverOptions []server.ServerOption
+	if cfg.ServerInstructions != "" {
+		serverOptions = append(serverOptions, server.WithInstructions(cfg.ServerInstructions))
+	}
+	serverOptions = append(serverOptions, handlers.StdioServerOptions(cfg)...)
+
+	mcpServer := server.NewMCPServer("mcp-system-info", "1.0.0", serverOptions...)
+	mcpServer.AddTools(toolset...)
 
 	// Добавляем отладочную информацию
+	toolNames := make([]string, 0, len(toolset))
+	for _, tool := range toolset {
+		toolNames = append(toolNames, tool.Tool.Name)
+	}
 	logger.Main.Info().
-		Str("tool1", "get_system_info").
-		Str("tool2", "system_monitor_stream").
+		Strs("tools", toolNames).
 		Msg("Registered MCP tools")
 
-	if port := os.Getenv("PORT"); port != "" {
-		portInt, err := strconv.Atoi(port)
-		if err != nil || portInt <= 0 {
-			logger.Main.Fatal().
-				Str("port", port).
-				Msg("Invalid PORT value")
-		}
+	if cfg.Port != 0 {
 
 		// Создаем Fiber приложение
 		app := fiber.New(fiber.Config{
 			DisableStartupMessage: false,
 			AppName:               "MCP System Info Server",
+			ErrorHandler:          handlers.ErrorHandler,
 		})
 
 		// Добавляем middleware для логгирования HTTP запросов с расширенной информацией о клиентах
 		app.Use(middleware.RequestLoggingMiddleware())
 
+		// Сжимаем gzip только достаточно большие ответы, SSE потоки не сжимаются
+		app.Use(middleware.CompressMiddlewareWithConfig(middleware.CompressConfig{
+			MinBytes: cfg.CompressMinBytes,
+		}))
+
 		// Добавляем CORS middleware
 		app.Use(cors.New(cors.Config{
-			AllowOrigins:     "*",
-			AllowMethods:     "GET,POST,OPTIONS",
+			AllowOrigins:     config.CORSAllowOrigins,
+			AllowMethods:     "GET,POST,DELETE,OPTIONS",
 			AllowHeaders:     "Content-Type,Mcp-Session-Id",
 			ExposeHeaders:    "Mcp-Session-Id",
 			AllowCredentials: false,
 		}))
 
-		sessionManager := types.NewSessionManager()
-		mcpHandler := handlers.NewFiberMCPHandler(mcpServer, sessionManager)
+		sessionManager := types.NewSessionManagerWithConfig(types.SessionConfig{
+			BufferSize:     cfg.SessionBufferSize,
+			OverflowPolicy: types.OverflowPolicy(cfg.SessionOverflowPolicy),
+			BlockTimeout:   cfg.SessionBlockTimeout,
+			EventMaxAge:    cfg.SessionEventMaxAge,
+		})
+		sessionManager.StartGoroutineMonitor(context.Background(), cfg.GoroutineCheckInterval, cfg.GoroutineWarnThreshold)
+		mcpHandler := handlers.NewFiberMCPHandler(mcpServer, sessionManager, cfg, toolset, heavy)
 
 		// Регистрируем маршруты
 		mcpHandler.RegisterRoutes(app)
 
-		addr := fmt.Sprintf(":%d", portInt)
+		addr := fmt.Sprintf(":%d", cfg.Port)
 		logger.Main.Info().
-			Str("port", port).
+			Int("port", cfg.Port).
 			Str("addr", addr).
+			Str("hostname", sysinfo.Hostname()).
 			Msg("Starting Fiber server")
 
 		if err = app.Listen(addr); err != nil {
@@ -94,8 +115,10 @@ func main() {
 				Msg("Error starting Fiber server")
 		}
 	} else {
-		logger.Main.Info().Msg("Starting MCP server in stdio mode")
-		if err := server.ServeStdio(mcpServer); err != nil {
+		logger.Main.Info().
+			Str("hostname", sysinfo.Hostname()).
+			Msg("Starting MCP server in stdio mode")
+		if err := serveStdio(mcpServer, cfg); err != nil {
 			logger.Main.Fatal().
 				Err(err).
 				Msg("Error starting MCP server in stdio mode")
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// serveStdio обслуживает MCP через stdin/stdout до закрытия stdin или сигнала завершения.
+// При cfg.StdioKeepAlive ошибка ввода-вывода логгируется и чтение перезапускается через cfg.StdioRestartDelay
+func serveStdio(mcpServer *server.MCPServer, cfg *config.Config) error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case sig := <-signals:
+			cancel(fmt.Errorf("received signal %v", sig))
+		case <-ctx.Done():
+		}
+	}()
+
+	stdioServer := server.NewStdioServer(mcpServer)
+	stdioServer.SetErrorLogger(log.New(logger.Main, "", 0))
+
+	for restarts := 0; ; restarts++ {
+		err := stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+
+		if ctx.Err() != nil {
+			logger.Main.Info().
+				Str("reason", context.Cause(ctx).Error()).
+				Msg("Stdio server shut down")
+			return nil
+		}
+		if err == nil {
+			logger.Main.Info().
+				Str("reason", "stdin closed").
+				Msg("Stdio server shut down")
+			return nil
+		}
+		if !cfg.StdioKeepAlive {
+			return err
+		}
+
+		logger.Main.Warn().
+			Err(err).
+			Int("restarts", restarts+1).
+			Dur("restart_delay", cfg.StdioRestartDelay).
+			Msg("Stdio transport error, restarting (STDIO_KEEP_ALIVE)")
+
+		select {
+		case <-time.After(cfg.StdioRestartDelay):
+		case <-ctx.Done():
+		}
+	}
+}
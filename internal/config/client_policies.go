@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Опции политики клиента в CLIENT_POLICIES
+const (
+	// ClientOptionForceJSON отвечать на streaming tools/call обычным JSON, даже если клиент принимает SSE
+	ClientOptionForceJSON = "force_json"
+	// ClientOptionRelaxRequiredArgs подставлять пустые значения отсутствующих обязательных аргументов
+	ClientOptionRelaxRequiredArgs = "relax_required_args"
+)
+
+// ClientPolicy переопределения поведения сервера для одного типа клиента
+type ClientPolicy struct {
+	// ForceJSON запрещает переключение ответа на SSE поток
+	ForceJSON bool
+	// RelaxRequiredArgs дополняет вызов инструмента отсутствующими обязательными аргументами
+	RelaxRequiredArgs bool
+}
+
+// Options возвращает включенные опции политики в формате CLIENT_POLICIES
+func (p ClientPolicy) Options() []string {
+	var options []string
+	if p.ForceJSON {
+		options = append(options, ClientOptionForceJSON)
+	}
+	if p.RelaxRequiredArgs {
+		options = append(options, ClientOptionRelaxRequiredArgs)
+	}
+	return options
+}
+
+// ClientPolicy возвращает политику для типа клиента, без переопределений - нулевое значение
+func (c *Config) ClientPolicy(clientType string) ClientPolicy {
+	return c.ClientPolicies[strings.ToLower(clientType)]
+}
+
+// clientPolicies читает политики вида client=option,option;client=option.
+// Тип клиента совпадает с client_type из логов запросов (cursor, n8n, curl, postman, mcp-client, unknown)
+func (l *loader) clientPolicies(key string) map[string]ClientPolicy {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	expected := "client=option[,option] entries separated by ';', options: " +
+		ClientOptionForceJSON + ", " + ClientOptionRelaxRequiredArgs
+	policies := make(map[string]ClientPolicy)
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		client, optionsStr, ok := strings.Cut(entry, "=")
+		client = strings.ToLower(strings.TrimSpace(client))
+		if !ok || client == "" {
+			l.fail(key, value, expected)
+			return nil
+		}
+
+		policy := policies[client]
+		for _, option := range strings.Split(optionsStr, ",") {
+			switch strings.ToLower(strings.TrimSpace(option)) {
+			case ClientOptionForceJSON:
+				policy.ForceJSON = true
+			case ClientOptionRelaxRequiredArgs:
+				policy.RelaxRequiredArgs = true
+			default:
+				l.fail(key, value, expected)
+				return nil
+			}
+		}
+		policies[client] = policy
+	}
+
+	return policies
+}
+
+// formatClientPolicies форматирует политики в стабильном порядке клиентов
+func formatClientPolicies(policies map[string]ClientPolicy) string {
+	clients := make([]string, 0, len(policies))
+	for client := range policies {
+		clients = append(clients, client)
+	}
+	sort.Strings(clients)
+
+	entries := make([]string, 0, len(clients))
+	for _, client := range clients {
+		entries = append(entries, client+"="+strings.Join(policies[client].Options(), ","))
+	}
+	return strings.Join(entries, ";")
+}
@@ -0,0 +1,605 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+)
+
+// DefaultAPIKey встроенный API ключ, используется если MCP_API_KEY не задан
+const DefaultAPIKey = "mcp-secret-key-2025"
+
+// Политики доступа к health check (HEALTH_AUTH)
+const (
+	// HealthAuthPublic полный ответ без авторизации
+	HealthAuthPublic = "public"
+	// HealthAuthMinimal без ключа только {"status":"ok"}, подробности по ключу
+	HealthAuthMinimal = "minimal"
+	// HealthAuthAPIKey health check целиком требует ключ
+	HealthAuthAPIKey = "api_key"
+)
+
+// DefaultEnvRedactPattern имена переменных окружения, значения которых скрываются в get_env
+const DefaultEnvRedactPattern = `(?i)(KEY|TOKEN|SECRET|PASSWORD|PASSWD|CREDENTIAL|AUTH)`
+
+// Config конфигурация сервера, загружаемая из переменных окружения
+type Config struct {
+	// Port порт HTTP сервера, 0 - режим stdio
+	Port int
+	// StdioKeepAlive перезапускает чтение stdio после ошибки ввода-вывода вместо завершения процесса
+	StdioKeepAlive bool
+	// StdioRestartDelay пауза перед перезапуском чтения stdio при StdioKeepAlive
+	StdioRestartDelay time.Duration
+	// BasePath префикс всех HTTP маршрутов для монтирования за reverse proxy (например /mcp-sysinfo), пусто - корень
+	BasePath string
+	// ServerInstructions текст instructions в ответе initialize, пусто - поле не передается
+	ServerInstructions string
+	// ServerEnvironment метка окружения (prod, staging) в serverInfo, пусто - поле не передается
+	ServerEnvironment string
+	// SchemaVersionField добавлять schema_version в результаты tools/call и serverInfo
+	SchemaVersionField bool
+	// SSEMaxDuration абсолютное ограничение времени жизни SSE соединения
+	SSEMaxDuration time.Duration
+	// SSEWriteBufferSize размер буфера записи SSE потоков в байтах
+	SSEWriteBufferSize int
+	// CompressMinBytes минимальный размер ответа для gzip сжатия
+	CompressMinBytes int
+	// MaxSSEStreams максимум одновременно открытых SSE потоков
+	MaxSSEStreams int
+	// SessionBufferSize размер буфера уведомлений каждой сессии
+	SessionBufferSize int
+	// SessionOverflowPolicy политика переполнения буфера: drop-oldest, drop-newest, block
+	SessionOverflowPolicy string
+	// SessionBlockTimeout максимальное ожидание места в буфере для политики block
+	SessionBlockTimeout time.Duration
+	// SessionEventMaxAge максимальный возраст событий для возобновления потока по Last-Event-Id, 0 - без ограничения
+	SessionEventMaxAge time.Duration
+	// StreamOverloadCPUPercent загрузка CPU, выше которой новые streaming вызовы отклоняются или замедляются, 0 - выключено
+	StreamOverloadCPUPercent float64
+	// StreamOverloadAction реакция на перегрузку: reject или degrade
+	StreamOverloadAction string
+	// StreamOverloadInterval минимальный интервал сэмплов streaming инструментов в режиме degrade
+	StreamOverloadInterval time.Duration
+	// CollectionTimeout общий таймаут сбора системной информации
+	CollectionTimeout time.Duration
+	// CPUModelMaxLength максимальная длина модели CPU в текстовом выводе и логах
+	CPUModelMaxLength int
+	// RedactHostname заменять имя хоста стабильным в пределах запуска хешем в выводе и логах
+	RedactHostname bool
+	// CPUWarmup пауза после начального замера CPU при старте, 0 - без прогрева
+	CPUWarmup time.Duration
+	// GoroutineCheckInterval период логгирования числа горутин
+	GoroutineCheckInterval time.Duration
+	// GoroutineWarnThreshold число горутин, выше которого пишется предупреждение
+	GoroutineWarnThreshold int
+	// EnablePprof монтирует /debug/pprof обработчики
+	EnablePprof bool
+	// PprofAccess ограничение доступа к pprof: localhost или auth
+	PprofAccess string
+	// ToolMaxConcurrency максимум одновременных выполнений тяжелых инструментов
+	ToolMaxConcurrency int
+	// ToolQueueTimeout ожидание свободного слота, 0 - немедленный отказ
+	ToolQueueTimeout time.Duration
+	// APIKey API ключ для заголовка X-API-Key
+	APIKey string
+	// AuthAllowQueryKey разрешает передачу API ключа в query параметре api_key
+	AuthAllowQueryKey bool
+	// HealthAuth политика доступа к health check: public, minimal или api_key
+	HealthAuth string
+	// HealthAPIKey ключ доступа к подробностям health check, по умолчанию MCP_API_KEY
+	HealthAPIKey string
+	// RequireInitialized запрещает tools/call до получения notifications/initialized
+	RequireInitialized bool
+	// ClientPolicies переопределения поведения по типу клиента из CLIENT_POLICIES, пусто - без переопределений
+	ClientPolicies map[string]ClientPolicy
+	// EnableSessionEvents публикует события жизненного цикла сессии в ее SSE поток (отладка)
+	EnableSessionEvents bool
+	// EnableEnvTool регистрирует инструмент get_env
+	EnableEnvTool bool
+	// EnvRedactPattern регулярное выражение имен переменных, значения которых скрываются в get_env
+	EnvRedactPattern string
+	// DiskMounts точки монтирования, о которых сообщает get_disk_usage, пусто - все физические разделы
+	DiskMounts []string
+	// DiskUsageCacheTTL время жизни кеша get_disk_usage, 0 - без кеша
+	DiskUsageCacheTTL time.Duration
+	// HealthWeights веса компонентов health_score
+	HealthWeights map[string]float64
+	// DiskScanRoots корневые каталоги, внутри которых разрешен disk_usage_scan, пусто - инструмент отключен
+	DiskScanRoots []string
+	// DiskScanMaxDepth максимальная глубина рекурсии disk_usage_scan
+	DiskScanMaxDepth int
+	// DiskScanTimeout ограничение времени одного сканирования
+	DiskScanTimeout time.Duration
+	// LogScanRoots каталоги логов, которые сканирует log_disk_usage, пусто - инструмент отключен
+	LogScanRoots []string
+	// LogScanMaxDepth максимальная глубина рекурсии log_disk_usage
+	LogScanMaxDepth int
+	// LogScanTimeout ограничение времени одного сканирования логов
+	LogScanTimeout time.Duration
+	// LogStaleAge файлы логов без изменений дольше этого срока считаются устаревшими
+	LogStaleAge time.Duration
+	// DiskProbeDir каталог для временного файла disk_latency_probe, пусто - инструмент отключен
+	DiskProbeDir string
+	// DiskProbeSizeKB размер пробного файла в KiB
+	DiskProbeSizeKB int
+	// FleetPeers URL MCP эндпоинтов соседних серверов для get_fleet_info, пусто - инструмент отключен
+	FleetPeers []string
+	// FleetAPIKey API ключ запросов к соседним серверам, по умолчанию MCP_API_KEY
+	FleetAPIKey string
+	// FleetConcurrency максимум одновременных запросов к соседним серверам
+	FleetConcurrency int
+	// FleetTimeout ограничение времени опроса одного соседнего сервера
+	FleetTimeout time.Duration
+	// KernelLogSource источник get_kernel_warnings и get_oom_events: auto, kmsg или journalctl
+	KernelLogSource string
+	// CustomToolsAllowedBinaries абсолютные пути бинарников, которые могут запускать пользовательские инструменты
+	CustomToolsAllowedBinaries []string
+	// CustomTools пользовательские инструменты из CUSTOM_TOOLS_FILE, пусто - не регистрируются
+	CustomTools []CustomTool
+	// CustomToolTimeout ограничение времени выполнения одной команды пользовательского инструмента
+	CustomToolTimeout time.Duration
+	// MonitorMaxSamples максимум сэмплов (duration/interval) одного вызова system_monitor_stream
+	MonitorMaxSamples int
+	// MonitorOutputDir директория для файлов сэмплов system_monitor_stream, пусто - запись отключена
+	MonitorOutputDir string
+	// InfluxMeasurement имя measurement в ответе /metrics/influx
+	InfluxMeasurement string
+	// InfluxHostTag значение тега host в /metrics/influx, пусто - имя хоста
+	InfluxHostTag string
+	// MetricHistoryInterval период фоновых замеров памяти для memory_trend, 0 - история отключена
+	MetricHistoryInterval time.Duration
+	// MetricHistoryWindow максимальная длительность хранимой истории замеров
+	MetricHistoryWindow time.Duration
+	// MetricsBroadcastInterval период общего сэмплера метрик для GET /mcp?metrics=true, 0 - рассылка отключена
+	MetricsBroadcastInterval time.Duration
+	// MonitorTimestampFormat формат времени сэмплов стримов по умолчанию: short, rfc3339, unix_ms
+	MonitorTimestampFormat string
+}
+
+// Load загружает конфигурацию из переменных окружения и проверяет все значения.
+// Возвращает ошибку со списком всех некорректных переменных, чтобы сервер не стартовал с неожиданными настройками
+func Load() (*Config, error) {
+	l := &loader{}
+
+	cfg := &Config{
+		Port: l.port("PORT"),
+
+		StdioKeepAlive:    l.bool("STDIO_KEEP_ALIVE", false),
+		StdioRestartDelay: l.duration("STDIO_RESTART_DELAY", time.Second),
+
+		BasePath: l.basePath("BASE_PATH"),
+
+		ServerInstructions: l.string("SERVER_INSTRUCTIONS", ""),
+		ServerEnvironment:  l.string("SERVER_ENVIRONMENT", ""),
+		SchemaVersionField: l.bool("SCHEMA_VERSION_FIELD", false),
+
+		SSEMaxDuration:        l.duration("SSE_MAX_DURATION", 10*time.Minute),
+		SSEWriteBufferSize:    l.int("SSE_WRITE_BUFFER_SIZE", 4096),
+		CompressMinBytes:      l.int("COMPRESS_MIN_BYTES", 1024),
+		MaxSSEStreams:         l.int("MAX_SSE_STREAMS", 100),
+		SessionBufferSize:     l.int("SESSION_BUFFER_SIZE", 100),
+		SessionOverflowPolicy: l.enum("SESSION_OVERFLOW_POLICY", "drop-oldest", "drop-oldest", "drop-newest", "block"),
+		SessionBlockTimeout:   l.duration("SESSION_BLOCK_TIMEOUT", time.Second),
+		SessionEventMaxAge:    l.nonNegativeDuration("SESSION_EVENT_MAX_AGE", time.Minute),
+		CollectionTimeout:     l.duration("COLLECTION_TIMEOUT", 5*time.Second),
+		CPUModelMaxLength:     l.int("CPU_MODEL_MAX_LENGTH", 64),
+		RedactHostname:        l.bool("REDACT_HOSTNAME", false),
+
+		StreamOverloadCPUPercent: l.percent("STREAM_OVERLOAD_CPU_PERCENT", 0),
+		StreamOverloadAction:     l.enum("STREAM_OVERLOAD_ACTION", "reject", "reject", "degrade"),
+		StreamOverloadInterval:   l.duration("STREAM_OVERLOAD_INTERVAL", 10*time.Second),
+
+		GoroutineCheckInterval: l.duration("GOROUTINE_CHECK_INTERVAL", time.Minute),
+		GoroutineWarnThreshold: l.int("GOROUTINE_WARN_THRESHOLD", 1000),
+
+		EnablePprof: l.bool("ENABLE_PPROF", false),
+		PprofAccess: l.enum("PPROF_ACCESS", "localhost", "localhost", "auth"),
+
+		ToolMaxConcurrency: l.int("TOOL_MAX_CONCURRENCY", 4),
+		ToolQueueTimeout:   l.nonNegativeDuration("TOOL_QUEUE_TIMEOUT", 0),
+
+		APIKey:             l.string("MCP_API_KEY", DefaultAPIKey),
+		AuthAllowQueryKey:  l.bool("AUTH_ALLOW_QUERY_KEY", false),
+		HealthAuth:         l.enum("HEALTH_AUTH", HealthAuthMinimal, HealthAuthPublic, HealthAuthMinimal, HealthAuthAPIKey),
+		RequireInitialized: l.bool("REQUIRE_INITIALIZED", false),
+		ClientPolicies:     l.clientPolicies("CLIENT_POLICIES"),
+
+		EnableSessionEvents: l.bool("ENABLE_SESSION_EVENTS", false),
+
+		EnableEnvTool:    l.bool("ENABLE_ENV_TOOL", false),
+		EnvRedactPattern: l.regexp("ENV_REDACT_PATTERN", DefaultEnvRedactPattern),
+
+		DiskMounts:        l.paths("DISK_MOUNTS"),
+		DiskUsageCacheTTL: l.nonNegativeDuration("DISK_USAGE_CACHE_TTL", 5*time.Second),
+		HealthWeights:     l.weights("HEALTH_WEIGHTS", sysinfo.HealthComponents),
+
+		DiskScanRoots:    l.paths("DISK_SCAN_ROOTS"),
+		DiskScanMaxDepth: l.int("DISK_SCAN_MAX_DEPTH", 32),
+		DiskScanTimeout:  l.duration("DISK_SCAN_TIMEOUT", 10*time.Second),
+
+		LogScanRoots:    l.paths("LOG_SCAN_ROOTS"),
+		LogScanMaxDepth: l.int("LOG_SCAN_MAX_DEPTH", 16),
+		LogScanTimeout:  l.duration("LOG_SCAN_TIMEOUT", 10*time.Second),
+		LogStaleAge:     l.duration("LOG_STALE_AGE", 7*24*time.Hour),
+
+		DiskProbeDir:    l.string("DISK_PROBE_DIR", ""),
+		DiskProbeSizeKB: l.int("DISK_PROBE_SIZE_KB", 1024),
+
+		FleetPeers:       l.urls("FLEET_PEERS"),
+		FleetConcurrency: l.int("FLEET_CONCURRENCY", 4),
+		FleetTimeout:     l.duration("FLEET_TIMEOUT", 10*time.Second),
+
+		KernelLogSource: l.enum("KERNEL_LOG_SOURCE", "auto", sysinfo.KernelLogSources...),
+
+		MonitorOutputDir:       l.string("MONITOR_OUTPUT_DIR", ""),
+		MonitorMaxSamples:      l.int("MONITOR_MAX_SAMPLES", 5000),
+		MonitorTimestampFormat: l.enum("MONITOR_TIMESTAMP_FORMAT", "short", "short", "rfc3339", "unix_ms"),
+
+		MetricsBroadcastInterval: l.nonNegativeDuration("METRICS_BROADCAST_INTERVAL", 2*time.Second),
+		InfluxMeasurement:        l.string("INFLUX_MEASUREMENT", "system"),
+		InfluxHostTag:            l.string("INFLUX_HOST_TAG", ""),
+		MetricHistoryInterval:    l.nonNegativeDuration("METRIC_HISTORY_INTERVAL", 10*time.Second),
+		MetricHistoryWindow:      l.duration("METRIC_HISTORY_WINDOW", time.Hour),
+
+		CustomToolsAllowedBinaries: l.paths("CUSTOM_TOOLS_ALLOWED_BINARIES"),
+		CustomToolTimeout:          l.duration("CUSTOM_TOOL_TIMEOUT", 5*time.Second),
+	}
+	cfg.CustomTools = l.customTools("CUSTOM_TOOLS_FILE", cfg.CustomToolsAllowedBinaries)
+	cfg.CPUWarmup = l.nonNegativeDuration("CPU_WARMUP", defaultCPUWarmup(cfg.Port))
+	cfg.FleetAPIKey = l.string("FLEET_API_KEY", cfg.APIKey)
+	cfg.HealthAPIKey = l.string("HEALTH_API_KEY", cfg.APIKey)
+	if cfg.MetricHistoryInterval > 0 && cfg.MetricHistoryWindow < cfg.MetricHistoryInterval {
+		l.fail("METRIC_HISTORY_WINDOW", cfg.MetricHistoryWindow.String(), "a duration not shorter than METRIC_HISTORY_INTERVAL")
+	}
+
+	if err := errors.Join(l.errs...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	logger.Main.Info().
+		Int("port", cfg.Port).
+		Bool("stdio_keep_alive", cfg.StdioKeepAlive).
+		Dur("stdio_restart_delay", cfg.StdioRestartDelay).
+		Str("base_path", cfg.BasePath).
+		Bool("server_instructions", cfg.ServerInstructions != "").
+		Str("server_environment", cfg.ServerEnvironment).
+		Bool("schema_version_field", cfg.SchemaVersionField).
+		Dur("sse_max_duration", cfg.SSEMaxDuration).
+		Int("compress_min_bytes", cfg.CompressMinBytes).
+		Int("max_sse_streams", cfg.MaxSSEStreams).
+		Int("session_buffer_size", cfg.SessionBufferSize).
+		Str("session_overflow_policy", cfg.SessionOverflowPolicy).
+		Dur("session_block_timeout", cfg.SessionBlockTimeout).
+		Dur("session_event_max_age", cfg.SessionEventMaxAge).
+		Dur("collection_timeout", cfg.CollectionTimeout).
+		Float64("stream_overload_cpu_percent", cfg.StreamOverloadCPUPercent).
+		Str("stream_overload_action", cfg.StreamOverloadAction).
+		Dur("stream_overload_interval", cfg.StreamOverloadInterval).
+		Int("cpu_model_max_length", cfg.CPUModelMaxLength).
+		Bool("redact_hostname", cfg.RedactHostname).
+		Dur("cpu_warmup", cfg.CPUWarmup).
+		Dur("goroutine_check_interval", cfg.GoroutineCheckInterval).
+		Int("goroutine_warn_threshold", cfg.GoroutineWarnThreshold).
+		Bool("enable_pprof", cfg.EnablePprof).
+		Str("pprof_access", cfg.PprofAccess).
+		Int("tool_max_concurrency", cfg.ToolMaxConcurrency).
+		Dur("tool_queue_timeout", cfg.ToolQueueTimeout).
+		Bool("api_key_default", cfg.APIKey == DefaultAPIKey).
+		Bool("auth_allow_query_key", cfg.AuthAllowQueryKey).
+		Str("health_auth", cfg.HealthAuth).
+		Bool("health_api_key_separate", cfg.HealthAPIKey != cfg.APIKey).
+		Bool("require_initialized", cfg.RequireInitialized).
+		Str("client_policies", formatClientPolicies(cfg.ClientPolicies)).
+		Bool("enable_session_events", cfg.EnableSessionEvents).
+		Bool("enable_env_tool", cfg.EnableEnvTool).
+		Str("env_redact_pattern", cfg.EnvRedactPattern).
+		Strs("disk_mounts", cfg.DiskMounts).
+		Dur("disk_usage_cache_ttl", cfg.DiskUsageCacheTTL).
+		Interface("health_weights", cfg.HealthWeights).
+		Strs("disk_scan_roots", cfg.DiskScanRoots).
+		Int("disk_scan_max_depth", cfg.DiskScanMaxDepth).
+		Dur("disk_scan_timeout", cfg.DiskScanTimeout).
+		Strs("log_scan_roots", cfg.LogScanRoots).
+		Int("log_scan_max_depth", cfg.LogScanMaxDepth).
+		Dur("log_scan_timeout", cfg.LogScanTimeout).
+		Dur("log_stale_age", cfg.LogStaleAge).
+		Str("disk_probe_dir", cfg.DiskProbeDir).
+		Int("disk_probe_size_kb", cfg.DiskProbeSizeKB).
+		Strs("fleet_peers", RedactURLs(cfg.FleetPeers)).
+		Int("fleet_concurrency", cfg.FleetConcurrency).
+		Dur("fleet_timeout", cfg.FleetTimeout).
+		Str("kernel_log_source", cfg.KernelLogSource).
+		Str("monitor_output_dir", cfg.MonitorOutputDir).
+		Int("monitor_max_samples", cfg.MonitorMaxSamples).
+		Str("monitor_timestamp_format", cfg.MonitorTimestampFormat).
+		Dur("metrics_broadcast_interval", cfg.MetricsBroadcastInterval).
+		Str("influx_measurement", cfg.InfluxMeasurement).
+		Str("influx_host_tag", cfg.InfluxHostTag).
+		Dur("metric_history_interval", cfg.MetricHistoryInterval).
+		Dur("metric_history_window", cfg.MetricHistoryWindow).
+		Strs("custom_tools_allowed_binaries", cfg.CustomToolsAllowedBinaries).
+		Int("custom_tools", len(cfg.CustomTools)).
+		Dur("custom_tool_timeout", cfg.CustomToolTimeout).
+		Msg("Configuration loaded")
+
+	return cfg, nil
+}
+
+// defaultCPUWarmup прогрев CPU по умолчанию включен только в HTTP режиме: stdio сервер
+// запускается клиентом на каждую сессию, и задержка старта там заметнее
+func defaultCPUWarmup(port int) time.Duration {
+	if port == 0 {
+		return 0
+	}
+	return 500 * time.Millisecond
+}
+
+// loader читает переменные окружения и накапливает ошибки разбора
+type loader struct {
+	errs []error
+}
+
+// fail добавляет ошибку разбора переменной
+func (l *loader) fail(key, value, expected string) {
+	l.errs = append(l.errs, fmt.Errorf("%s=%q: expected %s", key, value, expected))
+}
+
+// string читает строку из переменной окружения
+func (l *loader) string(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// paths читает список абсолютных путей через запятую
+func (l *loader) paths(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			l.fail(key, value, "a comma-separated list of absolute paths")
+			return nil
+		}
+		paths = append(paths, filepath.Clean(path))
+	}
+
+	return paths
+}
+
+// urls читает список абсолютных http(s) URL через запятую
+func (l *loader) urls(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var urls []string
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			l.fail(key, value, "a comma-separated list of http(s) URLs")
+			return nil
+		}
+		urls = append(urls, raw)
+	}
+
+	return urls
+}
+
+// RedactURL скрывает userinfo URL (логин и пароль) для логов и отображения настроек
+func RedactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.User == nil {
+		return raw
+	}
+	parsed.User = url.User("xxxxx")
+	return parsed.String()
+}
+
+// RedactURLs применяет RedactURL к каждому URL списка
+func RedactURLs(urls []string) []string {
+	redacted := make([]string, len(urls))
+	for i, raw := range urls {
+		redacted[i] = RedactURL(raw)
+	}
+	return redacted
+}
+
+// basePath читает префикс URL маршрутов: начальный "/" обязателен, завершающие "/" отбрасываются,
+// поэтому "/" и пустое значение означают корень
+func (l *loader) basePath(key string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return ""
+	}
+
+	if !strings.HasPrefix(value, "/") || strings.ContainsAny(value, "?#* ") || strings.Contains(value, "//") {
+		l.fail(key, value, "a URL path like /mcp-sysinfo")
+		return ""
+	}
+
+	return strings.TrimRight(value, "/")
+}
+
+// port читает номер TCP порта, пустое значение означает 0
+func (l *loader) port(key string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		l.fail(key, value, "a port number between 1 and 65535")
+		return 0
+	}
+
+	return port
+}
+
+// duration читает положительную длительность из переменной окружения
+func (l *loader) duration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		l.fail(key, value, "a positive duration like 500ms, 30s or 5m")
+		return defaultValue
+	}
+
+	return duration
+}
+
+// nonNegativeDuration читает длительность, допускающую нулевое значение
+func (l *loader) nonNegativeDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		l.fail(key, value, "a non-negative duration like 0, 500ms or 30s")
+		return defaultValue
+	}
+
+	return duration
+}
+
+// int читает положительное целое из переменной окружения
+func (l *loader) int(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		l.fail(key, value, "a positive integer")
+		return defaultValue
+	}
+
+	return number
+}
+
+// percent читает процент от 0 до 100, 0 обычно означает выключенную проверку
+func (l *loader) percent(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil || percent < 0 || percent > 100 {
+		l.fail(key, value, "a percentage between 0 and 100")
+		return defaultValue
+	}
+
+	return percent
+}
+
+// weights читает веса вида name=weight через запятую. Не указанные компоненты получают вес 1,
+// вес 0 исключает компонент из оценки
+func (l *loader) weights(key string, components []string) map[string]float64 {
+	weights := make(map[string]float64, len(components))
+	for _, component := range components {
+		weights[component] = 1
+	}
+
+	value := os.Getenv(key)
+	if value == "" {
+		return weights
+	}
+
+	expected := "comma-separated name=weight pairs with non-negative weights, names: " + strings.Join(components, ", ")
+	for _, pair := range strings.Split(value, ",") {
+		name, weightStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := weights[name]; !ok || !known {
+			l.fail(key, value, expected)
+			return weights
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+		if err != nil || weight < 0 {
+			l.fail(key, value, expected)
+			return weights
+		}
+		weights[name] = weight
+	}
+
+	return weights
+}
+
+// bool читает логический флаг из переменной окружения
+func (l *loader) bool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		l.fail(key, value, "a boolean (true/false)")
+		return defaultValue
+	}
+
+	return flag
+}
+
+// enum читает значение из списка допустимых вариантов
+func (l *loader) enum(key, defaultValue string, allowed ...string) string {
+	value := strings.ToLower(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	for _, option := range allowed {
+		if value == option {
+			return value
+		}
+	}
+
+	l.fail(key, value, "one of "+strings.Join(allowed, ", "))
+	return defaultValue
+}
+
+// regexp читает регулярное выражение, проверяя что оно компилируется
+func (l *loader) regexp(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	if _, err := regexp.Compile(value); err != nil {
+		l.fail(key, value, "a valid regular expression ("+err.Error()+")")
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// DefaultCustomParamPattern допустимые значения параметра пользовательского инструмента по умолчанию
+const DefaultCustomParamPattern = `^[A-Za-z0-9._-]+$`
+
+// customToolNamePattern допустимые имена пользовательских инструментов и их параметров
+var customToolNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CustomArgPlaceholder подстановка параметра в шаблон аргумента: {name}
+var CustomArgPlaceholder = regexp.MustCompile(`\{([a-z][a-z0-9_]*)\}`)
+
+// CustomTool пользовательский инструмент, выполняющий разрешенную команду без shell
+type CustomTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Command абсолютный путь к бинарнику из CUSTOM_TOOLS_ALLOWED_BINARIES
+	Command string `json:"command"`
+	// Args фиксированные шаблоны аргументов, {param} заменяется значением параметра целиком внутри одного аргумента
+	Args   []string          `json:"args"`
+	Params []CustomToolParam `json:"params"`
+}
+
+// CustomToolParam строковый параметр пользовательского инструмента
+type CustomToolParam struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	// Pattern регулярное выражение допустимых значений, по умолчанию DefaultCustomParamPattern
+	Pattern string `json:"pattern"`
+}
+
+// customTools читает и проверяет JSON файл с описаниями пользовательских инструментов
+func (l *loader) customTools(key string, allowedBinaries []string) []CustomTool {
+	path := os.Getenv(key)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s=%q: %v", key, path, err))
+		return nil
+	}
+
+	var tools []CustomTool
+	if err := json.Unmarshal(data, &tools); err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s=%q: invalid JSON: %v", key, path, err))
+		return nil
+	}
+
+	valid := true
+	names := make(map[string]bool)
+	for i := range tools {
+		if err := validateCustomTool(&tools[i], allowedBinaries); err != nil {
+			l.errs = append(l.errs, fmt.Errorf("%s=%q: tool #%d: %v", key, path, i+1, err))
+			valid = false
+			continue
+		}
+		if names[tools[i].Name] {
+			l.errs = append(l.errs, fmt.Errorf("%s=%q: duplicate tool name %q", key, path, tools[i].Name))
+			valid = false
+		}
+		names[tools[i].Name] = true
+	}
+
+	if !valid {
+		return nil
+	}
+
+	return tools
+}
+
+// validateCustomTool проверяет описание инструмента и подставляет паттерн параметров по умолчанию
+func validateCustomTool(tool *CustomTool, allowedBinaries []string) error {
+	if !customToolNamePattern.MatchString(tool.Name) {
+		return fmt.Errorf("invalid name %q: expected lowercase letters, digits and underscores", tool.Name)
+	}
+	if !filepath.IsAbs(tool.Command) {
+		return fmt.Errorf("tool %q: command %q must be an absolute path", tool.Name, tool.Command)
+	}
+	if !slices.Contains(allowedBinaries, filepath.Clean(tool.Command)) {
+		return fmt.Errorf("tool %q: command %q is not in CUSTOM_TOOLS_ALLOWED_BINARIES", tool.Name, tool.Command)
+	}
+
+	params := make(map[string]bool)
+	for i := range tool.Params {
+		param := &tool.Params[i]
+		if !customToolNamePattern.MatchString(param.Name) || params[param.Name] {
+			return fmt.Errorf("tool %q: invalid or duplicate parameter name %q", tool.Name, param.Name)
+		}
+		params[param.Name] = true
+
+		if param.Pattern == "" {
+			param.Pattern = DefaultCustomParamPattern
+		}
+		if _, err := regexp.Compile(AnchoredPattern(param.Pattern)); err != nil {
+			return fmt.Errorf("tool %q: parameter %q: invalid pattern: %v", tool.Name, param.Name, err)
+		}
+	}
+
+	for _, arg := range tool.Args {
+		for _, match := range CustomArgPlaceholder.FindAllStringSubmatch(arg, -1) {
+			if !params[match[1]] {
+				return fmt.Errorf("tool %q: argument %q references undeclared parameter %q", tool.Name, arg, match[1])
+			}
+		}
+	}
+
+	return nil
+}
+
+// AnchoredPattern привязывает паттерн параметра к началу и концу значения: паттерн описывает значение
+// целиком, иначе `[a-z]+` пропустил бы `abc;rm -rf x` в аргументы внешней команды
+func AnchoredPattern(pattern string) string {
+	return `^(?:` + pattern + `)$`
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/rs/zerolog"
+)
+
+// CORSAllowOrigins разрешенные CORS источники HTTP сервера
+const CORSAllowOrigins = "*"
+
+// OutputSchemaVersion версия контракта вывода инструментов (формат текста, структура результатов
+// streaming вызовов). Увеличивается при несовместимых изменениях вывода
+const OutputSchemaVersion = "1"
+
+// redactedValue подставляется вместо секретных значений настроек
+const redactedValue = "[REDACTED]"
+
+// Setting одна эффективная настройка сервера и ее источник
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Source env - значение задано переменной окружения, default - значение по умолчанию, derived - вычислено сервером
+	Source string `json:"source"`
+}
+
+// Settings возвращает эффективную конфигурацию для диагностики. Секреты (API ключ) скрываются,
+// поэтому результат можно прикладывать к issue
+func (c *Config) Settings() []Setting {
+	transport := "stdio"
+	if c.Port != 0 {
+		transport = "http"
+	}
+
+	customTools := make([]string, 0, len(c.CustomTools))
+	for _, tool := range c.CustomTools {
+		customTools = append(customTools, tool.Name)
+	}
+
+	settings := []Setting{
+		derived("transport", transport),
+		fromEnv("PORT", c.Port),
+		fromEnv("STDIO_KEEP_ALIVE", c.StdioKeepAlive),
+		fromEnv("STDIO_RESTART_DELAY", c.StdioRestartDelay),
+		fromEnv("BASE_PATH", c.BasePath),
+		fromEnv("LOG_LEVEL", zerolog.GlobalLevel().String()),
+		fromEnv("LOG_FORMAT", os.Getenv("LOG_FORMAT")),
+		fromEnv("SERVER_ENVIRONMENT", c.ServerEnvironment),
+		fromEnv("SCHEMA_VERSION_FIELD", c.SchemaVersionField),
+		fromEnv("SERVER_INSTRUCTIONS", fmt.Sprintf("%d chars", len(c.ServerInstructions))),
+
+		derived("auth_enabled", c.Port != 0),
+		derived("tls_enabled", false),
+		derived("cors_allow_origins", CORSAllowOrigins),
+		{Key: "MCP_API_KEY", Value: redactedValue, Source: source("MCP_API_KEY")},
+		fromEnv("AUTH_ALLOW_QUERY_KEY", c.AuthAllowQueryKey),
+		fromEnv("HEALTH_AUTH", c.HealthAuth),
+		{Key: "HEALTH_API_KEY", Value: redactedValue, Source: source("HEALTH_API_KEY")},
+		fromEnv("REQUIRE_INITIALIZED", c.RequireInitialized),
+		fromEnv("CLIENT_POLICIES", formatClientPolicies(c.ClientPolicies)),
+
+		fromEnv("SSE_MAX_DURATION", c.SSEMaxDuration),
+		fromEnv("MAX_SSE_STREAMS", c.MaxSSEStreams),
+		fromEnv("SSE_WRITE_BUFFER_SIZE", c.SSEWriteBufferSize),
+		fromEnv("COMPRESS_MIN_BYTES", c.CompressMinBytes),
+		fromEnv("SESSION_BUFFER_SIZE", c.SessionBufferSize),
+		fromEnv("SESSION_OVERFLOW_POLICY", c.SessionOverflowPolicy),
+		fromEnv("SESSION_BLOCK_TIMEOUT", c.SessionBlockTimeout),
+		fromEnv("SESSION_EVENT_MAX_AGE", c.SessionEventMaxAge),
+		fromEnv("ENABLE_SESSION_EVENTS", c.EnableSessionEvents),
+		fromEnv("STREAM_OVERLOAD_CPU_PERCENT", c.StreamOverloadCPUPercent),
+		fromEnv("STREAM_OVERLOAD_ACTION", c.StreamOverloadAction),
+		fromEnv("STREAM_OVERLOAD_INTERVAL", c.StreamOverloadInterval),
+		fromEnv("COLLECTION_TIMEOUT", c.CollectionTimeout),
+		fromEnv("CPU_MODEL_MAX_LENGTH", c.CPUModelMaxLength),
+		fromEnv("REDACT_HOSTNAME", c.RedactHostname),
+		fromEnv("CPU_WARMUP", c.CPUWarmup),
+		fromEnv("GOROUTINE_CHECK_INTERVAL", c.GoroutineCheckInterval),
+		fromEnv("GOROUTINE_WARN_THRESHOLD", c.GoroutineWarnThreshold),
+
+		fromEnv("ENABLE_PPROF", c.EnablePprof),
+		fromEnv("PPROF_ACCESS", c.PprofAccess),
+
+		fromEnv("TOOL_MAX_CONCURRENCY", c.ToolMaxConcurrency),
+		fromEnv("TOOL_QUEUE_TIMEOUT", c.ToolQueueTimeout),
+		fromEnv("ENABLE_ENV_TOOL", c.EnableEnvTool),
+		fromEnv("ENV_REDACT_PATTERN", c.EnvRedactPattern),
+		fromEnv("DISK_MOUNTS", strings.Join(c.DiskMounts, ",")),
+		fromEnv("DISK_USAGE_CACHE_TTL", c.DiskUsageCacheTTL),
+		fromEnv("HEALTH_WEIGHTS", formatWeights(c.HealthWeights)),
+		fromEnv("DISK_SCAN_ROOTS", strings.Join(c.DiskScanRoots, ",")),
+		fromEnv("DISK_SCAN_MAX_DEPTH", c.DiskScanMaxDepth),
+		fromEnv("DISK_SCAN_TIMEOUT", c.DiskScanTimeout),
+		fromEnv("LOG_SCAN_ROOTS", strings.Join(c.LogScanRoots, ",")),
+		fromEnv("LOG_SCAN_MAX_DEPTH", c.LogScanMaxDepth),
+		fromEnv("LOG_SCAN_TIMEOUT", c.LogScanTimeout),
+		fromEnv("LOG_STALE_AGE", c.LogStaleAge),
+		fromEnv("DISK_PROBE_DIR", c.DiskProbeDir),
+		fromEnv("DISK_PROBE_SIZE_KB", c.DiskProbeSizeKB),
+		fromEnv("FLEET_PEERS", strings.Join(RedactURLs(c.FleetPeers), ",")),
+		{Key: "FLEET_API_KEY", Value: redactedValue, Source: source("FLEET_API_KEY")},
+		fromEnv("FLEET_CONCURRENCY", c.FleetConcurrency),
+		fromEnv("FLEET_TIMEOUT", c.FleetTimeout),
+		fromEnv("KERNEL_LOG_SOURCE", c.KernelLogSource),
+		fromEnv("MONITOR_OUTPUT_DIR", c.MonitorOutputDir),
+		fromEnv("MONITOR_MAX_SAMPLES", c.MonitorMaxSamples),
+		fromEnv("MONITOR_TIMESTAMP_FORMAT", c.MonitorTimestampFormat),
+		fromEnv("METRICS_BROADCAST_INTERVAL", c.MetricsBroadcastInterval),
+		fromEnv("INFLUX_MEASUREMENT", c.InfluxMeasurement),
+		fromEnv("INFLUX_HOST_TAG", c.InfluxHostTag),
+		fromEnv("METRIC_HISTORY_INTERVAL", c.MetricHistoryInterval),
+		fromEnv("METRIC_HISTORY_WINDOW", c.MetricHistoryWindow),
+		fromEnv("CUSTOM_TOOLS_FILE", os.Getenv("CUSTOM_TOOLS_FILE")),
+		derived("custom_tools", strings.Join(customTools, ",")),
+		fromEnv("CUSTOM_TOOLS_ALLOWED_BINARIES", strings.Join(c.CustomToolsAllowedBinaries, ",")),
+		fromEnv("CUSTOM_TOOL_TIMEOUT", c.CustomToolTimeout),
+	}
+
+	return settings
+}
+
+// fromEnv описывает настройку, читаемую из переменной окружения key
+func fromEnv(key string, value interface{}) Setting {
+	return Setting{Key: key, Value: formatSettingValue(value), Source: source(key)}
+}
+
+// derived описывает настройку, вычисляемую сервером, а не задаваемую напрямую
+func derived(key string, value interface{}) Setting {
+	return Setting{Key: key, Value: formatSettingValue(value), Source: "derived"}
+}
+
+// source определяет, задана ли переменная окружения или используется значение по умолчанию
+func source(key string) string {
+	if os.Getenv(key) != "" {
+		return "env"
+	}
+	return "default"
+}
+
+// formatWeights форматирует веса компонентов в стабильном порядке
+func formatWeights(weights map[string]float64) string {
+	pairs := make([]string, 0, len(weights))
+	for _, component := range sysinfo.HealthComponents {
+		pairs = append(pairs, fmt.Sprintf("%s=%g", component, weights[component]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// formatSettingValue форматирует значение настройки, пустые значения показываются явно
+func formatSettingValue(value interface{}) string {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+
+	text := fmt.Sprint(value)
+	if text == "" {
+		return "(empty)"
+	}
+	return text
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"mcp-system-info/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// maxLoggedStringLen строки длиннее обрезаются в логах
+	maxLoggedStringLen = 256
+	// maxLoggedItems массивы и объекты длиннее сокращаются в логах
+	maxLoggedItems = 20
+	// maxLoggedDepth глубже вложенные значения не раскрываются
+	maxLoggedDepth = 4
+	// redactedArgument подставляется вместо значений секретных аргументов
+	redactedArgument = "[REDACTED]"
+)
+
+// secretArgumentPattern имена аргументов, значения которых не пишутся в лог
+var secretArgumentPattern = regexp.MustCompile(config.DefaultEnvRedactPattern)
+
+// loggableArguments возвращает копию аргументов инструмента, безопасную для логгирования:
+// секреты скрыты, большие значения обрезаны. Типы значений сохраняются, чтобы было видно 30 против "30"
+func loggableArguments(arguments map[string]interface{}) map[string]interface{} {
+	if arguments == nil {
+		return nil
+	}
+	return sanitizeValue(arguments, 0).(map[string]interface{})
+}
+
+// sanitizeValue рекурсивно обрезает значение для лога
+func sanitizeValue(value interface{}, depth int) interface{} {
+	switch v := value.(type) {
+	case string:
+		if len(v) > maxLoggedStringLen {
+			// Обрезаем по границе символа, чтобы в лог не попал разрезанный UTF-8
+			cut := maxLoggedStringLen
+			for cut > 0 && !utf8.RuneStart(v[cut]) {
+				cut--
+			}
+			return fmt.Sprintf("%s...(%d bytes total)", v[:cut], len(v))
+		}
+		return v
+
+	case map[string]interface{}:
+		if depth >= maxLoggedDepth {
+			return fmt.Sprintf("{...%d keys}", len(v))
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if len(result) >= maxLoggedItems {
+				result["..."] = fmt.Sprintf("%d more keys", len(v)-maxLoggedItems)
+				break
+			}
+			if secretArgumentPattern.MatchString(key) {
+				result[key] = redactedArgument
+				continue
+			}
+			result[key] = sanitizeValue(item, depth+1)
+		}
+		return result
+
+	case []interface{}:
+		if depth >= maxLoggedDepth {
+			return fmt.Sprintf("[...%d items]", len(v))
+		}
+		limit := min(len(v), maxLoggedItems)
+		result := make([]interface{}, 0, limit+1)
+		for _, item := range v[:limit] {
+			result = append(result, sanitizeValue(item, depth+1))
+		}
+		if len(v) > limit {
+			result = append(result, fmt.Sprintf("...%d more items", len(v)-limit))
+		}
+		return result
+
+	default:
+		return v
+	}
+}
+
+// fillRequiredArguments дополняет аргументы отсутствующими обязательными параметрами схемы для клиентов
+// с политикой relax_required_args: берется default из схемы, иначе пустое значение типа параметра.
+// Возвращает аргументы и отсортированные имена подставленных параметров
+func fillRequiredArguments(schema mcp.ToolInputSchema, arguments map[string]interface{}) (map[string]interface{}, []string) {
+	var filled []string
+	for _, name := range schema.Required {
+		if _, ok := arguments[name]; ok {
+			continue
+		}
+		if arguments == nil {
+			arguments = make(map[string]interface{}, len(schema.Required))
+		}
+
+		property, _ := schema.Properties[name].(map[string]interface{})
+		arguments[name] = emptyArgumentValue(property)
+		filled = append(filled, name)
+	}
+
+	sort.Strings(filled)
+	return arguments, filled
+}
+
+// emptyArgumentValue значение по умолчанию для параметра схемы инструмента
+func emptyArgumentValue(property map[string]interface{}) interface{} {
+	if value, ok := property["default"]; ok {
+		return value
+	}
+
+	switch property["type"] {
+	case "number", "integer":
+		return float64(0)
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	case "object":
+		return map[string]interface{}{}
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestFillRequiredArguments(t *testing.T) {
+	tool := mcp.NewTool("example",
+		mcp.WithString("name", mcp.Required()),
+		mcp.WithNumber("limit", mcp.Required(), mcp.DefaultNumber(10)),
+		mcp.WithBoolean("exact", mcp.Required()),
+		mcp.WithString("optional"),
+	)
+
+	arguments, filled := fillRequiredArguments(tool.InputSchema, map[string]interface{}{"name": "nginx"})
+
+	want := map[string]interface{}{"name": "nginx", "limit": float64(10), "exact": false}
+	if !reflect.DeepEqual(arguments, want) {
+		t.Fatalf("arguments = %v, want %v", arguments, want)
+	}
+	if !reflect.DeepEqual(filled, []string{"exact", "limit"}) {
+		t.Fatalf("filled = %v, want [exact limit]", filled)
+	}
+
+	arguments, filled = fillRequiredArguments(tool.InputSchema, nil)
+	if arguments["name"] != "" || len(filled) != 3 {
+		t.Fatalf("nil arguments: got %v, filled %v", arguments, filled)
+	}
+}
+
+func TestSanitizeValueTruncatesOnRuneBoundary(t *testing.T) {
+	value := "a" + strings.Repeat("я", maxLoggedStringLen)
+
+	logged := sanitizeValue(value, 0).(string)
+	if !utf8.ValidString(logged) {
+		t.Fatalf("truncated value is not valid UTF-8: %q", logged)
+	}
+	if !strings.HasPrefix(value, strings.Split(logged, "...")[0]) {
+		t.Fatalf("truncated value %q is not a prefix of the original", logged)
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleDeleteSession завершает сессию по заголовку Mcp-Session-Id (DELETE /mcp)
+func (h *FiberMCPHandler) HandleDeleteSession(c *fiber.Ctx) error {
+	sessionID := c.Get("Mcp-Session-Id")
+	sessionLogger := logger.GetSessionLogger(sessionID)
+
+	if sessionID == "" {
+		sessionLogger.Warn().Msg("Session delete request without Mcp-Session-Id")
+		return c.Status(fiber.StatusBadRequest).JSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"error": map[string]interface{}{
+				"code":    -32600,
+				"message": "Mcp-Session-Id header is required",
+			},
+		})
+	}
+
+	if _, exists := h.sessionManager.GetSession(sessionID); !exists {
+		return c.Status(fiber.StatusNotFound).JSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"error": map[string]interface{}{
+				"code":    -32001,
+				"message": "Session not found",
+			},
+		})
+	}
+
+	h.sessionManager.RemoveSession(sessionID)
+	sessionLogger.Info().Msg("Session terminated by client")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler отвечает JSON-RPC ошибкой вместо стандартного текстового ответа Fiber.
+// Сюда же попадают несуществующие маршруты (404) и неподдерживаемые методы (405): отдельный
+// catch-all маршрут не нужен и скрыл бы 405, так как Fiber считает его совпадением
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Internal server error"
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		status = fiberErr.Code
+	}
+
+	switch status {
+	case fiber.StatusNotFound:
+		message = fmt.Sprintf("Cannot %s %s: route not found", c.Method(), c.Path())
+	case fiber.StatusMethodNotAllowed:
+		message = fmt.Sprintf("Cannot %s %s: method not allowed", c.Method(), c.Path())
+	default:
+		if fiberErr != nil {
+			message = fiberErr.Message
+		}
+	}
+
+	code := -32600
+	if status >= fiber.StatusInternalServerError {
+		code = -32603
+		logger.HTTP.Error().
+			Err(err).
+			Str("method", c.Method()).
+			Str("path", c.Path()).
+			Msg("Unhandled request error")
+	}
+
+	return c.Status(status).JSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      nil,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"data": map[string]interface{}{
+				"status": status,
+				"method": c.Method(),
+				"path":   c.Path(),
+			},
+		},
+	})
+}
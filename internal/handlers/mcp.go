@@ -3,12 +3,15 @@ package handlers
 import (
 	"bufio"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
+	"mcp-system-info/internal/config"
 	"mcp-system-info/internal/logger"
 	"mcp-system-info/internal/middleware"
 	"mcp-system-info/internal/sysinfo"
@@ -16,45 +19,164 @@ import (
 	"mcp-system-info/internal/types"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog"
 )
 
 type FiberMCPHandler struct {
-	server               *server.MCPServer
-	sessionManager       *types.SessionManager
-	lastCreatedSessionID sync.Map
+	server         *server.MCPServer
+	sessionManager *types.SessionManager
+	config         *config.Config
+	toolset        []server.ServerTool
+	tools          map[string]server.ServerTool
+	methods        map[string]methodSpec
+	broadcaster    *types.Broadcaster // nil, если METRICS_BROADCAST_INTERVAL=0
+	streamGuard    *tools.StreamGuard // nil, если STREAM_OVERLOAD_CPU_PERCENT=0
+	heavy          *tools.Limiter     // общий с инструментами ограничитель тяжелых выполнений
+	openStreams    atomic.Int64
 }
 
-func NewFiberMCPHandler(server *server.MCPServer, sessionManager *types.SessionManager) *FiberMCPHandler {
+func NewFiberMCPHandler(mcpServer *server.MCPServer, sessionManager *types.SessionManager, cfg *config.Config, toolset []server.ServerTool, heavy *tools.Limiter) *FiberMCPHandler {
 	handler := &FiberMCPHandler{
-		server:         server,
+		server:         mcpServer,
 		sessionManager: sessionManager,
+		config:         cfg,
+		toolset:        toolset,
+		heavy:          heavy,
+		tools:          make(map[string]server.ServerTool, len(toolset)),
+	}
+
+	for _, tool := range toolset {
+		handler.tools[tool.Tool.Name] = tool
+	}
+	handler.methods = handler.newMethodRegistry()
+	handler.streamGuard = tools.NewStreamGuard(cfg.StreamOverloadCPUPercent, cfg.StreamOverloadAction, cfg.StreamOverloadInterval)
+	if cfg.MetricsBroadcastInterval > 0 {
+		handler.broadcaster = types.NewBroadcaster(cfg.MetricsBroadcastInterval, handler.broadcastSample)
 	}
 
 	return handler
 }
 
 func (h *FiberMCPHandler) RegisterRoutes(app *fiber.App) {
-	// Health check endpoint (без авторизации)
-	app.Get("/", h.HandleHealthCheck)
+	basePath := h.config.BasePath
+
+	authConfig := middleware.DefaultAuthConfig()
+	authConfig.APIKey = h.config.APIKey
+	authConfig.AllowQueryAPIKey = h.config.AuthAllowQueryKey
+	authConfig.SkipPaths = []string{h.route("/")}
+	if basePath != "" {
+		authConfig.SkipPaths = append(authConfig.SkipPaths, basePath)
+	}
+	auth := middleware.AuthMiddlewareWithConfig(authConfig)
+
+	// Все маршруты монтируются под BASE_PATH, чтобы сервер работал за reverse proxy без переписывания URL
+	var router fiber.Router = app
+	if basePath != "" {
+		router = app.Group(basePath)
+	}
+
+	// Health check endpoint: проверяет ключ сам по политике HEALTH_AUTH, поэтому без auth middleware
+	router.Get("/", h.HandleHealthCheck)
 
 	// MCP Streamable HTTP endpoints (с авторизацией)
-	mcpGroup := app.Group("/mcp", middleware.AuthMiddleware())
+	mcpGroup := router.Group("/mcp", auth)
 	mcpGroup.Post("/", h.HandleJSONRPC)
 	mcpGroup.Get("/", h.HandleSSE)
+	mcpGroup.Delete("/", h.HandleDeleteSession)
+
+	// Метрики в формате Prometheus (с авторизацией)
+	router.Get("/metrics", auth, h.HandleMetrics)
+	// Системные метрики для InfluxDB отдельно от Prometheus счетчиков сервера
+	router.Get("/metrics/influx", auth, h.HandleInfluxMetrics)
+
+	// Профилирование только при явном включении
+	if h.config.EnablePprof {
+		guard := middleware.LocalhostOnly()
+		if h.config.PprofAccess == "auth" {
+			guard = auth
+		}
+
+		app.Use(h.route("/debug/pprof"), guard)
+		app.Use(pprof.New(pprof.Config{Prefix: basePath}))
+
+		logger.Main.Warn().
+			Str("access", h.config.PprofAccess).
+			Msg("pprof endpoints enabled at " + h.route("/debug/pprof"))
+	}
 }
 
-// HandleHealthCheck простой health check endpoint
+// route возвращает путь маршрута с учетом BASE_PATH
+func (h *FiberMCPHandler) route(path string) string {
+	return h.config.BasePath + path
+}
+
+// HandleHealthCheck простой health check endpoint. Объем ответа зависит от HEALTH_AUTH:
+// в режиме minimal без ключа возвращается только статус, в режиме api_key без ключа - 401
 func (h *FiberMCPHandler) HandleHealthCheck(c *fiber.Ctx) error {
+	switch h.config.HealthAuth {
+	case config.HealthAuthAPIKey:
+		if !h.healthAuthorized(c) {
+			logger.HTTP.Warn().
+				Str("path", c.Path()).
+				Str("remote_ip", c.IP()).
+				Msg("Health check rejected: API key required")
+			middleware.MarkAuthRejected(c)
+
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":   "Unauthorized",
+				"message": "API key required",
+				"code":    "AUTH_INVALID_API_KEY",
+			})
+		}
+	case config.HealthAuthMinimal:
+		if !h.healthAuthorized(c) {
+			return c.JSON(map[string]interface{}{"status": "ok"})
+		}
+	}
+
 	return c.JSON(map[string]interface{}{
-		"status":  "ok",
-		"service": "mcp-system-info",
-		"version": "1.0.0",
-		"message": "MCP endpoints available at /mcp",
+		"status":    "ok",
+		"service":   "mcp-system-info",
+		"version":   "1.0.0",
+		"message":   "MCP endpoints available at " + h.route("/mcp"),
+		"endpoints": h.endpoints(),
 	})
 }
 
+// healthAuthorized проверяет ключ HEALTH_API_KEY в X-API-Key или, если разрешено, в query параметре api_key
+func (h *FiberMCPHandler) healthAuthorized(c *fiber.Ctx) bool {
+	key := c.Get("X-API-Key")
+	if key == "" && h.config.AuthAllowQueryKey {
+		key = c.Query("api_key")
+	}
+	return key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(h.config.HealthAPIKey)) == 1
+}
+
+// endpoints описание HTTP endpoints сервера с учетом BASE_PATH
+func (h *FiberMCPHandler) endpoints() []string {
+	return []string{
+		"GET " + h.route("/") + " (Health Check)",
+		"POST " + h.route("/mcp") + " (JSON-RPC)",
+		"GET " + h.route("/mcp") + " (SSE Stream)",
+		"DELETE " + h.route("/mcp") + " (Terminate Session)",
+	}
+}
+
+// supportedContentTypes форматы ответа POST /mcp: обычный JSON и SSE для streaming инструментов
+var supportedContentTypes = []string{"application/json", "text/event-stream"}
+
+// defaultContentType формат ответа для запросов без Accept или с Accept: */*
+const defaultContentType = "application/json"
+
+// negotiableContentTypes поддерживаемые форматы вместе с нестандартным text/plain для curl
+var negotiableContentTypes = []string{"application/json", "text/event-stream", "text/plain"}
+
+// jsonContentTypes форматы JSON-RPC ответа для запросов без текстового представления
+var jsonContentTypes = []string{"application/json", "text/event-stream"}
+
 // HandleJSONRPC обрабатывает JSON-RPC запросы
 func (h *FiberMCPHandler) HandleJSONRPC(c *fiber.Ctx) error {
 	// Получаем session ID из заголовков
@@ -62,6 +184,18 @@ func (h *FiberMCPHandler) HandleJSONRPC(c *fiber.Ctx) error {
 
 	mcpLogger := logger.GetMCPLogger("unknown", sessionID)
 
+	// Клиент должен принимать хотя бы один из поддерживаемых форматов ответа.
+	// text/plain допускается только для инструментов из plainTextTools и проверяется после разбора запроса,
+	// остальные запросы в этом случае получают JSON, если клиент его принимает.
+	// Отсутствующий Accept означает JSON ответ, как и */*
+	negotiated := defaultContentType
+	if c.Get(fiber.HeaderAccept) != "" {
+		negotiated = c.Accepts(negotiableContentTypes...)
+	}
+	if negotiated == "" {
+		return h.notAcceptable(c, mcpLogger)
+	}
+
 	// Парсим JSON-RPC запрос
 	var request map[string]interface{}
 	if err := json.Unmarshal(c.Body(), &request); err != nil {
@@ -75,27 +209,69 @@ func (h *FiberMCPHandler) HandleJSONRPC(c *fiber.Ctx) error {
 		})
 	}
 
+	if negotiated == "text/plain" && !isPlainTextToolCall(request) {
+		negotiated = c.Accepts(jsonContentTypes...)
+		if negotiated == "" {
+			return h.notAcceptable(c, mcpLogger)
+		}
+	}
+
+	if negotiated == "text/plain" {
+		response, _ := h.handleJSONRPCMessage(request, sessionID)
+		if response == nil {
+			return c.SendStatus(204)
+		}
+		return writePlainTextResponse(c, response)
+	}
+
 	// Проверяем если это streaming tool call и клиент поддерживает SSE
 	if h.isStreamingToolCall(request) && h.clientSupportsSSE(c) {
 		return h.handleStreamingToolCall(c, request, sessionID)
 	}
 
-	// Обрабатываем запрос
-	response := h.handleJSONRPCMessage(request, sessionID)
+	// Обрабатываем запрос. initialize с пустым или неизвестным sessionID создает новую сессию,
+	// ее ID возвращается вместе с ответом именно этого запроса
+	response, requestSessionID := h.handleJSONRPCMessage(request, sessionID)
 	if response == nil {
 		return c.SendStatus(204) // No Content
 	}
 
 	// Устанавливаем session ID в заголовок ответа если был создан новый
-	if sessionID == "" {
-		if storedSessionID, ok := h.lastCreatedSessionID.Load("sessionID"); ok {
-			c.Set("Mcp-Session-Id", storedSessionID.(string))
+	if requestSessionID != sessionID {
+		c.Set("Mcp-Session-Id", requestSessionID)
+	}
+
+	// Тип клиента сохраняется в сессии при initialize, чтобы не определять его заново в каждом логе.
+	// Сессия берется из ответа этого же запроса, поэтому параллельный initialize не может ее подменить
+	if method, _ := request["method"].(string); method == "initialize" {
+		if session, exists := h.sessionManager.GetSession(requestSessionID); exists {
+			if clientType := middleware.ClientType(c); clientType != "" {
+				session.SetClientType(clientType)
+			}
 		}
 	}
 
 	return c.JSON(response)
 }
 
+// notAcceptable отвечает 406 с JSON-RPC ошибкой и списком поддерживаемых форматов
+func (h *FiberMCPHandler) notAcceptable(c *fiber.Ctx, mcpLogger zerolog.Logger) error {
+	mcpLogger.Warn().
+		Str("accept", c.Get("Accept")).
+		Msg("Client does not accept any supported content type")
+	return c.Status(fiber.StatusNotAcceptable).JSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      nil,
+		"error": map[string]interface{}{
+			"code":    -32600,
+			"message": "Not Acceptable: Accept header must allow application/json or text/event-stream",
+			"data": map[string]interface{}{
+				"supported": supportedContentTypes,
+			},
+		},
+	})
+}
+
 // isStreamingToolCall проверяет является ли запрос вызовом streaming tool
 func (h *FiberMCPHandler) isStreamingToolCall(request map[string]interface{}) bool {
 	method, ok := request["method"].(string)
@@ -114,7 +290,7 @@ func (h *FiberMCPHandler) isStreamingToolCall(request map[string]interface{}) bo
 	}
 
 	// Список streaming tools
-	streamingTools := []string{"system_monitor_stream"}
+	streamingTools := []string{"system_monitor_stream", "watch_process", "wait_for_condition"}
 	for _, streamTool := range streamingTools {
 		if toolName == streamTool {
 			return true
@@ -124,14 +300,14 @@ func (h *FiberMCPHandler) isStreamingToolCall(request map[string]interface{}) bo
 	return false
 }
 
-// clientSupportsSSE проверяет поддерживает ли клиент SSE потоки
+// clientSupportsSSE проверяет поддерживает ли клиент SSE потоки. SSE выбирается только при явном
+// text/event-stream в Accept: без заголовка или с */* streaming инструменты отвечают обычным JSON.
+// Политика force_json из CLIENT_POLICIES отключает SSE для клиентов, которые объявляют его, но не разбирают поток
 func (h *FiberMCPHandler) clientSupportsSSE(c *fiber.Ctx) bool {
-	accept := c.Get("Accept", "")
-
-	// Проверяем заголовок Accept на поддержку text/event-stream
-	return accept != "" && (accept == "text/event-stream" ||
-		c.Accepts("text/event-stream") == "text/event-stream" ||
-		strings.Contains(accept, "text/event-stream"))
+	if h.config.ClientPolicy(middleware.ClientType(c)).ForceJSON {
+		return false
+	}
+	return strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
 }
 
 // handleStreamingToolCall обрабатывает streaming tool calls в SSE режиме
@@ -152,6 +328,14 @@ func (h *FiberMCPHandler) handleStreamingToolCall(c *fiber.Ctx, request map[stri
 		return c.Status(400).SendString("event: error\ndata: {\"error\":\"Session not found\"}\n\n")
 	}
 
+	if h.config.RequireInitialized && !session.IsInitialized() {
+		logger.Streamable.Warn().
+			Str("session_id", sessionID).
+			Str("client_type", session.GetClientType()).
+			Msg("Streaming tool call rejected: session is not initialized")
+		return c.Status(400).SendString("event: error\ndata: {\"error\":\"Session not initialized\"}\n\n")
+	}
+
 	// Парсим tool call параметры
 	params, _ := request["params"].(map[string]interface{})
 	toolName, _ := params["name"].(string)
@@ -159,9 +343,52 @@ func (h *FiberMCPHandler) handleStreamingToolCall(c *fiber.Ctx, request map[stri
 	// Получаем request ID для финального ответа
 	requestID := request["id"]
 
+	arguments, _ := params["arguments"].(map[string]interface{})
+	logger.Streamable.Debug().
+		Str("session_id", sessionID).
+		Str("client_type", session.GetClientType()).
+		Str("tool_name", toolName).
+		Interface("arguments", loggableArguments(arguments)).
+		Msg("Streaming tool call arguments")
+
+	// Контекст запроса живет до завершения stream writer и отменяется при остановке сервера
+	ctx := c.Context()
+
+	guarded, err := h.streamGuard.Check(ctx, toolName, arguments)
+	if err != nil {
+		return h.rejectUnavailableStream(c, requestID, err)
+	}
+	if h.streamGuard != nil {
+		params["arguments"] = guarded
+	}
+
+	// Стрим занимает слот тяжелых инструментов на все время работы, как и вызов через WithLimit
+	releaseHeavy, err := h.heavy.AcquireTool(ctx, toolName)
+	if err != nil {
+		return h.rejectUnavailableStream(c, requestID, err)
+	}
+
+	if !h.acquireStream() {
+		releaseHeavy()
+		return h.rejectStream(c, sessionID, requestID)
+	}
+
+	session.StreamStarted()
+	h.emitSessionEvent(session, "tool_call", map[string]interface{}{"tool": toolName, "streaming": true})
+
 	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
-		if toolName == "system_monitor_stream" {
-			h.handleSystemMonitorStream(w, params, session, requestID)
+		defer releaseHeavy()
+		defer h.releaseStream()
+		defer session.StreamFinished()
+		w = h.sseWriter(w)
+
+		switch toolName {
+		case "system_monitor_stream":
+			h.handleSystemMonitorStream(ctx, w, params, session, requestID)
+		case "watch_process":
+			h.handleWatchProcessStream(ctx, w, params, session, requestID)
+		case "wait_for_condition":
+			h.handleWaitForConditionStream(ctx, w, params, session, requestID)
 		}
 	})
 
@@ -169,7 +396,7 @@ func (h *FiberMCPHandler) handleStreamingToolCall(c *fiber.Ctx, request map[stri
 }
 
 // handleSystemMonitorStream выполняет real-time streaming мониторинга системы
-func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[string]interface{}, session *types.Session, requestID interface{}) {
+func (h *FiberMCPHandler) handleSystemMonitorStream(ctx context.Context, w *bufio.Writer, params map[string]interface{}, session *types.Session, requestID interface{}) {
 	logger.Streamable.Info().
 		Str("session_id", session.ID).
 		Msg("Starting real-time system monitor stream")
@@ -180,7 +407,9 @@ func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[
 		arguments = args
 	}
 
-	var durationStr, intervalStr string
+	var durationStr, intervalStr, outputFile string
+	verboseSamples, _ := arguments["verbose_samples"].(bool)
+	align, _ := arguments["align"].(bool)
 	if dur, exists := arguments["duration"]; exists {
 		if durStr, ok := dur.(string); ok {
 			durationStr = durStr
@@ -191,6 +420,11 @@ func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[
 			intervalStr = interStr
 		}
 	}
+	if out, exists := arguments["output_file"]; exists {
+		if outStr, ok := out.(string); ok {
+			outputFile = outStr
+		}
+	}
 
 	if durationStr == "" {
 		durationStr = "30s"
@@ -201,31 +435,112 @@ func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[
 
 	duration, err := time.ParseDuration(durationStr)
 	if err != nil {
-		fmt.Fprintf(w, "event: error\n")
-		fmt.Fprintf(w, "data: {\"error\":\"Invalid duration format: %v\"}\n\n", err)
-		w.Flush()
+		writeSSEInvalidParams(w, requestID, fmt.Sprintf("Invalid duration format: %v", err))
 		return
 	}
 
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
-		fmt.Fprintf(w, "event: error\n")
-		fmt.Fprintf(w, "data: {\"error\":\"Invalid interval format: %v\"}\n\n", err)
-		w.Flush()
+		writeSSEInvalidParams(w, requestID, fmt.Sprintf("Invalid interval format: %v", err))
+		return
+	}
+
+	err = tools.ValidateSampleCount(duration, interval, h.config.MonitorMaxSamples)
+	if err == nil && duration > h.config.SSEMaxDuration {
+		// Иначе стрим был бы молча оборван по SSE_MAX_DURATION раньше запрошенного времени
+		err = fmt.Errorf("duration %v exceeds the server's SSE_MAX_DURATION %v", duration, h.config.SSEMaxDuration)
+	}
+	if err != nil {
+		logger.Streamable.Warn().
+			Err(err).
+			Str("session_id", session.ID).
+			Dur("duration", duration).
+			Dur("interval", interval).
+			Msg("Rejected monitor stream window")
+
+		writeSSEInvalidParams(w, requestID, fmt.Sprintf("Invalid monitoring window: %v", err))
+		return
+	}
+
+	timestampFormat, _ := arguments["timestamp_format"].(string)
+	timestampFormat, err = tools.ParseTimestampFormat(timestampFormat, h.config.MonitorTimestampFormat)
+	if err != nil {
+		writeSSEInvalidParams(w, requestID, err.Error())
 		return
 	}
 
-	// Отправляем начальную JSON-RPC notification
-	fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"phase\":\"start\",\"duration\":\"%v\",\"interval\":\"%v\"}}\n\n", duration, interval)
+	// Файл сэмплов является побочным артефактом, стрим отправляется как обычно
+	var sampleWriter *tools.SampleWriter
+	if outputFile != "" {
+		sampleWriter, err = tools.OpenSampleWriter(h.config.MonitorOutputDir, outputFile)
+		if err != nil {
+			logger.Streamable.Error().
+				Err(err).
+				Str("session_id", session.ID).
+				Str("output_file", outputFile).
+				Msg("Invalid output file")
+
+			writeSSEInvalidParams(w, requestID, fmt.Sprintf("Invalid output_file: %v", err))
+			return
+		}
+		defer sampleWriter.Close()
+	}
+
+	// Отправляем начальную JSON-RPC notification. Статические данные хоста передаются один раз здесь,
+	// а в сэмплах только меняющиеся значения (если клиент не запросил verbose_samples)
+	fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"phase\":\"start\",\"duration\":\"%v\",\"interval\":\"%v\"", duration, interval)
+	if host, err := sysinfo.GetHostSummary(ctx); err == nil {
+		hostJSON, _ := json.Marshal(host)
+		fmt.Fprintf(w, ",\"host\":%s", hostJSON)
+	}
+	fmt.Fprintf(w, "}}\n\n")
 	w.Flush()
 
+	// С align тикер запускается на границе интервала, и все сэмплы попадают на границы по часам
+	if align && !tools.WaitAligned(ctx, interval) {
+		return
+	}
+
 	endTime := time.Now().Add(duration)
+	// Своя база steal: каждый сэмпл покрывает интервал стрима, а не окно с чужого вызова
+	steal := sysinfo.NewStealBaseline()
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	// Абсолютное ограничение времени жизни соединения независимо от запрошенной длительности
+	maxLifetime := time.NewTimer(h.config.SSEMaxDuration)
+	defer maxLifetime.Stop()
+
+	clock := tools.NewSampleClock(time.Now())
 	iteration := 0
 	for {
 		select {
+		case <-ctx.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Request context cancelled, stopping stream early")
+			return
+
+		case <-session.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Session terminated, stopping stream")
+			writeSSEClose(w, "session_deleted")
+			return
+
+		case <-maxLifetime.C:
+			logger.Streamable.Warn().
+				Str("session_id", session.ID).
+				Dur("max_duration", h.config.SSEMaxDuration).
+				Int("total_samples", iteration).
+				Msg("Stream exceeded max connection lifetime, closing")
+
+			h.writeSSEResult(w, requestID, map[string]interface{}{"status": "max_duration_exceeded", "total_samples": iteration})
+			writeSSEClose(w, "max_duration_exceeded")
+			return
+
 		case <-ticker.C:
 			if time.Now().After(endTime) {
 				logger.Streamable.Info().
@@ -233,22 +548,14 @@ func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[
 					Msg("Stream duration completed")
 
 				// Отправляем финальный JSON-RPC response
-				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":")
-				if requestID != nil {
-					jsonBytes, _ := json.Marshal(requestID)
-					fmt.Fprintf(w, "%s", string(jsonBytes))
-				} else {
-					fmt.Fprintf(w, "null")
-				}
-				fmt.Fprintf(w, ",\"result\":{\"status\":\"completed\",\"total_samples\":%d}}\n\n", iteration)
-				w.Flush()
+				h.writeSSEResult(w, requestID, map[string]interface{}{"status": "completed", "total_samples": iteration})
 				return
 			}
 
 			iteration++
 
 			// Получаем системную информацию
-			sysInfo, err := sysinfo.Get()
+			sysInfo, err := sysinfo.GetWithOptions(sysinfo.Options{Steal: steal})
 			if err != nil {
 				logger.Streamable.Error().
 					Err(err).
@@ -256,32 +563,64 @@ func (h *FiberMCPHandler) handleSystemMonitorStream(w *bufio.Writer, params map[
 					Int("iteration", iteration).
 					Msg("Failed to get system info during stream")
 
-				// Отправляем JSON-RPC notification об ошибке
-				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"error\":\"%v\"}}\n\n", iteration, err)
-				w.Flush()
+				// Отправляем JSON-RPC notification об ошибке, текст экранируется как JSON-строка
+				errJSON, _ := json.Marshal(err.Error())
+				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"error\":%s}}\n\n", iteration, errJSON)
+				if err := w.Flush(); err != nil {
+					logger.Streamable.Info().
+						Err(err).
+						Str("session_id", session.ID).
+						Int("total_samples", iteration).
+						Msg("Client disconnected, stopping stream early")
+					return
+				}
 				continue
 			}
+			actualInterval := clock.Mark(time.Now())
+
+			if sampleWriter != nil {
+				if err := sampleWriter.Write(tools.NewSample(iteration, sysInfo, actualInterval)); err != nil {
+					logger.Streamable.Error().
+						Err(err).
+						Str("session_id", session.ID).
+						Int("iteration", iteration).
+						Msg("Failed to write sample to output file")
+				}
+			}
 
 			// 🚀 ОТПРАВЛЯЕМ ДАННЫЕ В РЕАЛЬНОМ ВРЕМЕНИ как JSON-RPC notification!
-			timestamp := time.Now().Format("15:04:05")
+			timestamp := tools.FormatTimestamp(time.Now(), timestampFormat)
 			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{")
 			fmt.Fprintf(w, "\"iteration\":%d,", iteration)
 			fmt.Fprintf(w, "\"timestamp\":\"%s\",", timestamp)
+			fmt.Fprintf(w, "\"actual_interval_ms\":%.3f,", tools.Milliseconds(actualInterval))
 			fmt.Fprintf(w, "\"cpu\":%.2f,", sysInfo.CPU.UsagePercent)
-			fmt.Fprintf(w, "\"memory\":%.2f", sysInfo.Memory.UsedPercent)
+			fmt.Fprintf(w, "\"memory\":%.2f,", sysInfo.Memory.UsedPercent)
+			fmt.Fprintf(w, "\"memory_used\":%d", sysInfo.Memory.Used)
+			if verboseSamples {
+				modelJSON, _ := json.Marshal(sysInfo.CPU.ModelName)
+				fmt.Fprintf(w, ",\"cpu_model\":%s,", modelJSON)
+				fmt.Fprintf(w, "\"cpu_count\":%d,", sysInfo.CPU.Count)
+				fmt.Fprintf(w, "\"memory_total\":%d", sysInfo.Memory.Total)
+			}
 			fmt.Fprintf(w, "}}\n\n")
-			w.Flush() // 🔥 НЕМЕДЛЕННАЯ ОТПРАВКА!
+			// 🔥 НЕМЕДЛЕННАЯ ОТПРАВКА! Ошибка записи означает что клиент отключился
+			if err := w.Flush(); err != nil {
+				logger.Streamable.Info().
+					Err(err).
+					Str("session_id", session.ID).
+					Int("total_samples", iteration).
+					Msg("Client disconnected, stopping stream early")
+				return
+			}
 
 			logger.Streamable.Debug().
 				Str("session_id", session.ID).
 				Int("iteration", iteration).
+				Dur("actual_interval", actualInterval).
 				Float64("cpu_usage", sysInfo.CPU.UsagePercent).
 				Float64("memory_usage", sysInfo.Memory.UsedPercent).
 				Msg("Sample sent via SSE")
-
-		default:
-			// Проверяем не закрыто ли соединение
-			time.Sleep(10 * time.Millisecond)
 		}
 	}
 }
@@ -296,6 +635,10 @@ func (h *FiberMCPHandler) HandleSSE(c *fiber.Ctx) error {
 
 		sessionID := c.Get("Mcp-Session-Id", "")
 
+		if !h.acquireStream() {
+			return h.rejectStream(c, sessionID, nil)
+		}
+
 		logger.SSE.Info().
 			Str("session_id", sessionID).
 			Str("accept", accept).
@@ -307,21 +650,123 @@ func (h *FiberMCPHandler) HandleSSE(c *fiber.Ctx) error {
 		c.Set("Connection", "keep-alive")
 		c.Set("Access-Control-Allow-Origin", "*")
 
-		// TODO: Реализовать SSE stream
+		// Уведомления сессии доставляются клиенту пока поток открыт
+		var session *types.Session
+		var notifications <-chan interface{}
+		var sessionDone <-chan struct{}
+		if sessionID != "" {
+			if existing, exists := h.sessionManager.GetSession(sessionID); exists {
+				session = existing
+				notifications = session.Notifications()
+				sessionDone = session.Done()
+			}
+		}
+
+		ctx := c.Context()
+		// Клиент, переподключающийся после обрыва, получает события после последнего принятого
+		lastEventID := c.Get("Last-Event-Id")
+
+		if session != nil {
+			session.StreamStarted()
+		}
+
+		// С ?metrics=true сессия получает сэмплы общего сэмплера: N клиентов стоят одного сбора метрик
+		subscribed := session != nil && h.broadcaster != nil && c.QueryBool("metrics")
+		if subscribed {
+			h.broadcaster.Subscribe(session)
+		}
+
 		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			// Слот освобождается при любом завершении потока, включая панику
+			defer h.releaseStream()
+			if session != nil {
+				defer session.StreamFinished()
+			}
+			if subscribed {
+				defer h.broadcaster.Unsubscribe(session)
+			}
+
+			// Событие закрытия попадет в буфер и будет доставлено при следующем подключении
+			closeReason := "client_disconnected"
+			defer func() {
+				h.emitSessionEvent(session, "stream_closed", map[string]interface{}{"reason": closeReason})
+			}()
+			h.emitSessionEvent(session, "stream_opened", nil)
+
 			logger.SSE.Debug().Msg("SSE stream writer started")
+			w = h.sseWriter(w)
 
-			// Отправляем initial event
+			// Отправляем initial event вместе с уведомлениями, накопленными до подключения, одним Flush
 			fmt.Fprintf(w, "event: message\n")
 			fmt.Fprintf(w, "data: {\"type\":\"connected\"}\n\n")
-			w.Flush()
-
-			// Держим соединение открытым
-			select {
-			case <-c.Context().Done():
-				logger.SSE.Debug().Msg("SSE stream closed by client")
-			case <-time.After(30 * time.Second):
-				logger.SSE.Debug().Msg("SSE stream timeout")
+			resumed := 0
+			if session != nil && lastEventID != "" {
+				resumed = writeResumedEvents(w, session, lastEventID)
+			}
+			replayed := writeQueuedNotifications(w, notifications, session, sessionID)
+			if err := w.Flush(); err != nil {
+				logger.SSE.Debug().
+					Err(err).
+					Str("session_id", sessionID).
+					Msg("SSE client disconnected")
+				return
+			}
+			for range replayed {
+				session.MarkDelivered()
+			}
+			if resumed > 0 || replayed > 0 {
+				logger.SSE.Debug().
+					Str("session_id", sessionID).
+					Int("resumed", resumed).
+					Int("replayed", replayed).
+					Msg("Replayed queued session notifications")
+			}
+
+			// Держим соединение открытым, но не дольше максимального времени жизни
+			timeout, reason := 30*time.Second, "timeout"
+			if h.config.SSEMaxDuration < timeout {
+				timeout, reason = h.config.SSEMaxDuration, "max_duration_exceeded"
+			}
+
+			deadline := time.NewTimer(timeout)
+			defer deadline.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					logger.SSE.Debug().Msg("SSE stream closed by client")
+					return
+
+				case <-sessionDone:
+					logger.SSE.Debug().
+						Str("session_id", sessionID).
+						Msg("Session terminated, closing SSE stream")
+					closeReason = "session_deleted"
+					writeSSEClose(w, closeReason)
+					return
+
+				case <-deadline.C:
+					logger.SSE.Debug().
+						Str("reason", reason).
+						Dur("timeout", timeout).
+						Msg("SSE stream timeout")
+					closeReason = reason
+					writeSSEClose(w, reason)
+					return
+
+				case message := <-notifications:
+					if !writeSSENotification(w, message, session, sessionID) {
+						continue
+					}
+					if err := w.Flush(); err != nil {
+						logger.SSE.Debug().
+							Err(err).
+							Str("session_id", sessionID).
+							Msg("SSE client disconnected")
+						return
+					}
+					session.MarkDelivered()
+				}
 			}
 		})
 
@@ -334,15 +779,254 @@ func (h *FiberMCPHandler) HandleSSE(c *fiber.Ctx) error {
 		"version":       "1.0.0",
 		"protocol":      "MCP Streamable HTTP",
 		"specification": "2025-03-26",
-		"endpoints": []string{
-			"GET / (Health Check)",
-			"POST /mcp (JSON-RPC)",
-			"GET /mcp (SSE Stream)",
+		"endpoints":     h.endpoints(),
+	})
+}
+
+// handleWatchProcessStream стримит сэмплы одного процесса до его завершения или истечения max_duration
+func (h *FiberMCPHandler) handleWatchProcessStream(ctx context.Context, w *bufio.Writer, params map[string]interface{}, session *types.Session, requestID interface{}) {
+	arguments, _ := params["arguments"].(map[string]interface{})
+	args, err := tools.ParseWatchProcessArgs(arguments, h.config.MonitorMaxSamples, h.config.MonitorTimestampFormat)
+	if err == nil && args.MaxDuration > h.config.SSEMaxDuration {
+		err = fmt.Errorf("max_duration %v exceeds the server's SSE_MAX_DURATION %v", args.MaxDuration, h.config.SSEMaxDuration)
+	}
+	if err != nil {
+		logger.Streamable.Warn().
+			Err(err).
+			Str("session_id", session.ID).
+			Msg("Rejected watch_process arguments")
+		writeSSEInvalidParams(w, requestID, err.Error())
+		return
+	}
+
+	logger.Streamable.Info().
+		Str("session_id", session.ID).
+		Int32("pid", args.PID).
+		Dur("max_duration", args.MaxDuration).
+		Dur("interval", args.Interval).
+		Msg("Starting process watch stream")
+
+	watcher, err := sysinfo.NewProcessWatcher(ctx, args.PID)
+	if errors.Is(err, sysinfo.ErrProcessExited) {
+		h.writeSSEResult(w, requestID, map[string]interface{}{"status": "already_exited", "pid": args.PID, "total_samples": 0})
+		return
+	}
+	if err != nil {
+		writeSSEInvalidParams(w, requestID, fmt.Sprintf("Error watching process %d: %v", args.PID, err))
+		return
+	}
+
+	nameJSON, _ := json.Marshal(watcher.Name())
+	fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"phase\":\"start\",\"pid\":%d,\"name\":%s,\"max_duration\":\"%v\",\"interval\":\"%v\"}}\n\n",
+		args.PID, nameJSON, args.MaxDuration, args.Interval)
+	w.Flush()
+
+	endTime := time.Now().Add(args.MaxDuration)
+	ticker := time.NewTicker(args.Interval)
+	defer ticker.Stop()
+
+	iteration := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Request context cancelled, stopping process watch")
+			return
+
+		case <-session.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Session terminated, stopping process watch")
+			writeSSEClose(w, "session_deleted")
+			return
+
+		case <-ticker.C:
+			if time.Now().After(endTime) {
+				h.writeSSEResult(w, requestID, map[string]interface{}{"status": "max_duration_reached", "pid": args.PID, "total_samples": iteration})
+				return
+			}
+
+			sample, err := watcher.Sample(ctx)
+			if errors.Is(err, sysinfo.ErrProcessExited) {
+				logger.Streamable.Info().
+					Str("session_id", session.ID).
+					Int32("pid", args.PID).
+					Int("total_samples", iteration).
+					Msg("Watched process exited")
+				h.writeSSEResult(w, requestID, map[string]interface{}{"status": "exited", "pid": args.PID, "total_samples": iteration})
+				return
+			}
+
+			iteration++
+			if err != nil {
+				errJSON, _ := json.Marshal(err.Error())
+				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"error\":%s}}\n\n", iteration, errJSON)
+				w.Flush()
+				continue
+			}
+
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"timestamp\":\"%s\",\"pid\":%d,\"cpu\":%.2f,\"rss\":%d,\"memory\":%.2f}}\n\n",
+				iteration, tools.FormatTimestamp(time.Now(), args.TimestampFormat), sample.PID, sample.CPUPercent, sample.RSS, sample.MemoryPercent)
+			if err := w.Flush(); err != nil {
+				logger.Streamable.Info().
+					Err(err).
+					Str("session_id", session.ID).
+					Int("total_samples", iteration).
+					Msg("Client disconnected, stopping process watch")
+				return
+			}
+		}
+	}
+}
+
+// handleWaitForConditionStream стримит прогресс ожидания, пока условие не продержится sustain или не истечет timeout
+func (h *FiberMCPHandler) handleWaitForConditionStream(ctx context.Context, w *bufio.Writer, params map[string]interface{}, session *types.Session, requestID interface{}) {
+	arguments, _ := params["arguments"].(map[string]interface{})
+	args, err := tools.ParseWaitForConditionArgs(arguments, h.config.MonitorMaxSamples, h.config.MonitorTimestampFormat)
+	if err == nil && args.Timeout > h.config.SSEMaxDuration {
+		err = fmt.Errorf("timeout %v exceeds the server's SSE_MAX_DURATION %v", args.Timeout, h.config.SSEMaxDuration)
+	}
+	if err != nil {
+		logger.Streamable.Warn().
+			Err(err).
+			Str("session_id", session.ID).
+			Msg("Rejected wait_for_condition arguments")
+		writeSSEInvalidParams(w, requestID, err.Error())
+		return
+	}
+
+	logger.Streamable.Info().
+		Str("session_id", session.ID).
+		Str("condition", args.String()).
+		Dur("timeout", args.Timeout).
+		Dur("interval", args.Interval).
+		Msg("Starting condition wait stream")
+
+	fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"phase\":\"start\",\"metric\":\"%s\",\"operator\":\"%s\",\"threshold\":%g,\"sustain\":\"%v\",\"timeout\":\"%v\",\"interval\":\"%v\"}}\n\n",
+		args.Metric, args.Operator, args.Threshold, args.Sustain, args.Timeout, args.Interval)
+	w.Flush()
+
+	tracker := tools.NewConditionTracker(args)
+	endTime := time.Now().Add(args.Timeout)
+	steal := sysinfo.NewStealBaseline()
+	ticker := time.NewTicker(args.Interval)
+	defer ticker.Stop()
+
+	iteration := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Request context cancelled, stopping condition wait")
+			return
+
+		case <-session.Done():
+			logger.Streamable.Info().
+				Str("session_id", session.ID).
+				Int("total_samples", iteration).
+				Msg("Session terminated, stopping condition wait")
+			writeSSEClose(w, "session_deleted")
+			return
+
+		case <-ticker.C:
+			if time.Now().After(endTime) {
+				logger.Streamable.Info().
+					Str("session_id", session.ID).
+					Str("condition", args.String()).
+					Int("total_samples", iteration).
+					Msg("Condition wait timed out")
+				h.writeSSEResult(w, requestID, map[string]interface{}{"status": "timeout", "condition": args.String(), "total_samples": iteration})
+				return
+			}
+
+			iteration++
+			info, err := sysinfo.GetWithOptions(sysinfo.Options{Steal: steal})
+			if err != nil {
+				errJSON, _ := json.Marshal(err.Error())
+				fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"error\":%s}}\n\n", iteration, errJSON)
+				w.Flush()
+				continue
+			}
+			value, ok := tools.ConditionMetricValue(info, args.Metric)
+			if !ok {
+				writeSSEInvalidParams(w, requestID, fmt.Sprintf("Metric %s is not available on this platform", args.Metric))
+				return
+			}
+
+			now := time.Now()
+			holding, held, triggered := tracker.Observe(value, now)
+			if triggered {
+				logger.Streamable.Info().
+					Str("session_id", session.ID).
+					Str("condition", args.String()).
+					Time("since", tracker.Since()).
+					Int("total_samples", iteration).
+					Msg("Condition sustained")
+				h.writeSSEResult(w, requestID, map[string]interface{}{
+					"status":        "triggered",
+					"condition":     args.String(),
+					"value":         value,
+					"since":         tools.FormatTimestamp(tracker.Since(), args.TimestampFormat),
+					"triggered_at":  tools.FormatTimestamp(now, args.TimestampFormat),
+					"total_samples": iteration,
+				})
+				return
+			}
+
+			fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"method\":\"tool_progress\",\"params\":{\"iteration\":%d,\"timestamp\":\"%s\",\"value\":%.2f,\"holding\":%t,\"held\":\"%v\"}}\n\n",
+				iteration, tools.FormatTimestamp(now, args.TimestampFormat), value, holding, held.Round(time.Second))
+			if err := w.Flush(); err != nil {
+				logger.Streamable.Info().
+					Err(err).
+					Str("session_id", session.ID).
+					Int("total_samples", iteration).
+					Msg("Client disconnected, stopping condition wait")
+				return
+			}
+		}
+	}
+}
+
+// writeSSEResult отправляет финальный JSON-RPC ответ streaming вызова
+func (h *FiberMCPHandler) writeSSEResult(w *bufio.Writer, requestID interface{}, result map[string]interface{}) {
+	h.stampSchemaVersion(result)
+	response, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      requestID,
+		"result":  result,
+	})
+	fmt.Fprintf(w, "data: %s\n\n", response)
+	w.Flush()
+}
+
+// writeSSEInvalidParams отправляет JSON-RPC ошибку -32602 в ответ на streaming вызов с некорректными аргументами
+func writeSSEInvalidParams(w *bufio.Writer, requestID interface{}, message string) {
+	response, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      requestID,
+		"error": map[string]interface{}{
+			"code":    -32602,
+			"message": message,
 		},
 	})
+	fmt.Fprintf(w, "event: message\n")
+	fmt.Fprintf(w, "data: %s\n\n", response)
+	w.Flush()
+}
+
+// writeSSEClose отправляет финальное событие закрытия SSE потока
+func writeSSEClose(w *bufio.Writer, reason string) {
+	fmt.Fprintf(w, "event: close\n")
+	fmt.Fprintf(w, "data: {\"reason\":\"%s\"}\n\n", reason)
+	w.Flush()
 }
 
-func (h *FiberMCPHandler) handleJSONRPCMessage(request map[string]interface{}, sessionID string) map[string]interface{} {
+func (h *FiberMCPHandler) handleJSONRPCMessage(request map[string]interface{}, sessionID string) (map[string]interface{}, string) {
 	mcpLogger := logger.GetMCPLogger("unknown", sessionID)
 
 	method, hasMethod := request["method"].(string)
@@ -358,126 +1042,122 @@ func (h *FiberMCPHandler) handleJSONRPCMessage(request map[string]interface{}, s
 
 	if !hasMethod {
 		mcpLogger.Warn().Msg("Request missing method field")
-		return nil
-	}
-
-	if method == "initialize" {
-		mcpLogger.Info().Msg("Handling initialize request")
-		return h.handleInitializeRequest(request)
-	}
-
-	// Обрабатываем notifications/initialized до проверки сессии, так как эта нотификация
-	// может прийти сразу после initialize и использовать последний созданный sessionID
-	if method == "notifications/initialized" {
-		mcpLogger.Debug().Msg("Handling notifications/initialized notification")
-		return h.handleInitializedNotification(request, sessionID)
+		return nil, sessionID
 	}
 
-	session, exists := h.sessionManager.GetSession(sessionID)
-	if !exists {
-		mcpLogger.Warn().Msg("Session not found")
+	spec, known := h.methods[method]
+	if !known {
+		mcpLogger.Warn().Str("method", method).Msg("Unknown method")
 		if hasID {
 			return map[string]interface{}{
 				"jsonrpc": "2.0",
 				"id":      id,
 				"error": map[string]interface{}{
-					"code":    -32001,
-					"message": "Session not found",
+					"code":    -32601,
+					"message": "Method not found",
 				},
-			}
+			}, sessionID
 		}
-		return nil
+		return nil, sessionID
 	}
 
-	switch method {
-	case "tools/list":
-		if !hasID {
-			mcpLogger.Warn().Msg("tools/list request missing id field")
-			return nil
-		}
-		mcpLogger.Debug().Msg("Handling tools/list request")
-		return h.handleToolsListRequest(request, session)
-
-	case "tools/call":
-		if !hasID {
-			mcpLogger.Warn().Msg("tools/call request missing id field")
-			return nil
-		}
-		mcpLogger.Debug().Msg("Handling tools/call request")
-		return h.handleToolCallRequest(request, session)
+	if spec.idRequired && !hasID {
+		mcpLogger.Warn().Msg("Request missing id field")
+		return nil, sessionID
+	}
 
-	default:
-		mcpLogger.Warn().Str("method", method).Msg("Unknown method")
-		if hasID {
-			return map[string]interface{}{
-				"jsonrpc": "2.0",
-				"id":      id,
-				"error": map[string]interface{}{
-					"code":    -32601,
-					"message": "Method not found",
-				},
+	var session *types.Session
+	if spec.sessionRequired {
+		var exists bool
+		session, exists = h.sessionManager.GetSession(sessionID)
+		if !exists {
+			mcpLogger.Warn().Msg("Session not found")
+			if hasID {
+				return map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      id,
+					"error": map[string]interface{}{
+						"code":    -32001,
+						"message": "Session not found",
+					},
+				}, sessionID
 			}
+			return nil, sessionID
 		}
-		return nil
 	}
+
+	mcpLogger.Debug().Msg("Handling request")
+	return spec.handle(request, sessionID, session)
 }
 
-func (h *FiberMCPHandler) handleInitializeRequest(request map[string]interface{}) map[string]interface{} {
+// handleInitializeRequest переиспользует переданную клиентом сессию, если она существует,
+// иначе создает новую. Это исключает утечку сессий при повторной инициализации.
+// Возвращает ответ и ID сессии, который вызывающий передает клиенту в Mcp-Session-Id
+func (h *FiberMCPHandler) handleInitializeRequest(request map[string]interface{}, providedSessionID string) (map[string]interface{}, string) {
 	id := request["id"]
+	params, _ := request["params"].(map[string]interface{})
+	protocolVersion, _ := params["protocolVersion"].(string)
 
-	sessionID := h.sessionManager.CreateSession()
+	sessionID := providedSessionID
+	if session, exists := h.sessionManager.GetSession(providedSessionID); providedSessionID != "" && exists {
+		logger.Session.Info().
+			Str("session_id", sessionID).
+			Msg("Reusing existing session for initialize")
+		h.emitSessionEvent(session, "reinitialized", nil)
+	} else {
+		sessionID = h.sessionManager.CreateSession()
 
-	logger.Session.Info().
-		Str("session_id", sessionID).
-		Msg("Created new session")
+		logger.Session.Info().
+			Str("session_id", sessionID).
+			Str("provided_session_id", providedSessionID).
+			Msg("Created new session")
 
-	h.lastCreatedSessionID.Store("sessionID", sessionID)
+		if session, exists := h.sessionManager.GetSession(sessionID); exists {
+			h.emitSessionEvent(session, "created", nil)
+		}
+	}
 
 	logger.Session.Info().
 		Str("session_id", sessionID).
 		Msg("Initialize response prepared")
 
+	serverInfo := map[string]interface{}{
+		"name":    "mcp-system-info",
+		"version": "1.0.0",
+	}
+	if h.config.ServerEnvironment != "" {
+		serverInfo["environment"] = h.config.ServerEnvironment
+	}
+
+	result := map[string]interface{}{
+		"protocolVersion": negotiateProtocolVersion(protocolVersion),
+		"capabilities": map[string]interface{}{
+			"tools":   map[string]interface{}{},
+			"logging": map[string]interface{}{},
+		},
+		"serverInfo": serverInfo,
+	}
+	h.stampSchemaVersion(result)
+	if h.config.ServerInstructions != "" {
+		result["instructions"] = h.config.ServerInstructions
+	}
+
 	return map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      id,
-		"result": map[string]interface{}{
-			"protocolVersion": "2024-11-05",
-			"capabilities": map[string]interface{}{
-				"tools": map[string]interface{}{},
-			},
-			"serverInfo": map[string]interface{}{
-				"name":    "mcp-system-info",
-				"version": "1.0.0",
-			},
-		},
-	}
+		"result":  result,
+	}, sessionID
 }
 
 func (h *FiberMCPHandler) handleInitializedNotification(request map[string]interface{}, sessionID string) map[string]interface{} {
 	mcpLogger := logger.GetMCPLogger("notifications/initialized", sessionID)
 
-	// Находим сессию (обычно это последняя созданная сессия при инициализации)
+	// Клиент передает Mcp-Session-Id, полученный в ответе initialize
 	session, exists := h.sessionManager.GetSession(sessionID)
 	if !exists {
-		// Пробуем использовать последнюю созданную сессию если переданный sessionID не найден
-		if lastSessionIDValue, ok := h.lastCreatedSessionID.Load("sessionID"); ok {
-			if lastSessionID, ok := lastSessionIDValue.(string); ok {
-				session, exists = h.sessionManager.GetSession(lastSessionID)
-				if exists {
-					mcpLogger.Debug().
-						Str("provided_session_id", sessionID).
-						Str("actual_session_id", lastSessionID).
-						Msg("Using last created session for notifications/initialized")
-					sessionID = lastSessionID
-				}
-			}
-		}
-
-		if !exists {
-			mcpLogger.Error().
-				Msg("Received notifications/initialized but no session exists - protocol violation")
-			return nil
-		}
+		mcpLogger.Error().
+			Msg("Received notifications/initialized but no session exists - protocol violation")
+		return nil
 	}
 
 	// Проверяем что сессия еще не была инициализирована (защита от дублирующих нотификаций)
@@ -490,6 +1170,7 @@ func (h *FiberMCPHandler) handleInitializedNotification(request map[string]inter
 
 	// Устанавливаем флаг инициализации
 	session.SetInitialized()
+	h.emitSessionEvent(session, "initialized", nil)
 
 	mcpLogger.Info().
 		Str("session_id", sessionID).
@@ -499,6 +1180,37 @@ func (h *FiberMCPHandler) handleInitializedNotification(request map[string]inter
 	return nil
 }
 
+func (h *FiberMCPHandler) handleSetLevelRequest(request map[string]interface{}, session *types.Session) map[string]interface{} {
+	id := request["id"]
+	mcpLogger := logger.GetMCPLogger("logging/setLevel", session.ID)
+
+	params, _ := request["params"].(map[string]interface{})
+	levelStr, _ := params["level"].(string)
+
+	level, ok := logger.ParseMCPLevel(levelStr)
+	if !ok {
+		mcpLogger.Warn().
+			Str("level", levelStr).
+			Msg("Unknown log level requested")
+		return map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"error": map[string]interface{}{
+				"code":    -32602,
+				"message": fmt.Sprintf("Invalid params: unknown log level %q", levelStr),
+			},
+		}
+	}
+
+	setGlobalLogLevel(mcpLogger, levelStr, level)
+
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  map[string]interface{}{},
+	}
+}
+
 func (h *FiberMCPHandler) handleToolsListRequest(request map[string]interface{}, session *types.Session) map[string]interface{} {
 	id := request["id"]
 
@@ -507,50 +1219,37 @@ func (h *FiberMCPHandler) handleToolsListRequest(request map[string]interface{},
 		Msg("Listing available tools")
 
 	// Возвращаем список всех зарегистрированных инструментов
+	toolList := make([]mcp.Tool, 0, len(h.toolset))
+	for _, tool := range h.toolset {
+		toolList = append(toolList, tool.Tool)
+	}
+
 	return map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      id,
 		"result": map[string]interface{}{
-			"tools": []map[string]interface{}{
-				{
-					"name":        "get_system_info",
-					"description": "Gets system information: CPU and memory",
-					"inputSchema": map[string]interface{}{
-						"type": "object",
-						"properties": map[string]interface{}{
-							"random_string": map[string]interface{}{
-								"type":        "string",
-								"description": "Dummy parameter for no-parameter tools",
-							},
-						},
-						"required": []string{"random_string"},
-					},
-				},
-				{
-					"name":        "system_monitor_stream",
-					"description": "Streams real-time system information: CPU and memory monitoring",
-					"inputSchema": map[string]interface{}{
-						"type": "object",
-						"properties": map[string]interface{}{
-							"duration": map[string]interface{}{
-								"type":        "string",
-								"description": "Monitoring duration (e.g., '30s', '5m')",
-							},
-							"interval": map[string]interface{}{
-								"type":        "string",
-								"description": "Update interval (e.g., '1s', '2s')",
-							},
-						},
-						"required": []string{},
-					},
-				},
-			},
+			"tools": toolList,
 		},
 	}
 }
 
 func (h *FiberMCPHandler) handleToolCallRequest(request map[string]interface{}, session *types.Session) map[string]interface{} {
 	id := request["id"]
+
+	// При REQUIRE_INITIALIZED вызов инструментов разрешен только после notifications/initialized
+	if h.config.RequireInitialized && !session.IsInitialized() {
+		logger.Tools.Warn().
+			Str("session_id", session.ID).
+			Msg("Tool call rejected: session is not initialized")
+		return map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"error": map[string]interface{}{
+				"code":    -32002,
+				"message": "Session not initialized: send notifications/initialized before tools/call",
+			},
+		}
+	}
 	params, ok := request["params"].(map[string]interface{})
 	if !ok {
 		logger.Tools.Warn().
@@ -586,105 +1285,111 @@ func (h *FiberMCPHandler) handleToolCallRequest(request map[string]interface{},
 		Str("tool_name", toolName).
 		Msg("Executing tool")
 
-	if toolName == "get_system_info" {
-		sysInfo, err := sysinfo.Get()
-		if err != nil {
-			logger.Tools.Error().
-				Err(err).
+	arguments, _ := params["arguments"].(map[string]interface{})
+	if tool, exists := h.tools[toolName]; exists && h.config.ClientPolicy(session.GetClientType()).RelaxRequiredArgs {
+		var filled []string
+		arguments, filled = fillRequiredArguments(tool.Tool.InputSchema, arguments)
+		if len(filled) > 0 {
+			params["arguments"] = arguments
+			logger.Tools.Debug().
 				Str("session_id", session.ID).
+				Str("client_type", session.GetClientType()).
 				Str("tool_name", toolName).
-				Msg("Error getting system information")
-
-			return map[string]interface{}{
-				"jsonrpc": "2.0",
-				"id":      id,
-				"error": map[string]interface{}{
-					"code":    -32603,
-					"message": fmt.Sprintf("Error getting system information: %v", err),
-				},
-			}
+				Strs("filled_arguments", filled).
+				Msg("Missing required arguments filled by client policy")
 		}
+	}
+	logger.Tools.Debug().
+		Str("session_id", session.ID).
+		Str("client_type", session.GetClientType()).
+		Str("tool_name", toolName).
+		Interface("arguments", loggableArguments(arguments)).
+		Msg("Tool call arguments")
 
-		logger.Tools.Debug().
-			Str("session_id", session.ID).
-			Str("tool_name", toolName).
-			Interface("cpu_count", sysInfo.CPU.Count).
-			Float64("memory_total_gb", float64(sysInfo.Memory.Total)/(1024*1024*1024)).
-			Msg("System information retrieved successfully")
+	h.emitSessionEvent(session, "tool_call", map[string]interface{}{"tool": toolName})
 
-		return map[string]interface{}{
-			"jsonrpc": "2.0",
-			"id":      id,
-			"result": map[string]interface{}{
-				"content": []map[string]interface{}{
-					{
-						"type": "text",
-						"text": sysInfo.FormatText(),
-					},
-				},
-			},
-		}
+	// Инструменты вызываются теми же обработчиками, что и в stdio через mcp-go, без особых случаев
+	// для отдельных инструментов, поэтому их поведение одинаково в обоих транспортах
+	if tool, ok := h.tools[toolName]; ok {
+		return h.callRegisteredTool(id, tool, params, session)
 	}
 
-	if toolName == "system_monitor_stream" {
-		// Создаем стандартный MCP запрос для вызова инструмента через основной сервер
-		arguments := make(map[string]interface{})
-		if args, ok := params["arguments"].(map[string]interface{}); ok {
-			arguments = args
-		}
+	logger.Tools.Warn().
+		Str("session_id", session.ID).
+		Str("tool_name", toolName).
+		Msg("Unknown tool requested")
 
-		// Создаем CallToolRequest напрямую для вызова зарегистрированного обработчика
-		toolRequest := mcp.CallToolRequest{
-			Params: mcp.CallToolParams{
-				Name:      toolName,
-				Arguments: arguments,
-			},
-		}
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    -32601,
+			"message": "Tool not found",
+		},
+	}
+}
 
-		// Вызываем обработчик напрямую
-		result, err := tools.SystemMonitorStreamHandler(context.Background(), toolRequest)
-		if err != nil {
-			logger.Tools.Error().
-				Err(err).
-				Str("session_id", session.ID).
-				Str("tool_name", toolName).
-				Msg("Error executing system monitor stream")
+// callRegisteredTool вызывает обработчик инструмента из реестра и оборачивает результат в JSON-RPC ответ
+func (h *FiberMCPHandler) callRegisteredTool(id interface{}, tool server.ServerTool, params map[string]interface{}, session *types.Session) map[string]interface{} {
+	toolName := tool.Tool.Name
 
-			return map[string]interface{}{
-				"jsonrpc": "2.0",
-				"id":      id,
-				"error": map[string]interface{}{
-					"code":    -32603,
-					"message": fmt.Sprintf("Error executing system monitor stream: %v", err),
-				},
-			}
-		}
+	arguments := make(map[string]interface{})
+	if args, ok := params["arguments"].(map[string]interface{}); ok {
+		arguments = args
+	}
 
-		logger.Tools.Debug().
+	toolRequest := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Name:      toolName,
+			Arguments: arguments,
+		},
+	}
+
+	result, err := tool.Handler(context.Background(), toolRequest)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
 			Str("session_id", session.ID).
 			Str("tool_name", toolName).
-			Msg("System monitor stream executed successfully")
+			Msg("Error executing tool")
 
 		return map[string]interface{}{
 			"jsonrpc": "2.0",
 			"id":      id,
-			"result": map[string]interface{}{
-				"content": result.Content,
+			"error": map[string]interface{}{
+				"code":    toolErrorCode(err),
+				"message": fmt.Sprintf("Error executing %s: %v", toolName, err),
 			},
 		}
 	}
 
-	logger.Tools.Warn().
+	logger.Tools.Debug().
 		Str("session_id", session.ID).
 		Str("tool_name", toolName).
-		Msg("Unknown tool requested")
+		Bool("is_error", result.IsError).
+		Msg("Tool executed successfully")
+
+	toolResult := map[string]interface{}{
+		"content": result.Content,
+	}
+	if result.IsError {
+		toolResult["isError"] = true
+	}
+	if len(result.Meta) > 0 {
+		toolResult["_meta"] = result.Meta
+	}
 
 	return map[string]interface{}{
 		"jsonrpc": "2.0",
 		"id":      id,
-		"error": map[string]interface{}{
-			"code":    -32601,
-			"message": "Tool not found",
-		},
+		"result":  toolResult,
+	}
+}
+
+// toolErrorCode возвращает JSON-RPC код ошибки для ошибки обработчика инструмента
+func toolErrorCode(err error) int {
+	if errors.Is(err, tools.ErrServerBusy) || errors.Is(err, tools.ErrHostOverloaded) {
+		return -32000
 	}
+	return -32603
 }
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/middleware"
+	"mcp-system-info/internal/tools"
+	"mcp-system-info/internal/types"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newTestApp создает Fiber приложение с маршрутами MCP без зарегистрированных инструментов
+func newTestApp() *fiber.App {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	mcpServer := server.NewMCPServer("mcp-system-info", "1.0.0")
+	handler := NewFiberMCPHandler(mcpServer, types.NewSessionManager(), cfg, nil, nil)
+
+	app := fiber.New()
+	handler.RegisterRoutes(app)
+	return app
+}
+
+func TestHandleJSONRPCWithoutAcceptReturnsJSON(t *testing.T) {
+	for _, accept := range []string{"", "*/*"} {
+		t.Run("accept="+accept, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+			req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", config.DefaultAPIKey)
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+
+			resp, err := newTestApp().Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+			}
+			if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+				t.Fatalf("Content-Type = %q, want application/json", contentType)
+			}
+
+			var response map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			if _, ok := response["result"]; !ok {
+				t.Fatalf("response has no result: %v", response)
+			}
+		})
+	}
+}
+
+func TestPlainTextPreferenceFallsBackToJSON(t *testing.T) {
+	cases := []struct {
+		accept string
+		status int
+	}{
+		{"text/plain, application/json;q=0.5", fiber.StatusOK},
+		{"text/plain", fiber.StatusNotAcceptable},
+	}
+	for _, tc := range cases {
+		t.Run("accept="+tc.accept, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+			req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", config.DefaultAPIKey)
+			req.Header.Set("Accept", tc.accept)
+
+			resp, err := newTestApp().Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tc.status)
+			}
+			if tc.status == fiber.StatusOK {
+				if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+					t.Fatalf("Content-Type = %q, want application/json", contentType)
+				}
+			}
+		})
+	}
+}
+
+func TestInitializeStoresClientTypeOnSession(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	sessionManager := types.NewSessionManager()
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), sessionManager, cfg, nil, nil)
+
+	app := fiber.New()
+	app.Use(middleware.RequestLoggingMiddleware())
+	handler.RegisterRoutes(app)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+	req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", config.DefaultAPIKey)
+	req.Header.Set("User-Agent", "curl/8.5.0")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	sessionID := resp.Header.Get("Mcp-Session-Id")
+	session, exists := sessionManager.GetSession(sessionID)
+	if !exists {
+		t.Fatalf("session %q was not created", sessionID)
+	}
+	if clientType := session.GetClientType(); clientType != "curl" {
+		t.Fatalf("client type = %q, want %q", clientType, "curl")
+	}
+}
+
+func TestRegisterRoutesWithBasePath(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey, BasePath: "/mcp-sysinfo"}
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, nil, nil)
+
+	app := fiber.New()
+	handler.RegisterRoutes(app)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/mcp-sysinfo/", nil))
+	if err != nil {
+		t.Fatalf("health request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("health status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+	var health struct {
+		Endpoints []string `json:"endpoints"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		t.Fatalf("health response is not JSON: %v", err)
+	}
+	if len(health.Endpoints) == 0 || health.Endpoints[1] != "POST /mcp-sysinfo/mcp (JSON-RPC)" {
+		t.Fatalf("endpoints = %v, want paths under /mcp-sysinfo", health.Endpoints)
+	}
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+	req := httptest.NewRequest("POST", "/mcp-sysinfo/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", config.DefaultAPIKey)
+
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("initialize request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("initialize status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/mcp", nil))
+	if err != nil {
+		t.Fatalf("unprefixed request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("unprefixed /mcp status = %d, want %d", resp.StatusCode, fiber.StatusNotFound)
+	}
+}
+
+func TestClientPolicyForceJSONDisablesSSE(t *testing.T) {
+	cfg := &config.Config{
+		APIKey:         config.DefaultAPIKey,
+		ClientPolicies: map[string]config.ClientPolicy{"n8n": {ForceJSON: true}},
+	}
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, nil, nil)
+
+	app := fiber.New()
+	app.Use(middleware.RequestLoggingMiddleware())
+	app.Get("/sse", func(c *fiber.Ctx) error {
+		return c.JSON(handler.clientSupportsSSE(c))
+	})
+
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"n8n/1.0", "false"},
+		{"curl/8.5.0", "true"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/sse", nil)
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		req.Header.Set("User-Agent", tt.userAgent)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if got := string(body); got != tt.want {
+			t.Errorf("clientSupportsSSE for %q = %s, want %s", tt.userAgent, got, tt.want)
+		}
+	}
+}
+
+func TestHealthCheckAuthPolicies(t *testing.T) {
+	tests := []struct {
+		policy      string
+		key         string
+		wantStatus  int
+		wantDetails bool
+	}{
+		{config.HealthAuthPublic, "", fiber.StatusOK, true},
+		{config.HealthAuthMinimal, "", fiber.StatusOK, false},
+		{config.HealthAuthMinimal, "wrong", fiber.StatusOK, false},
+		{config.HealthAuthMinimal, "health-secret", fiber.StatusOK, true},
+		{config.HealthAuthAPIKey, "", fiber.StatusUnauthorized, false},
+		{config.HealthAuthAPIKey, "health-secret", fiber.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+"/"+tt.key, func(t *testing.T) {
+			cfg := &config.Config{APIKey: config.DefaultAPIKey, HealthAuth: tt.policy, HealthAPIKey: "health-secret"}
+			handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, nil, nil)
+			app := fiber.New()
+			handler.RegisterRoutes(app)
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("health request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+
+			var health map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+				t.Fatalf("health response is not JSON: %v", err)
+			}
+			if health["status"] != "ok" {
+				t.Fatalf("status field = %v, want ok", health["status"])
+			}
+			if _, hasVersion := health["version"]; hasVersion != tt.wantDetails {
+				t.Fatalf("response %v: details present = %v, want %v", health, hasVersion, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestStreamingToolCallTakesHeavyLimiter(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey, MaxSSEStreams: 10}
+	sessionManager := types.NewSessionManager()
+	// Ограничитель без слотов: любой тяжелый вызов получает ErrServerBusy
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), sessionManager, cfg, nil, tools.NewLimiter(0, 0))
+	app := fiber.New()
+	handler.RegisterRoutes(app)
+
+	body := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"system_monitor_stream","arguments":{"duration":"1s"}}}`
+	req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set("X-API-Key", config.DefaultAPIKey)
+	req.Header.Set("Mcp-Session-Id", sessionManager.CreateSession())
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusServiceUnavailable)
+	}
+	var response struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if response.Error.Code != -32000 || response.Error.Message != tools.ErrServerBusy.Error() {
+		t.Errorf("error = %+v, want -32000 %q", response.Error, tools.ErrServerBusy)
+	}
+	if open := handler.OpenStreams(); open != 0 {
+		t.Errorf("OpenStreams() = %d after rejection, want 0", open)
+	}
+}
+
+func TestConcurrentInitializeReturnsOwnSessions(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	sessionManager := types.NewSessionManager()
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), sessionManager, cfg, nil, nil)
+	app := fiber.New()
+	handler.RegisterRoutes(app)
+
+	const clients = 20
+	sessionIDs := make(chan string, clients)
+	var wg sync.WaitGroup
+	for range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+			req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", config.DefaultAPIKey)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+			sessionIDs <- resp.Header.Get("Mcp-Session-Id")
+		}()
+	}
+	wg.Wait()
+	close(sessionIDs)
+
+	seen := make(map[string]bool)
+	for sessionID := range sessionIDs {
+		if _, exists := sessionManager.GetSession(sessionID); !exists {
+			t.Errorf("Mcp-Session-Id %q is not a live session", sessionID)
+		}
+		if seen[sessionID] {
+			t.Errorf("Mcp-Session-Id %q was returned to two clients", sessionID)
+		}
+		seen[sessionID] = true
+	}
+}
+
+func TestConcurrentInitializeTagsOwnClientType(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	sessionManager := types.NewSessionManager()
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), sessionManager, cfg, nil, nil)
+	app := fiber.New()
+	app.Use(middleware.RequestLoggingMiddleware())
+	handler.RegisterRoutes(app)
+
+	userAgents := map[string]string{"curl/8.5.0": "curl", "PostmanRuntime/7.36": "postman", "n8n/1.0": "n8n"}
+	var wg sync.WaitGroup
+	for range 10 {
+		for userAgent, want := range userAgents {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+				req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-API-Key", config.DefaultAPIKey)
+				req.Header.Set("User-Agent", userAgent)
+
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Errorf("request failed: %v", err)
+					return
+				}
+				resp.Body.Close()
+
+				session, exists := sessionManager.GetSession(resp.Header.Get("Mcp-Session-Id"))
+				if !exists {
+					t.Errorf("%s: session was not created", userAgent)
+					return
+				}
+				if clientType := session.GetClientType(); clientType != want {
+					t.Errorf("%s: client type = %q, want %q", userAgent, clientType, want)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"mcp-system-info/internal/tools"
+	"mcp-system-info/internal/types"
+)
+
+// methodHandler обрабатывает один JSON-RPC метод. session передается только методам с sessionRequired,
+// nil результат означает, что ответ не отправляется (уведомления). Вторым значением возвращается
+// сессия запроса: initialize может создать новую, остальные методы возвращают sessionID без изменений
+type methodHandler func(request map[string]interface{}, sessionID string, session *types.Session) (map[string]interface{}, string)
+
+// methodSpec запись реестра JSON-RPC методов
+type methodSpec struct {
+	handle methodHandler
+	// sessionRequired метод выполняется только в существующей сессии, иначе ошибка -32001
+	sessionRequired bool
+	// idRequired запрос без id не обрабатывается: ответить на него невозможно
+	idRequired bool
+}
+
+// newMethodRegistry собирает реестр JSON-RPC методов. Все HTTP точки входа (JSON и text/plain)
+// обрабатывают запросы через него, поэтому новый метод добавляется только здесь. stdio обслуживает
+// mcp-go: его поведение для тех же методов выравнивают StdioServerOptions и negotiateProtocolVersion,
+// а tools/call в обоих транспортах вызывает одни и те же обработчики из tools.Definitions.
+// TestTransportsAnswerAlike проверяет, что оба транспорта отвечают одинаково
+func (h *FiberMCPHandler) newMethodRegistry() map[string]methodSpec {
+	return map[string]methodSpec{
+		"initialize": {
+			handle: func(request map[string]interface{}, sessionID string, _ *types.Session) (map[string]interface{}, string) {
+				return h.handleInitializeRequest(request, sessionID)
+			},
+		},
+		// Отсутствие сессии у notifications/initialized логгируется в самом обработчике
+		// как нарушение протокола, ответа на уведомление нет
+		"notifications/initialized": {
+			handle: func(request map[string]interface{}, sessionID string, _ *types.Session) (map[string]interface{}, string) {
+				return h.handleInitializedNotification(request, sessionID), sessionID
+			},
+		},
+		"ping": {
+			handle:     h.handlePingRequest,
+			idRequired: true,
+		},
+		"tools/list": {
+			handle: func(request map[string]interface{}, sessionID string, session *types.Session) (map[string]interface{}, string) {
+				return h.handleToolsListRequest(request, session), sessionID
+			},
+			sessionRequired: true,
+			idRequired:      true,
+		},
+		"tools/call": {
+			handle: func(request map[string]interface{}, sessionID string, session *types.Session) (map[string]interface{}, string) {
+				return h.handleToolCallRequest(request, session), sessionID
+			},
+			sessionRequired: true,
+			idRequired:      true,
+		},
+		"logging/setLevel": {
+			handle: func(request map[string]interface{}, sessionID string, session *types.Session) (map[string]interface{}, string) {
+				return h.handleSetLevelRequest(request, session), sessionID
+			},
+			sessionRequired: true,
+			idRequired:      true,
+		},
+	}
+}
+
+// handlePingRequest отвечает на ping пустым результатом, сессия для проверки связи не требуется
+func (h *FiberMCPHandler) handlePingRequest(request map[string]interface{}, sessionID string, _ *types.Session) (map[string]interface{}, string) {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      request["id"],
+		"result":  map[string]interface{}{},
+	}, sessionID
+}
+
+// stampSchemaVersion добавляет в _meta результата версию контракта вывода, если она включена
+// SCHEMA_VERSION_FIELD. Нужен для ответов, которые собираются здесь, а не обработчиками из tools.Definitions
+func (h *FiberMCPHandler) stampSchemaVersion(result map[string]interface{}) {
+	if h.config.SchemaVersionField {
+		meta, _ := result["_meta"].(map[string]any)
+		result["_meta"] = tools.WithSchemaVersion(meta)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/tools"
+	"mcp-system-info/internal/types"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func TestHandleJSONRPCMessageUsesMethodRegistry(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	h := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, nil, nil)
+
+	errorCode := func(response map[string]interface{}) interface{} {
+		rpcErr, _ := response["error"].(map[string]interface{})
+		return rpcErr["code"]
+	}
+
+	ping, _ := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "ping"}, "")
+	if _, ok := ping["result"]; !ok {
+		t.Fatalf("ping without session = %v, want empty result", ping)
+	}
+
+	unknown, _ := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "id": 2, "method": "resources/list"}, "")
+	if code := errorCode(unknown); code != -32601 {
+		t.Fatalf("unknown method error code = %v, want -32601", code)
+	}
+
+	noSession, _ := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "id": 3, "method": "tools/list"}, "session_missing")
+	if code := errorCode(noSession); code != -32001 {
+		t.Fatalf("tools/list without session error code = %v, want -32001", code)
+	}
+
+	if response, _ := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "method": "ping"}, ""); response != nil {
+		t.Fatalf("ping without id = %v, want no response", response)
+	}
+}
+
+func TestSchemaVersionField(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		cfg := &config.Config{APIKey: config.DefaultAPIKey, SchemaVersionField: enabled}
+		h := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, tools.Definitions(cfg, nil), nil)
+
+		initialize, sessionID := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "initialize"}, "")
+
+		call, _ := h.handleJSONRPCMessage(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      2,
+			"method":  "tools/call",
+			"params":  map[string]interface{}{"name": "get_server_config"},
+		}, sessionID)
+		if _, ok := call["result"]; !ok {
+			t.Fatalf("tools/call = %v, want result", call)
+		}
+
+		for name, response := range map[string]map[string]interface{}{"initialize": initialize, "tools/call": call} {
+			data, _ := json.Marshal(response)
+			var parsed struct {
+				Result struct {
+					Meta map[string]interface{} `json:"_meta"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(data, &parsed); err != nil {
+				t.Fatalf("%s response %s: %v", name, data, err)
+			}
+
+			version, present := parsed.Result.Meta["schema_version"]
+			if present != enabled {
+				t.Errorf("SchemaVersionField=%t: %s has _meta.schema_version = %t, want %t", enabled, name, present, enabled)
+			}
+			if enabled && version != config.OutputSchemaVersion {
+				t.Errorf("%s _meta.schema_version = %v, want %s", name, version, config.OutputSchemaVersion)
+			}
+		}
+	}
+}
+
+func TestToolCallMatchesStdioDispatch(t *testing.T) {
+	cfg := &config.Config{APIKey: config.DefaultAPIKey}
+	toolset := []server.ServerTool{{Tool: tools.GetSystemInfoTool(), Handler: tools.GetSystemInfoHandler}}
+	mcpServer := server.NewMCPServer("mcp-system-info", "1.0.0")
+	mcpServer.AddTools(toolset...)
+	h := NewFiberMCPHandler(mcpServer, types.NewSessionManager(), cfg, toolset, nil)
+
+	_, sessionID := h.handleJSONRPCMessage(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "initialize"}, "")
+
+	// Некорректный аргумент проверяется самим обработчиком инструмента, одинаково для HTTP и stdio
+	call := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "tools/call",
+		"params": map[string]interface{}{
+			"name":      "get_system_info",
+			"arguments": map[string]interface{}{"random_string": "x", "cpu_sample_interval": "forever"},
+		},
+	}
+	response, _ := h.handleJSONRPCMessage(call, sessionID)
+	httpResponse, _ := json.Marshal(response)
+
+	message, _ := json.Marshal(call)
+	stdioResponse, _ := json.Marshal(mcpServer.HandleMessage(context.Background(), message))
+
+	var httpResult, stdioResult struct {
+		Result map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(httpResponse, &httpResult); err != nil || httpResult.Result == nil {
+		t.Fatalf("HTTP tools/call = %s, want result", httpResponse)
+	}
+	if err := json.Unmarshal(stdioResponse, &stdioResult); err != nil || stdioResult.Result == nil {
+		t.Fatalf("stdio tools/call = %s, want result", stdioResponse)
+	}
+	if httpResult.Result["isError"] != true || !reflect.DeepEqual(httpResult.Result, stdioResult.Result) {
+		t.Errorf("HTTP result %s differs from stdio result %s", httpResponse, stdioResponse)
+	}
+}
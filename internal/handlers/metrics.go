@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleMetrics отдает счетчики сервера в текстовом формате Prometheus
+func (h *FiberMCPHandler) HandleMetrics(c *fiber.Ctx) error {
+	totals := h.sessionManager.NotificationTotals()
+
+	var b strings.Builder
+	writeMetric(&b, "mcp_active_sessions", "gauge", "Number of active MCP sessions", uint64(h.sessionManager.SessionCount()))
+	writeMetric(&b, "mcp_open_sse_streams", "gauge", "Number of currently open SSE streams", uint64(h.OpenStreams()))
+	writeMetric(&b, "mcp_session_notifications_enqueued_total", "counter", "Notifications enqueued to session buffers", totals.Enqueued)
+	writeMetric(&b, "mcp_session_notifications_delivered_total", "counter", "Notifications delivered to clients over SSE", totals.Delivered)
+	writeMetric(&b, "mcp_session_notifications_dropped_total", "counter", "Notifications dropped due to buffer overflow", totals.Dropped)
+	if h.broadcaster != nil {
+		writeMetric(&b, "mcp_metrics_broadcast_subscribers", "gauge", "Sessions subscribed to the shared metrics broadcast", uint64(h.broadcaster.Subscribers()))
+		writeMetric(&b, "mcp_metrics_broadcast_collections_total", "counter", "Metric collections performed by the shared broadcaster", h.broadcaster.Collections())
+	}
+
+	c.Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	return c.SendString(b.String())
+}
+
+// HandleInfluxMetrics отдает текущие системные метрики одной точкой в InfluxDB line protocol
+// для Telegraf http/exec input. Тег host по умолчанию - имя хоста (с учетом REDACT_HOSTNAME)
+func (h *FiberMCPHandler) HandleInfluxMetrics(c *fiber.Ctx) error {
+	info, err := sysinfo.Get()
+	if errors.Is(err, sysinfo.ErrCollectionTimeout) && info != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("System info collection timed out, serving partial InfluxDB metrics")
+	} else if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to collect system info for InfluxDB metrics")
+		return c.Status(fiber.StatusServiceUnavailable).SendString(fmt.Sprintf("Error getting system info: %v\n", err))
+	}
+
+	host := h.config.InfluxHostTag
+	if host == "" {
+		host = sysinfo.Hostname()
+	}
+
+	c.Set("Content-Type", "text/plain; charset=utf-8")
+	return c.SendString(info.FormatInfluxLine(h.config.InfluxMeasurement, host, time.Now()))
+}
+
+// writeMetric записывает одну метрику с HELP и TYPE заголовками
+func writeMetric(b *strings.Builder, name, metricType, help string, value uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(b, "%s %d\n", name, value)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/sysinfo"
+	"mcp-system-info/internal/tools"
+)
+
+// broadcastSample собирает один сэмпл для общей рассылки метрик подписанным сессиям
+func (h *FiberMCPHandler) broadcastSample() (interface{}, error) {
+	sysInfo, err := sysinfo.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system info: %v", err)
+	}
+
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/metrics",
+		"params": map[string]interface{}{
+			"timestamp":   tools.FormatTimestamp(time.Now(), h.config.MonitorTimestampFormat),
+			"cpu":         sysInfo.CPU.UsagePercent,
+			"memory":      sysInfo.Memory.UsedPercent,
+			"memory_used": sysInfo.Memory.Used,
+		},
+	}, nil
+}
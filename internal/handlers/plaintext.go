@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// plainTextTools инструменты, результат которых можно получить без JSON-RPC обертки через Accept: text/plain
+var plainTextTools = map[string]bool{
+	"get_system_info": true,
+}
+
+// isPlainTextToolCall проверяет, что запрос является tools/call инструмента с поддержкой text/plain
+func isPlainTextToolCall(request map[string]interface{}) bool {
+	if method, _ := request["method"].(string); method != "tools/call" {
+		return false
+	}
+
+	params, _ := request["params"].(map[string]interface{})
+	toolName, _ := params["name"].(string)
+	return plainTextTools[toolName]
+}
+
+// plainTextResult разбирает JSON-RPC ответ на вызов инструмента
+type plainTextResult struct {
+	Result *struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// writePlainTextResponse отдает текст результата инструмента без JSON-RPC обертки.
+// Это нестандартный удобный режим для curl, включаемый заголовком Accept: text/plain
+func writePlainTextResponse(c *fiber.Ctx, response map[string]interface{}) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	var parsed plainTextResult
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+
+	c.Set("Content-Type", "text/plain; charset=utf-8")
+
+	if parsed.Error != nil {
+		status := fiber.StatusInternalServerError
+		switch parsed.Error.Code {
+		case -32001:
+			status = fiber.StatusNotFound
+		case -32002, -32602:
+			status = fiber.StatusBadRequest
+		}
+
+		logger.MCP.Debug().
+			Int("code", parsed.Error.Code).
+			Msg("Plain text tool call failed")
+		return c.Status(status).SendString(parsed.Error.Message + "\n")
+	}
+
+	var text []string
+	if parsed.Result != nil {
+		for _, content := range parsed.Result.Content {
+			if content.Type == "text" {
+				text = append(text, content.Text)
+			}
+		}
+	}
+
+	status := fiber.StatusOK
+	if parsed.Result != nil && parsed.Result.IsError {
+		status = fiber.StatusInternalServerError
+	}
+
+	return c.Status(status).SendString(strings.Join(text, "\n") + "\n")
+}
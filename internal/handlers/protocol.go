@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/tools"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog"
+)
+
+// negotiateProtocolVersion выбирает версию протокола для initialize так же, как mcp-go в stdio:
+// поддерживаемая версия клиента возвращается как есть, иначе последняя известная
+func negotiateProtocolVersion(clientVersion string) string {
+	if slices.Contains(mcp.ValidProtocolVersions, clientVersion) {
+		return clientVersion
+	}
+	return mcp.LATEST_PROTOCOL_VERSION
+}
+
+// setGlobalLogLevel применяет уровень из logging/setLevel. Изменение логгируется до смены уровня,
+// чтобы запись не была отфильтрована при его повышении
+func setGlobalLogLevel(mcpLogger zerolog.Logger, requested string, level zerolog.Level) {
+	mcpLogger.Info().
+		Str("previous_level", zerolog.GlobalLevel().String()).
+		Str("requested_level", requested).
+		Str("new_level", level.String()).
+		Msg("Changing global log level")
+
+	zerolog.SetGlobalLevel(level)
+}
+
+// StdioServerOptions опции mcp-go сервера, приводящие stdio к поведению методов HTTP реестра:
+// объявляется capability logging, logging/setLevel меняет глобальный уровень логов,
+// а initialize при SCHEMA_VERSION_FIELD сообщает версию контракта вывода
+func StdioServerOptions(cfg *config.Config) []server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddAfterSetLevel(func(_ context.Context, _ any, request *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+		requested := string(request.Params.Level)
+		if level, ok := logger.ParseMCPLevel(requested); ok {
+			setGlobalLogLevel(logger.GetMCPLogger("logging/setLevel", "stdio"), requested, level)
+		}
+	})
+	if cfg.SchemaVersionField {
+		hooks.AddAfterInitialize(func(_ context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+			result.Meta = tools.WithSchemaVersion(result.Meta)
+		})
+	}
+
+	return []server.ServerOption{server.WithLogging(), server.WithHooks(hooks)}
+}
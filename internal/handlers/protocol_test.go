@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/tools"
+	"mcp-system-info/internal/types"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/rs/zerolog"
+)
+
+// rpcOutcome значимая для сравнения транспортов часть JSON-RPC ответа
+type rpcOutcome struct {
+	Result map[string]interface{} `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+// callOverStdio выполняет запросы через stdio транспорт mcp-go после initialize
+func callOverStdio(t *testing.T, cfg *config.Config, initialize string, requests []string) []rpcOutcome {
+	t.Helper()
+
+	mcpServer := server.NewMCPServer("mcp-system-info", "1.0.0", StdioServerOptions(cfg)...)
+	mcpServer.AddTools(tools.Definitions(cfg, nil)...)
+	input := strings.Join(append([]string{initialize, `{"jsonrpc":"2.0","method":"notifications/initialized"}`}, requests...), "\n") + "\n"
+
+	var output bytes.Buffer
+	if err := server.NewStdioServer(mcpServer).Listen(context.Background(), strings.NewReader(input), &output); err != nil {
+		t.Fatalf("stdio Listen: %v", err)
+	}
+
+	var outcomes []rpcOutcome
+	decoder := json.NewDecoder(&output)
+	for {
+		var outcome rpcOutcome
+		if err := decoder.Decode(&outcome); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("stdio output is not JSON: %v", err)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// callOverHTTP выполняет те же запросы через HTTP транспорт в сессии, созданной initialize
+func callOverHTTP(t *testing.T, cfg *config.Config, initialize string, requests []string) []rpcOutcome {
+	t.Helper()
+
+	handler := NewFiberMCPHandler(server.NewMCPServer("mcp-system-info", "1.0.0"), types.NewSessionManager(), cfg, tools.Definitions(cfg, nil), nil)
+	app := fiber.New()
+	handler.RegisterRoutes(app)
+
+	var sessionID string
+	post := func(body string) *rpcOutcome {
+		req := httptest.NewRequest("POST", "/mcp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", config.DefaultAPIKey)
+		if sessionID != "" {
+			req.Header.Set("Mcp-Session-Id", sessionID)
+		}
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+			sessionID = id
+		}
+		if resp.StatusCode == fiber.StatusNoContent {
+			return nil
+		}
+		var outcome rpcOutcome
+		if err := json.NewDecoder(resp.Body).Decode(&outcome); err != nil {
+			t.Fatalf("HTTP response is not JSON: %v", err)
+		}
+		return &outcome
+	}
+
+	outcomes := []rpcOutcome{*post(initialize)}
+	post(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	for _, request := range requests {
+		outcomes = append(outcomes, *post(request))
+	}
+	return outcomes
+}
+
+func TestTransportsAnswerAlike(t *testing.T) {
+	previousLevel := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previousLevel) })
+
+	cfg := &config.Config{APIKey: config.DefaultAPIKey, SchemaVersionField: true}
+	requests := []string{
+		`{"jsonrpc":"2.0","id":2,"method":"logging/setLevel","params":{"level":"loud"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"logging/setLevel","params":{"level":"error"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_server_config","arguments":{}}}`,
+	}
+
+	for _, clientVersion := range []string{"2024-11-05", "1999-01-01"} {
+		initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"` + clientVersion + `","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`
+		wantVersion := clientVersion
+		if clientVersion == "1999-01-01" {
+			wantVersion = mcp.LATEST_PROTOCOL_VERSION
+		}
+
+		for transport, call := range map[string]func(*testing.T, *config.Config, string, []string) []rpcOutcome{"stdio": callOverStdio, "http": callOverHTTP} {
+			zerolog.SetGlobalLevel(zerolog.InfoLevel)
+			outcomes := call(t, cfg, initialize, requests)
+			if len(outcomes) != 4 {
+				t.Fatalf("%s: got %d responses, want 4", transport, len(outcomes))
+			}
+
+			initResult := outcomes[0].Result
+			if initResult["protocolVersion"] != wantVersion {
+				t.Errorf("%s: protocolVersion = %v for client %s, want %s", transport, initResult["protocolVersion"], clientVersion, wantVersion)
+			}
+			if capabilities, _ := initResult["capabilities"].(map[string]interface{}); capabilities["logging"] == nil {
+				t.Errorf("%s: initialize does not declare the logging capability", transport)
+			}
+			if meta, _ := initResult["_meta"].(map[string]interface{}); meta["schema_version"] != config.OutputSchemaVersion {
+				t.Errorf("%s: initialize _meta = %v, want schema_version", transport, initResult["_meta"])
+			}
+			if outcomes[1].Error == nil || outcomes[1].Error.Code != -32602 {
+				t.Errorf("%s: unknown log level = %+v, want error -32602", transport, outcomes[1])
+			}
+			if outcomes[2].Error != nil || zerolog.GlobalLevel() != zerolog.ErrorLevel {
+				t.Errorf("%s: logging/setLevel error = %+v, global level %v, want error level", transport, outcomes[2].Error, zerolog.GlobalLevel())
+			}
+			if meta, _ := outcomes[3].Result["_meta"].(map[string]interface{}); meta["schema_version"] != config.OutputSchemaVersion {
+				t.Errorf("%s: tools/call result = %v, want _meta.schema_version", transport, outcomes[3].Result)
+			}
+		}
+	}
+}
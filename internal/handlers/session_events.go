@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/types"
+)
+
+// emitSessionEvent записывает событие жизненного цикла в хронологию сессии (всегда) и кладет его
+// в буфер уведомлений. Уведомления доставляются клиенту через GET /mcp и включаются флагом ENABLE_SESSION_EVENTS
+func (h *FiberMCPHandler) emitSessionEvent(session *types.Session, event string, details map[string]interface{}) {
+	if session == nil {
+		return
+	}
+
+	session.RecordEvent(event, formatEventDetails(details))
+
+	if !h.config.EnableSessionEvents {
+		return
+	}
+
+	params := map[string]interface{}{
+		"session_id": session.ID,
+		"event":      event,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	for key, value := range details {
+		params[key] = value
+	}
+
+	if !session.Notify(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/session_event",
+		"params":  params,
+	}) {
+		logger.Session.Debug().
+			Str("session_id", session.ID).
+			Str("event", event).
+			Msg("Session event dropped")
+	}
+}
+
+// formatEventDetails форматирует детали события для хронологии как отсортированные пары key=value
+func formatEventDetails(details map[string]interface{}) string {
+	pairs := make([]string, 0, len(details))
+	for key, value := range details {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
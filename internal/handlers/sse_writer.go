@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/types"
+)
+
+// flushingWriter передает данные в буфер потока fasthttp и сразу сбрасывает его,
+// чтобы Flush внешнего буфера доставлял события клиенту
+type flushingWriter struct {
+	w *bufio.Writer
+}
+
+func (f flushingWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, f.w.Flush()
+}
+
+// sseWriter возвращает writer SSE потока с буфером SSE_WRITE_BUFFER_SIZE. Буфер fasthttp имеет
+// фиксированный размер 4 KiB, поэтому больший буфер оборачивает его, а не заменяет
+func (h *FiberMCPHandler) sseWriter(w *bufio.Writer) *bufio.Writer {
+	size := h.config.SSEWriteBufferSize
+	if size <= 0 || size == w.Size() {
+		return w
+	}
+	return bufio.NewWriterSize(flushingWriter{w: w}, size)
+}
+
+// writeQueuedNotifications записывает в w уже накопленные уведомления сессии без ожидания новых
+// и без сброса буфера: при переподключении они уходят клиенту одним Flush вместе с начальным событием
+func writeQueuedNotifications(w *bufio.Writer, notifications <-chan interface{}, session *types.Session, sessionID string) int {
+	written := 0
+	for {
+		select {
+		case message := <-notifications:
+			if writeSSENotification(w, message, session, sessionID) {
+				written++
+			}
+		default:
+			return written
+		}
+	}
+}
+
+// writeSSENotification записывает уведомление сессии как SSE событие message,
+// сообщение, которое не удалось сериализовать, пропускается. Событие сессии получает id
+// и сохраняется для возобновления потока по Last-Event-Id
+func writeSSENotification(w *bufio.Writer, message interface{}, session *types.Session, sessionID string) bool {
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.SSE.Error().
+			Err(err).
+			Str("session_id", sessionID).
+			Msg("Failed to marshal session notification")
+		return false
+	}
+
+	var eventID uint64
+	if session != nil {
+		eventID = session.RetainEvent(data)
+	}
+	writeSSEEvent(w, eventID, data)
+	return true
+}
+
+// writeSSEEvent записывает SSE событие message, id 0 означает событие без id
+func writeSSEEvent(w *bufio.Writer, eventID uint64, data []byte) {
+	fmt.Fprintf(w, "event: message\n")
+	if eventID > 0 {
+		fmt.Fprintf(w, "id: %d\n", eventID)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+// writeResumedEvents повторяет события сессии после Last-Event-Id. Если часть событий уже вытеснена
+// из истории, повтор был бы молча неполным, поэтому вместо него отправляется notifications/resync:
+// клиент должен запросить полный снимок, после чего поток продолжается с новых событий
+func writeResumedEvents(w *bufio.Writer, session *types.Session, lastEventID string) int {
+	// Нечисловой id выдан не этим сервером: возобновление невозможно
+	var events []types.SessionEvent
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	complete := err == nil
+	if complete {
+		events, complete = session.EventsAfter(lastID)
+	}
+
+	if !complete {
+		logger.SSE.Info().
+			Str("session_id", session.ID).
+			Str("last_event_id", lastEventID).
+			Uint64("oldest_event_id", session.OldestEventID()).
+			Msg("Requested events are no longer retained, sending resync")
+		data, _ := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "notifications/resync",
+			"params": map[string]interface{}{
+				"reason":          "events_expired",
+				"last_event_id":   lastEventID,
+				"oldest_event_id": session.OldestEventID(),
+			},
+		})
+		writeSSEEvent(w, 0, data)
+		return 0
+	}
+
+	for _, event := range events {
+		writeSSEEvent(w, event.ID, event.Data)
+	}
+	return len(events)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/types"
+)
+
+func TestSSEWriterReplaysQueuedNotificationsInOneFlush(t *testing.T) {
+	var out bytes.Buffer
+	h := &FiberMCPHandler{config: &config.Config{SSEWriteBufferSize: 64 * 1024}}
+	w := h.sseWriter(bufio.NewWriterSize(&out, 16))
+
+	notifications := make(chan interface{}, 3)
+	notifications <- map[string]string{"method": "one"}
+	notifications <- map[string]string{"method": "two"}
+	notifications <- func() {} // не сериализуется и пропускается
+
+	if replayed := writeQueuedNotifications(w, notifications, nil, "test"); replayed != 2 {
+		t.Fatalf("replayed = %d, want 2", replayed)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("notifications reached the client before Flush: %q", out.String())
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := strings.Count(out.String(), "event: message\n"); got != 2 {
+		t.Fatalf("client received %d events, want 2: %q", got, out.String())
+	}
+}
+
+func TestWriteResumedEvents(t *testing.T) {
+	session := types.NewSessionWithConfig("resume", types.SessionConfig{BufferSize: 2})
+	for _, method := range []string{"one", "two", "three"} {
+		session.RetainEvent([]byte(`{"method":"` + method + `"}`))
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+		resumed     int
+	}{
+		{name: "retained", lastEventID: "2", want: []string{"id: 3\ndata: {\"method\":\"three\"}"}, resumed: 1},
+		{name: "rolled over", lastEventID: "0", want: []string{"notifications/resync", `"oldest_event_id":2`}},
+		{name: "foreign id", lastEventID: "abc", want: []string{"notifications/resync"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w := bufio.NewWriter(&out)
+			resumed := writeResumedEvents(w, session, tt.lastEventID)
+			w.Flush()
+
+			if resumed != tt.resumed {
+				t.Fatalf("resumed = %d, want %d", resumed, tt.resumed)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Fatalf("output %q does not contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
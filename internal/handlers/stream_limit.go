@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"strconv"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamRetryAfterSeconds значение Retry-After при отказе из-за лимита SSE потоков
+const streamRetryAfterSeconds = 5
+
+// acquireStream резервирует слот SSE потока, false если достигнут MAX_SSE_STREAMS
+func (h *FiberMCPHandler) acquireStream() bool {
+	if h.openStreams.Add(1) > int64(h.config.MaxSSEStreams) {
+		h.openStreams.Add(-1)
+		return false
+	}
+	return true
+}
+
+// releaseStream освобождает слот SSE потока
+func (h *FiberMCPHandler) releaseStream() {
+	h.openStreams.Add(-1)
+}
+
+// OpenStreams возвращает число открытых SSE потоков
+func (h *FiberMCPHandler) OpenStreams() int64 {
+	return h.openStreams.Load()
+}
+
+// rejectUnavailableStream отклоняет streaming вызов до переключения в SSE: хост перегружен
+// или заняты все слоты тяжелых инструментов. Код и сообщение те же, что у ошибки обработчика
+func (h *FiberMCPHandler) rejectUnavailableStream(c *fiber.Ctx, id interface{}, err error) error {
+	c.Set("Retry-After", strconv.Itoa(streamRetryAfterSeconds))
+	return c.Status(fiber.StatusServiceUnavailable).JSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    toolErrorCode(err),
+			"message": err.Error(),
+		},
+	})
+}
+
+// rejectStream отвечает 503 с Retry-After, когда лимит SSE потоков исчерпан
+func (h *FiberMCPHandler) rejectStream(c *fiber.Ctx, sessionID string, id interface{}) error {
+	logger.SSE.Warn().
+		Str("session_id", sessionID).
+		Int64("open_streams", h.openStreams.Load()).
+		Int("max_streams", h.config.MaxSSEStreams).
+		Msg("Rejecting SSE stream: concurrent stream limit reached")
+
+	c.Set("Retry-After", strconv.Itoa(streamRetryAfterSeconds))
+	return c.Status(fiber.StatusServiceUnavailable).JSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    -32000,
+			"message": "Too many open SSE streams, retry later",
+		},
+	})
+}
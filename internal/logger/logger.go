@@ -20,6 +20,8 @@ var (
 	SysInfo    zerolog.Logger
 	SSE        zerolog.Logger
 	Streamable zerolog.Logger
+	// Audit отдельный поток аудита решений авторизации
+	Audit zerolog.Logger
 )
 
 // InitLogger инициализирует логгеры на основе переменных окружения
@@ -34,9 +36,10 @@ func InitLogger() {
 	level := getLogLevel()
 	zerolog.SetGlobalLevel(level)
 
-	// Настраиваем вывод в зависимости от окружения
+	// Формат вывода задается LOG_FORMAT, иначе выводится из окружения
+	format, formatValid := getLogFormat()
 	var writer zerolog.ConsoleWriter
-	if isDevelopmentMode() {
+	if format == FormatConsole {
 		// Красивый консольный вывод для разработки
 		writer = zerolog.ConsoleWriter{
 			Out:        os.Stdout,
@@ -61,11 +64,73 @@ func InitLogger() {
 	SysInfo = log.Logger.With().Str("component", "sysinfo").Logger()
 	SSE = log.Logger.With().Str("component", "sse").Logger()
 	Streamable = log.Logger.With().Str("component", "streamable").Logger()
+	Audit = newAuditLogger()
 
 	Main.Info().
 		Str("level", level.String()).
+		Str("format", format).
 		Bool("development", isDevelopmentMode()).
 		Msg("Logger initialized")
+
+	if !formatValid {
+		Main.Warn().
+			Str("log_format", os.Getenv("LOG_FORMAT")).
+			Str("format", format).
+			Msg("Unknown LOG_FORMAT, expected json or console; using environment-based format")
+	}
+}
+
+// Форматы вывода логов
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// getLogFormat определяет формат вывода: LOG_FORMAT (json|console) или, если не задан,
+// console в режиме разработки и json в остальных окружениях. Второе значение false при неизвестном LOG_FORMAT
+func getLogFormat() (string, bool) {
+	switch strings.ToLower(os.Getenv("LOG_FORMAT")) {
+	case FormatJSON:
+		return FormatJSON, true
+	case FormatConsole:
+		return FormatConsole, true
+	case "":
+		if isDevelopmentMode() {
+			return FormatConsole, true
+		}
+		return FormatJSON, true
+	default:
+		if isDevelopmentMode() {
+			return FormatConsole, false
+		}
+		return FormatJSON, false
+	}
+}
+
+// newAuditLogger создает аудит-логгер, при заданном AUDIT_LOG_FILE пишет JSON в отдельный файл
+func newAuditLogger() zerolog.Logger {
+	path := os.Getenv("AUDIT_LOG_FILE")
+	if path == "" {
+		return log.Logger.With().Str("component", "audit").Logger()
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		Main.Error().
+			Err(err).
+			Str("path", path).
+			Msg("Failed to open audit log file, falling back to main log output")
+		return log.Logger.With().Str("component", "audit").Logger()
+	}
+
+	return zerolog.New(file).With().Timestamp().Str("component", "audit").Logger()
+}
+
+// AuditEvent начинает запись аудита с заданным уровнем. Событие пишется с NoLevel, поэтому
+// глобальный уровень (LOG_LEVEL, logging/setLevel) не отфильтровывает аудит, а уровень
+// сохраняется отдельным полем
+func AuditEvent(level zerolog.Level) *zerolog.Event {
+	return Audit.WithLevel(zerolog.NoLevel).Str(zerolog.LevelFieldName, level.String())
 }
 
 // getLogLevel определяет уровень логгирования из переменной окружения
@@ -93,6 +158,23 @@ func getLogLevel() zerolog.Level {
 	}
 }
 
+// ParseMCPLevel преобразует уровень логгирования MCP (RFC 5424) в уровень zerolog.
+// В zerolog нет уровней строже error, поэтому critical, alert и emergency отображаются в error
+func ParseMCPLevel(level string) (zerolog.Level, bool) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return zerolog.DebugLevel, true
+	case "info", "notice":
+		return zerolog.InfoLevel, true
+	case "warning":
+		return zerolog.WarnLevel, true
+	case "error", "critical", "alert", "emergency":
+		return zerolog.ErrorLevel, true
+	default:
+		return zerolog.NoLevel, false
+	}
+}
+
 // isDevelopmentMode проверяет режим разработки
 func isDevelopmentMode() bool {
 	env := strings.ToLower(os.Getenv("ENVIRONMENT"))
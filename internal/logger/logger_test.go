@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestAuditEventIgnoresGlobalLevel(t *testing.T) {
+	previousAudit, previousLevel := Audit, zerolog.GlobalLevel()
+	t.Cleanup(func() {
+		Audit = previousAudit
+		zerolog.SetGlobalLevel(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	Audit = zerolog.New(&buf)
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+
+	AuditEvent(zerolog.InfoLevel).Str("decision", "allow").Msg("Auth decision")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("audit record was not written at global level error: %q", buf.String())
+	}
+	if record["level"] != "info" || record["decision"] != "allow" {
+		t.Errorf("audit record = %v, want level info and decision allow", record)
+	}
+}
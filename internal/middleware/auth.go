@@ -3,11 +3,21 @@ package middleware
 import (
 	"strings"
 
+	"mcp-system-info/internal/config"
 	"mcp-system-info/internal/logger"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
 )
 
+// authRejectedKey ключ c.Locals, которым auth middleware помечает отклоненные запросы для логов
+const authRejectedKey = "auth_rejected"
+
+// MarkAuthRejected помечает запрос как отклоненный авторизацией, чтобы лог запроса получил outcome rejected_auth
+func MarkAuthRejected(c *fiber.Ctx) {
+	c.Locals(authRejectedKey, true)
+}
+
 // AuthConfig конфигурация для middleware авторизации
 type AuthConfig struct {
 	// APIKey API ключ для доступа к MCP endpoints
@@ -16,13 +26,14 @@ type AuthConfig struct {
 	AllowedUserAgents []string
 	// SkipPaths пути которые нужно пропустить при проверке авторизации
 	SkipPaths []string
+	// AllowQueryAPIKey разрешает передавать ключ в query параметре api_key, если нет заголовка
+	AllowQueryAPIKey bool
 }
 
-// AuthMiddleware создает middleware для проверки авторизации MCP запросов
-func AuthMiddleware() fiber.Handler {
-	// Дефолтная конфигурация
-	config := AuthConfig{
-		APIKey: "mcp-secret-key-2025", // хардкодное значение как запросил пользователь
+// DefaultAuthConfig возвращает конфигурацию авторизации по умолчанию
+func DefaultAuthConfig() AuthConfig {
+	return AuthConfig{
+		APIKey: config.DefaultAPIKey, // хардкодное значение как запросил пользователь
 		AllowedUserAgents: []string{
 			"Cursor/", // Cursor клиент
 		},
@@ -30,8 +41,11 @@ func AuthMiddleware() fiber.Handler {
 			"/", // Health check
 		},
 	}
+}
 
-	return AuthMiddlewareWithConfig(config)
+// AuthMiddleware создает middleware для проверки авторизации MCP запросов
+func AuthMiddleware() fiber.Handler {
+	return AuthMiddlewareWithConfig(DefaultAuthConfig())
 }
 
 // AuthMiddlewareWithConfig создает middleware для авторизации с настраиваемой конфигурацией
@@ -43,12 +57,22 @@ func AuthMiddlewareWithConfig(config AuthConfig) fiber.Handler {
 		apiKey := c.Get("X-API-Key")
 		sessionID := c.Get("Mcp-Session-Id", "unknown")
 
+		// Query параметр используется только если разрешен и заголовок не передан
+		keySource := "header"
+		if apiKey == "" && config.AllowQueryAPIKey {
+			if queryKey := c.Query("api_key"); queryKey != "" {
+				apiKey = queryKey
+				keySource = "query"
+			}
+		}
+
 		authLogger := logger.HTTP.With().
 			Str("session_id", sessionID).
 			Str("method", method).
 			Str("path", path).
 			Str("remote_ip", c.IP()).
 			Str("user_agent", userAgent).
+			Str("key_source", keySource).
 			Logger()
 
 		// Пропускаем проверку для определенных путей
@@ -57,6 +81,7 @@ func AuthMiddlewareWithConfig(config AuthConfig) fiber.Handler {
 				authLogger.Debug().
 					Str("skip_reason", "path_in_skip_list").
 					Msg("Auth check skipped")
+				auditDecision(c, sessionID, apiKey, keySource, true, "path_in_skip_list")
 				return c.Next()
 			}
 		}
@@ -73,6 +98,7 @@ func AuthMiddlewareWithConfig(config AuthConfig) fiber.Handler {
 		if isCursorClient {
 			authLogger.Debug().
 				Msg("Cursor client detected - skipping API key check")
+			auditDecision(c, sessionID, apiKey, keySource, true, "allowed_user_agent")
 			return c.Next()
 		}
 
@@ -82,6 +108,8 @@ func AuthMiddlewareWithConfig(config AuthConfig) fiber.Handler {
 				Str("provided_api_key", maskAPIKey(apiKey)).
 				Str("expected_api_key", maskAPIKey(config.APIKey)).
 				Msg("Non-Cursor client with invalid API key")
+			auditDecision(c, sessionID, apiKey, keySource, false, "invalid_api_key")
+			MarkAuthRejected(c)
 
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error":   "Unauthorized",
@@ -92,11 +120,34 @@ func AuthMiddlewareWithConfig(config AuthConfig) fiber.Handler {
 
 		authLogger.Debug().
 			Msg("Non-Cursor client authorized with valid API key")
+		auditDecision(c, sessionID, apiKey, keySource, true, "valid_api_key")
 
 		return c.Next()
 	}
 }
 
+// auditDecision записывает решение авторизации в аудит-лог
+func auditDecision(c *fiber.Ctx, sessionID, apiKey, keySource string, allowed bool, reason string) {
+	event := logger.AuditEvent(zerolog.InfoLevel)
+	decision := "allow"
+	if !allowed {
+		event = logger.AuditEvent(zerolog.WarnLevel)
+		decision = "deny"
+	}
+
+	event.
+		Str("decision", decision).
+		Str("reason", reason).
+		Str("remote_ip", c.IP()).
+		Str("user_agent", c.Get("User-Agent")).
+		Str("session_id", sessionID).
+		Str("method", c.Method()).
+		Str("path", c.Path()).
+		Str("api_key", maskAPIKey(apiKey)).
+		Str("key_source", keySource).
+		Msg("Auth decision")
+}
+
 // maskAPIKey маскирует API ключ для безопасного логгирования
 func maskAPIKey(key string) string {
 	if key == "" {
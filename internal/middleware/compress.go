@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// CompressConfig конфигурация gzip сжатия ответов
+type CompressConfig struct {
+	// MinBytes минимальный размер тела ответа для сжатия, меньшие ответы отдаются как есть
+	MinBytes int
+}
+
+// CompressMiddlewareWithConfig создает middleware, сжимающий gzip ответы не меньше MinBytes.
+// Потоковые ответы (SSE) не сжимаются никогда: буферизация gzip задерживала бы события
+func CompressMiddlewareWithConfig(config CompressConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if resp.IsBodyStream() ||
+			strings.HasPrefix(string(resp.Header.ContentType()), "text/event-stream") ||
+			len(resp.Header.Peek(fiber.HeaderContentEncoding)) > 0 ||
+			!strings.Contains(c.Get(fiber.HeaderAcceptEncoding), "gzip") {
+			return nil
+		}
+
+		body := resp.Body()
+		if len(body) < config.MinBytes {
+			return nil
+		}
+
+		compressed := fasthttp.AppendGzipBytes(nil, body)
+		resp.SetBodyRaw(compressed)
+		resp.Header.Set(fiber.HeaderContentEncoding, "gzip")
+		resp.Header.Add(fiber.HeaderVary, fiber.HeaderAcceptEncoding)
+
+		logger.HTTP.Debug().
+			Str("path", c.Path()).
+			Int("original_size", len(body)).
+			Int("compressed_size", len(compressed)).
+			Msg("Response compressed")
+
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalhostOnly создает middleware, пропускающий только запросы с loopback адресов
+func LocalhostOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ip := net.ParseIP(c.IP())
+		if ip != nil && ip.IsLoopback() {
+			return c.Next()
+		}
+
+		logger.HTTP.Warn().
+			Str("remote_ip", c.IP()).
+			Str("path", c.Path()).
+			Msg("Rejected non-local request to localhost-only endpoint")
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   "Forbidden",
+			"message": "Endpoint is available only from localhost",
+			"code":    "LOCALHOST_ONLY",
+		})
+	}
+}
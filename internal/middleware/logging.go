@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"time"
 
@@ -11,6 +12,62 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// responseBodySize возвращает размер тела ответа, для потоковых ответов (SSE) -1.
+// Чтение Body() у потокового ответа вычитало бы весь поток в память до отправки клиенту
+func responseBodySize(c *fiber.Ctx) int {
+	if c.Response().IsBodyStream() {
+		return -1
+	}
+	return len(c.Response().Body())
+}
+
+// clientTypeKey ключ c.Locals с типом клиента, определенным logging middleware
+const clientTypeKey = "client_type"
+
+// ClientType возвращает тип клиента, определенный RequestLoggingMiddleware для запроса
+func ClientType(c *fiber.Ctx) string {
+	clientType, _ := c.Locals(clientTypeKey).(string)
+	return clientType
+}
+
+// Значения поля outcome в логе завершения запроса
+const (
+	OutcomeSuccess      = "success"
+	OutcomeClientError  = "client_error"
+	OutcomeServerError  = "server_error"
+	OutcomeRejectedAuth = "rejected_auth"
+)
+
+// responseStatus возвращает статус ответа. Ошибка из цепочки обработчиков превращается в ответ
+// ErrorHandler уже после middleware, поэтому статус в этом случае берется из самой ошибки
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// requestOutcome классифицирует результат запроса для дашбордов без разбора диапазонов статусов
+func requestOutcome(c *fiber.Ctx, status int) string {
+	if rejected, _ := c.Locals(authRejectedKey).(bool); rejected {
+		return OutcomeRejectedAuth
+	}
+
+	switch {
+	case status >= 500:
+		return OutcomeServerError
+	case status >= 400:
+		return OutcomeClientError
+	default:
+		return OutcomeSuccess
+	}
+}
+
 // LoggingMiddleware создает middleware для логгирования HTTP запросов
 func LoggingMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -39,8 +96,8 @@ func LoggingMiddleware() fiber.Handler {
 
 		// Логгируем результат
 		duration := time.Since(start)
-		status := c.Response().StatusCode()
-		responseSize := len(c.Response().Body())
+		status := responseStatus(c, err)
+		responseSize := responseBodySize(c)
 
 		logEvent := requestLogger.Info()
 		if err != nil {
@@ -51,6 +108,7 @@ func LoggingMiddleware() fiber.Handler {
 
 		logEvent.
 			Int("status", status).
+			Str("outcome", requestOutcome(c, status)).
 			Dur("duration", duration).
 			Int("response_size", responseSize).
 			Msg("Request completed")
@@ -116,8 +174,8 @@ func CustomLoggingMiddleware(config LoggingConfig) fiber.Handler {
 		err := c.Next()
 
 		duration := time.Since(start)
-		status := c.Response().StatusCode()
-		responseSize := len(c.Response().Body())
+		status := responseStatus(c, err)
+		responseSize := responseBodySize(c)
 
 		// Определяем уровень логгирования
 		var logEvent *zerolog.Event
@@ -135,6 +193,7 @@ func CustomLoggingMiddleware(config LoggingConfig) fiber.Handler {
 
 		logEvent.
 			Int("status", status).
+			Str("outcome", requestOutcome(c, status)).
 			Dur("duration", duration).
 			Int("response_size", responseSize).
 			Msg("Request completed")
@@ -202,6 +261,7 @@ func RequestLoggingMiddleware() fiber.Handler {
 		default:
 			clientType = "unknown"
 		}
+		c.Locals(clientTypeKey, clientType)
 
 		sessionID := c.Get("Mcp-Session-Id")
 		if sessionID == "" {
@@ -244,12 +304,13 @@ func RequestLoggingMiddleware() fiber.Handler {
 
 		// Логируем завершение запроса
 		duration := time.Since(start)
-		status := c.Response().StatusCode()
-		responseSize := len(c.Response().Body())
+		status := responseStatus(c, err)
+		responseSize := responseBodySize(c)
 
 		logEvent := httpLogger.With().
 			Dur("duration", duration).
 			Int("status", status).
+			Str("outcome", requestOutcome(c, status)).
 			Int("response_size", responseSize).
 			Logger()
 
@@ -0,0 +1,24 @@
+package sysinfo
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// parseCpusAllowedList возвращает число процессоров из строки Cpus_allowed_list файла /proc/<pid>/status
+func parseCpusAllowedList(r io.Reader) (int, bool) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		list, found := strings.CutPrefix(scanner.Text(), "Cpus_allowed_list:")
+		if !found {
+			continue
+		}
+		count, err := countCPUList(strings.TrimSpace(list))
+		if err != nil || count == 0 {
+			return 0, false
+		}
+		return count, true
+	}
+	return 0, false
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"runtime"
+)
+
+// availableCores возвращает число процессоров в текущей маске привязки процесса (sched_getaffinity,
+// с учетом cpuset cgroup). runtime.NumCPU читает маску только при старте, поэтому она перечитывается
+func availableCores() int {
+	file, err := os.Open("/proc/self/status")
+	if err != nil {
+		return runtime.NumCPU()
+	}
+	defer file.Close()
+
+	if count, ok := parseCpusAllowedList(file); ok {
+		return count
+	}
+	return runtime.NumCPU()
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysinfo
+
+import "runtime"
+
+// availableCores маска привязки читается только в Linux, иначе используется runtime.NumCPU
+func availableCores() int {
+	return runtime.NumCPU()
+}
@@ -0,0 +1,111 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// sectorSize единица атрибутов size и start в sysfs независимо от физического сектора диска
+const sectorSize = 512
+
+// virtualBlockPrefixes виртуальные устройства без физического носителя, не входящие в инвентарь дисков
+var virtualBlockPrefixes = []string{"loop", "ram", "zram"}
+
+// readBlockDevices читает диски и их разделы из каталога в формате /sys/block.
+// mounts сопоставляет имя устройства ядра (sda1, dm-0) с точками монтирования
+func readBlockDevices(root string, mounts map[string][]string) (*BlockDevicesInfo, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list block devices: %v", err)
+	}
+
+	info := &BlockDevicesInfo{Supported: true}
+	for _, entry := range entries {
+		name := entry.Name()
+		if isVirtualBlockDevice(name) {
+			continue
+		}
+
+		dir := filepath.Join(root, name)
+		device := BlockDevice{
+			Name:        name,
+			SizeBytes:   readSysfsSectors(dir, "size"),
+			Model:       readSysfsString(dir, "device/model"),
+			Vendor:      readSysfsString(dir, "device/vendor"),
+			State:       readSysfsString(dir, "device/state"),
+			Rotational:  readSysfsString(dir, "queue/rotational") == "1",
+			Removable:   readSysfsString(dir, "removable") == "1",
+			ReadOnly:    readSysfsString(dir, "ro") == "1",
+			Mountpoints: mounts[name],
+		}
+
+		// Разделы - подкаталоги устройства с атрибутом partition
+		subdirs, _ := os.ReadDir(dir)
+		for _, sub := range subdirs {
+			partDir := filepath.Join(dir, sub.Name())
+			if readSysfsString(partDir, "partition") == "" {
+				continue
+			}
+			device.Partitions = append(device.Partitions, BlockPartition{
+				Name:        sub.Name(),
+				SizeBytes:   readSysfsSectors(partDir, "size"),
+				StartBytes:  readSysfsSectors(partDir, "start"),
+				Mountpoints: mounts[sub.Name()],
+			})
+		}
+		sort.Slice(device.Partitions, func(i, j int) bool {
+			return device.Partitions[i].StartBytes < device.Partitions[j].StartBytes
+		})
+
+		info.Devices = append(info.Devices, device)
+	}
+
+	sort.Slice(info.Devices, func(i, j int) bool {
+		return info.Devices[i].Name < info.Devices[j].Name
+	})
+
+	return info, nil
+}
+
+// isVirtualBlockDevice проверяет, что устройство не имеет физического носителя
+func isVirtualBlockDevice(name string) bool {
+	for _, prefix := range virtualBlockPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readSysfsSectors читает размер в 512-байтных секторах и переводит его в байты
+func readSysfsSectors(dir, name string) uint64 {
+	sectors, err := strconv.ParseUint(readSysfsString(dir, name), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return sectors * sectorSize
+}
+
+// mountsByDevice группирует точки монтирования по имени устройства ядра. Символические ссылки
+// (/dev/mapper/root, /dev/disk/by-uuid/...) разрешаются до реального устройства
+func mountsByDevice(partitions []disk.PartitionStat) map[string][]string {
+	mounts := make(map[string][]string)
+	for _, p := range partitions {
+		if !strings.HasPrefix(p.Device, "/dev/") {
+			continue
+		}
+		device := p.Device
+		if resolved, err := filepath.EvalSymlinks(device); err == nil {
+			device = resolved
+		}
+		name := filepath.Base(device)
+		mounts[name] = append(mounts[name], p.Mountpoint)
+	}
+	return mounts
+}
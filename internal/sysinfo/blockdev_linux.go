@@ -0,0 +1,39 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// blockDevicesRoot каталог блочных устройств в sysfs
+const blockDevicesRoot = "/sys/block"
+
+// GetBlockDevices читает физические диски и разделы из sysfs и сопоставляет им точки монтирования.
+// Если список монтирований недоступен, устройства возвращаются без них
+func GetBlockDevices(ctx context.Context) (*BlockDevicesInfo, error) {
+	partitions, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to list mounts, block devices are reported without mountpoints")
+	}
+
+	info, err := readBlockDevices(blockDevicesRoot, mountsByDevice(partitions))
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to read block devices")
+		return nil, err
+	}
+
+	logger.SysInfo.Debug().
+		Int("devices", len(info.Devices)).
+		Msg("Got block devices")
+
+	return info, nil
+}
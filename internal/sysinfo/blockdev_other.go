@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysinfo
+
+import "context"
+
+// GetBlockDevices блочные устройства читаются только из sysfs Linux
+func GetBlockDevices(_ context.Context) (*BlockDevicesInfo, error) {
+	return &BlockDevicesInfo{Supported: false}, nil
+}
@@ -0,0 +1,70 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSysfs создает атрибуты sysfs в каталоге dir
+func writeSysfs(t *testing.T, dir string, attrs map[string]string) {
+	t.Helper()
+	for name, value := range attrs {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(value+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadBlockDevices(t *testing.T) {
+	root := t.TempDir()
+	writeSysfs(t, filepath.Join(root, "sda"), map[string]string{
+		"size":             "1953525168",
+		"device/model":     "WDC WD10EZEX",
+		"device/vendor":    "ATA",
+		"device/state":     "running",
+		"queue/rotational": "1",
+		"removable":        "0",
+		"ro":               "0",
+		"sda2/partition":   "2",
+		"sda2/start":       "1050624",
+		"sda2/size":        "1952474112",
+		"sda1/partition":   "1",
+		"sda1/start":       "2048",
+		"sda1/size":        "1048576",
+	})
+	writeSysfs(t, filepath.Join(root, "nvme0n1"), map[string]string{
+		"size":             "0",
+		"device/state":     "dead",
+		"queue/rotational": "0",
+	})
+	writeSysfs(t, filepath.Join(root, "loop0"), map[string]string{"size": "8"})
+
+	info, err := readBlockDevices(root, map[string][]string{"sda2": {"/"}, "sda1": {"/boot/efi"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(info.Devices) != 2 {
+		t.Fatalf("got %d devices, want 2 without loop devices: %+v", len(info.Devices), info.Devices)
+	}
+
+	sda := info.Devices[1]
+	if sda.Name != "sda" || !sda.Rotational || sda.SizeBytes != 1953525168*512 || sda.Model != "WDC WD10EZEX" {
+		t.Fatalf("sda = %+v", sda)
+	}
+	if len(sda.Partitions) != 2 || sda.Partitions[0].Name != "sda1" || sda.Partitions[1].Mountpoints[0] != "/" {
+		t.Fatalf("sda partitions = %+v, want sda1 then sda2 mounted at /", sda.Partitions)
+	}
+
+	text := info.FormatText()
+	for _, want := range []string{"sda: 931.5 GiB, HDD, ATA WDC WD10EZEX", "sda2: 931.0 GiB at offset 513.0 MiB, mounted at /", "nvme0n1 reports zero size", `nvme0n1 is in state "dead"`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("FormatText() does not contain %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// capabilities кеш проверки доступных подсистем, набор метрик хоста не меняется за время работы
+var capabilities struct {
+	mu   sync.Mutex
+	info *Capabilities
+}
+
+// GetCapabilities проверяет, какие подсистемы можно прочитать на этом хосте.
+// Проверка выполняется один раз, далее возвращается кешированный результат
+func GetCapabilities(ctx context.Context) *Capabilities {
+	capabilities.mu.Lock()
+	defer capabilities.mu.Unlock()
+
+	if capabilities.info != nil {
+		return capabilities.info
+	}
+
+	info := &Capabilities{
+		Platform:    runtime.GOOS,
+		CPU:         probeCPU(ctx),
+		Memory:      probeMemory(ctx),
+		Swap:        probeSwap(ctx),
+		Disk:        probeDisk(ctx),
+		Network:     probeNetwork(ctx),
+		Temperature: probeTemperature(ctx),
+		GPU:         probeGPU(),
+		Load:        probeLoad(ctx),
+		Permissions: probePermissions(ctx),
+	}
+
+	logger.SysInfo.Debug().
+		Interface("capabilities", info).
+		Msg("Probed host capabilities")
+
+	// Отмененный контекст дает ложные отрицательные результаты, такая проверка не кешируется
+	if ctx.Err() == nil {
+		capabilities.info = info
+	}
+	return info
+}
+
+func probeCPU(ctx context.Context) bool {
+	count, err := cpu.CountsWithContext(ctx, true)
+	return err == nil && count > 0
+}
+
+func probeMemory(ctx context.Context) bool {
+	vm, err := mem.VirtualMemoryWithContext(ctx)
+	return err == nil && vm.Total > 0
+}
+
+// probeSwap считает swap доступным только при ненулевом объеме: хост без swap раздела его не имеет
+func probeSwap(ctx context.Context) bool {
+	swap, err := mem.SwapMemoryWithContext(ctx)
+	return err == nil && swap.Total > 0
+}
+
+func probeDisk(ctx context.Context) bool {
+	partitions, err := disk.PartitionsWithContext(ctx, false)
+	return err == nil && len(partitions) > 0
+}
+
+func probeNetwork(ctx context.Context) bool {
+	counters, err := net.IOCountersWithContext(ctx, true)
+	return err == nil && len(counters) > 0
+}
+
+// probeTemperature как и GetThermalStatus игнорирует частичные предупреждения, если датчики прочитаны
+func probeTemperature(ctx context.Context) bool {
+	temps, err := host.SensorsTemperaturesWithContext(ctx)
+	var warnings *host.Warnings
+	if err != nil && !errors.As(err, &warnings) {
+		return false
+	}
+	return len(temps) > 0
+}
+
+// probeGPU ищет DRM устройства в Linux. На других платформах GPU не определяется и считается недоступным
+func probeGPU() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	cards, err := filepath.Glob("/sys/class/drm/card[0-9]*")
+	return err == nil && len(cards) > 0
+}
+
+func probeLoad(ctx context.Context) bool {
+	_, err := load.AvgWithContext(ctx)
+	return err == nil
+}
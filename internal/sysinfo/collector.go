@@ -1,29 +1,221 @@
 package sysinfo
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 
 	"mcp-system-info/internal/logger"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
 )
 
+// ErrCollectionTimeout возвращается когда сбор информации не уложился в отведенное время
+var ErrCollectionTimeout = errors.New("system information collection timed out")
+
+// collectionTimeout общий таймаут сбора информации для Get
+var collectionTimeout = 5 * time.Second
+
+// SetCollectionTimeout задает общий таймаут сбора информации для Get
+func SetCollectionTimeout(timeout time.Duration) {
+	collectionTimeout = timeout
+}
+
+// modelNameMaxLength максимальная длина модели CPU в текстовом выводе и логах
+var modelNameMaxLength = 64
+
+// SetModelNameMaxLength задает максимальную длину модели CPU в текстовом выводе и логах.
+// JSON вывод всегда содержит полную строку
+func SetModelNameMaxLength(length int) {
+	modelNameMaxLength = length
+}
+
+// TruncateModelName обрезает модель CPU до заданной длины с многоточием. Некоторые виртуальные CPU
+// сообщают очень длинные строки модели, которые разваливают текстовый вывод
+func TruncateModelName(name string) string {
+	const ellipsis = "..."
+
+	runes := []rune(name)
+	if len(runes) <= modelNameMaxLength {
+		return name
+	}
+	if modelNameMaxLength <= len(ellipsis) {
+		return string(runes[:modelNameMaxLength])
+	}
+	return string(runes[:modelNameMaxLength-len(ellipsis)]) + ellipsis
+}
+
+// Options параметры сбора системной информации
+type Options struct {
+	// CPUSampleInterval окно замера загрузки CPU. 0 - мгновенное значение без блокировки,
+	// иначе сбор блокируется на весь интервал, но результат точнее
+	CPUSampleInterval time.Duration
+	// Steal база замера steal между вызовами одного потребителя (стрима). nil - steal за
+	// CPUSampleInterval или, без него, среднее с момента загрузки
+	Steal *StealBaseline
+}
+
+// Get собирает системную информацию с общим таймаутом сбора
 func Get() (*SystemInfo, error) {
+	return GetWithOptions(Options{})
+}
+
+// GetWithOptions собирает системную информацию с заданными параметрами.
+// Таймаут сбора увеличивается на окно замера CPU
+func GetWithOptions(opts Options) (*SystemInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectionTimeout+opts.CPUSampleInterval)
+	defer cancel()
+
+	return getWithContext(ctx, opts)
+}
+
+// collection накапливает результаты подсистем по мере их готовности
+type collection struct {
+	mu        sync.Mutex
+	opts      Options
+	info      SystemInfo
+	completed []string
+}
+
+// store сохраняет результат подсистемы
+func (c *collection) store(subsystem string, apply func(info *SystemInfo)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	apply(&c.info)
+	c.completed = append(c.completed, subsystem)
+}
+
+// snapshot возвращает копию собранных данных и список завершенных подсистем
+func (c *collection) snapshot() (*SystemInfo, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info := c.info
+	return &info, append([]string(nil), c.completed...)
+}
+
+// GetWithContext собирает системную информацию с учетом дедлайна контекста.
+// При превышении дедлайна возвращает уже собранные подсистемы вместе с ErrCollectionTimeout
+func GetWithContext(ctx context.Context) (*SystemInfo, error) {
+	return getWithContext(ctx, Options{})
+}
+
+// getWithContext собирает системную информацию с учетом дедлайна контекста и параметров сбора
+func getWithContext(ctx context.Context, opts Options) (*SystemInfo, error) {
 	start := time.Now()
-	logger.SysInfo.Debug().Msg("Starting system information collection")
+	logger.SysInfo.Debug().
+		Dur("cpu_sample_interval", opts.CPUSampleInterval).
+		Msg("Starting system information collection")
+
+	result := &collection{opts: opts, info: SystemInfo{Hostname: Hostname()}}
+	done := make(chan error, 1)
+
+	go func() {
+		done <- collectParallel(ctx, result)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+
+	case <-ctx.Done():
+		partial, completed := result.snapshot()
+
+		logger.SysInfo.Warn().
+			Dur("duration", time.Since(start)).
+			Strs("completed_subsystems", completed).
+			Msg("System information collection timed out")
+
+		completedList := "none"
+		if len(completed) > 0 {
+			completedList = strings.Join(completed, ", ")
+		}
+
+		return partial, fmt.Errorf("%w after %v (completed: %s)",
+			ErrCollectionTimeout, time.Since(start).Round(time.Microsecond), completedList)
+	}
+
+	sysInfo, _ := result.snapshot()
+
+	duration := time.Since(start)
+	logger.SysInfo.Info().
+		Dur("duration", duration).
+		Str("hostname", sysInfo.Hostname).
+		Int("cpu_count", sysInfo.CPU.Count).
+		Str("cpu_model", TruncateModelName(sysInfo.CPU.ModelName)).
+		Float64("cpu_usage", sysInfo.CPU.UsagePercent).
+		Float64("memory_total_gb", float64(sysInfo.Memory.Total)/(1024*1024*1024)).
+		Float64("memory_used_percent", sysInfo.Memory.UsedPercent).
+		Msg("System information collection completed")
+
+	return sysInfo, nil
+}
+
+// collector собирает одну независимую подсистему и сохраняет ее в collection
+type collector struct {
+	name    string
+	collect func(ctx context.Context, result *collection) error
+}
 
+// collectors независимые подсистемы, каждая пишет только свои поля SystemInfo
+var collectors = []collector{
+	{name: "cpu_info", collect: collectCPUInfo},
+	{name: "cpu_usage", collect: collectCPUUsage},
+	{name: "cpu_steal", collect: collectCPUSteal},
+	{name: "memory", collect: collectMemory},
+	{name: "load", collect: collectLoad},
+}
+
+// collectParallel собирает все подсистемы параллельно и объединяет их ошибки
+func collectParallel(ctx context.Context, result *collection) error {
+	errs := make([]error, len(collectors))
+
+	var wg sync.WaitGroup
+	for i, c := range collectors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.collect(ctx, result)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// collectSequential собирает подсистемы по очереди, используется как базовая линия в бенчмарках
+func collectSequential(ctx context.Context, result *collection) error {
+	var errs []error
+	for _, c := range collectors {
+		if err := c.collect(ctx, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectCPUInfo собирает количество ядер и модель процессора
+func collectCPUInfo(ctx context.Context, result *collection) error {
 	cpuCount := runtime.NumCPU()
 	logger.SysInfo.Debug().Int("cpu_count", cpuCount).Msg("Got CPU count from runtime")
+	cores := availableCores()
 
-	cpuInfo, err := cpu.Info()
+	cpuInfo, err := cpu.InfoWithContext(ctx)
 	if err != nil {
 		logger.SysInfo.Error().
 			Err(err).
 			Msg("Failed to get CPU information")
-		return nil, fmt.Errorf("failed to get CPU information: %v", err)
+		return fmt.Errorf("failed to get CPU information: %v", err)
 	}
 
 	var modelName string
@@ -37,12 +229,44 @@ func Get() (*SystemInfo, error) {
 		logger.SysInfo.Warn().Msg("No CPU information available")
 	}
 
-	cpuPercent, err := cpu.Percent(0, false)
+	result.store("cpu_info", func(info *SystemInfo) {
+		info.CPU.Count = cpuCount
+		info.CPU.AvailableCores = cores
+		info.CPU.ModelName = modelName
+	})
+
+	return nil
+}
+
+// WarmupCPU делает пробный мгновенный замер CPU и ждет warmup. Мгновенный замер считает загрузку
+// с предыдущего вызова, а без прогрева первый вызов после старта покрывает лишь доли секунды
+// с запуска процесса и обычно показывает около 0%
+func WarmupCPU(ctx context.Context, warmup time.Duration) {
+	if _, err := cpu.PercentWithContext(ctx, 0, false); err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("CPU warmup sample failed")
+		return
+	}
+
+	select {
+	case <-time.After(warmup):
+	case <-ctx.Done():
+	}
+
+	logger.SysInfo.Debug().
+		Dur("warmup", warmup).
+		Msg("CPU usage baseline warmed up")
+}
+
+// collectCPUUsage собирает текущую загрузку процессора
+func collectCPUUsage(ctx context.Context, result *collection) error {
+	cpuPercent, err := cpu.PercentWithContext(ctx, result.opts.CPUSampleInterval, false)
 	if err != nil {
 		logger.SysInfo.Error().
 			Err(err).
 			Msg("Failed to get CPU usage")
-		return nil, fmt.Errorf("failed to get CPU usage: %v", err)
+		return fmt.Errorf("failed to get CPU usage: %v", err)
 	}
 
 	var usagePercent float64
@@ -55,12 +279,48 @@ func Get() (*SystemInfo, error) {
 		logger.SysInfo.Warn().Msg("No CPU usage data available")
 	}
 
-	memInfo, err := mem.VirtualMemory()
+	result.store("cpu_usage", func(info *SystemInfo) {
+		info.CPU.UsagePercent = usagePercent
+	})
+
+	return nil
+}
+
+// collectCPUSteal собирает долю времени CPU, отобранного гипервизором (только Linux)
+func collectCPUSteal(ctx context.Context, result *collection) error {
+	stealPercent, available, err := readStealPercent(ctx, result.opts.Steal, result.opts.CPUSampleInterval)
+	if err != nil {
+		// Steal необязательная метрика: ее ошибка не должна срывать весь сбор
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("CPU steal time is not available")
+		result.store("cpu_steal", func(info *SystemInfo) {
+			info.CPU.StealPercent = 0
+		})
+		return nil
+	}
+
+	if available {
+		logger.SysInfo.Debug().
+			Float64("cpu_steal_percent", stealPercent).
+			Msg("Got CPU steal percentage")
+	}
+
+	result.store("cpu_steal", func(info *SystemInfo) {
+		info.CPU.StealPercent = stealPercent
+	})
+
+	return nil
+}
+
+// collectMemory собирает информацию о виртуальной памяти
+func collectMemory(ctx context.Context, result *collection) error {
+	memInfo, err := mem.VirtualMemoryWithContext(ctx)
 	if err != nil {
 		logger.SysInfo.Error().
 			Err(err).
 			Msg("Failed to get memory information")
-		return nil, fmt.Errorf("failed to get memory information: %v", err)
+		return fmt.Errorf("failed to get memory information: %v", err)
 	}
 
 	logger.SysInfo.Debug().
@@ -70,29 +330,46 @@ func Get() (*SystemInfo, error) {
 		Float64("memory_used_percent", memInfo.UsedPercent).
 		Msg("Got memory information")
 
-	sysInfo := &SystemInfo{
-		CPU: CPUInfo{
-			Count:        cpuCount,
-			ModelName:    modelName,
-			UsagePercent: usagePercent,
-		},
-		Memory: MemoryInfo{
+	result.store("memory", func(info *SystemInfo) {
+		info.Memory = MemoryInfo{
 			Total:       memInfo.Total,
 			Available:   memInfo.Available,
 			Used:        memInfo.Used,
 			UsedPercent: memInfo.UsedPercent,
-		},
+		}
+	})
+
+	return nil
+}
+
+// collectLoad собирает среднюю загрузку системы.
+// Load average есть не на всех платформах, поэтому ошибка не прерывает сбор остальных подсистем
+func collectLoad(ctx context.Context, result *collection) error {
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Load average is not available")
+		result.store("load", func(info *SystemInfo) {
+			info.Load = LoadInfo{}
+		})
+		return nil
 	}
 
-	duration := time.Since(start)
-	logger.SysInfo.Info().
-		Dur("duration", duration).
-		Int("cpu_count", cpuCount).
-		Str("cpu_model", modelName).
-		Float64("cpu_usage", usagePercent).
-		Float64("memory_total_gb", float64(memInfo.Total)/(1024*1024*1024)).
-		Float64("memory_used_percent", memInfo.UsedPercent).
-		Msg("System information collection completed")
+	logger.SysInfo.Debug().
+		Float64("load1", avg.Load1).
+		Float64("load5", avg.Load5).
+		Float64("load15", avg.Load15).
+		Msg("Got load average")
 
-	return sysInfo, nil
+	result.store("load", func(info *SystemInfo) {
+		info.Load = LoadInfo{
+			Available: true,
+			Load1:     avg.Load1,
+			Load5:     avg.Load5,
+			Load15:    avg.Load15,
+		}
+	})
+
+	return nil
 }
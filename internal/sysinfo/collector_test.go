@@ -0,0 +1,66 @@
+package sysinfo
+
+import (
+	"context"
+	"testing"
+)
+
+// BenchmarkGet измеряет параллельный сбор всех подсистем
+func BenchmarkGet(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := GetWithContext(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetSequential измеряет последовательный сбор тех же подсистем для сравнения
+func BenchmarkGetSequential(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var result collection
+		if err := collectSequential(ctx, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkCollector измеряет отдельную подсистему
+func benchmarkCollector(b *testing.B, collect func(ctx context.Context, result *collection) error) {
+	ctx := context.Background()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var result collection
+		if err := collect(ctx, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCollectCPUInfo(b *testing.B) {
+	benchmarkCollector(b, collectCPUInfo)
+}
+
+func BenchmarkCollectCPUUsage(b *testing.B) {
+	benchmarkCollector(b, collectCPUUsage)
+}
+
+func BenchmarkCollectMemory(b *testing.B) {
+	benchmarkCollector(b, collectMemory)
+}
+
+func BenchmarkGetFDInfo(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := GetFDInfo(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+const (
+	conntrackCountPath = "/proc/sys/net/netfilter/nf_conntrack_count"
+	conntrackMaxPath   = "/proc/sys/net/netfilter/nf_conntrack_max"
+	portRangePath      = "/proc/sys/net/ipv4/ip_local_port_range"
+)
+
+// GetConntrackInfo читает заполненность таблицы conntrack и диапазон эфемерных портов (только Linux).
+// Отсутствующие файлы (модуль nf_conntrack не загружен) не являются ошибкой: раздел помечается недоступным
+func GetConntrackInfo(ctx context.Context) (*ConntrackInfo, error) {
+	info := &ConntrackInfo{Supported: runtime.GOOS == "linux"}
+	if !info.Supported {
+		return info, nil
+	}
+
+	count, errCount := readProcUint(conntrackCountPath)
+	maxEntries, errMax := readProcUint(conntrackMaxPath)
+	if errCount == nil && errMax == nil {
+		info.ConntrackAvailable = true
+		info.ConntrackCount = count
+		info.ConntrackMax = maxEntries
+		if maxEntries > 0 {
+			info.ConntrackUsedPercent = float64(count) / float64(maxEntries) * 100
+		}
+	} else {
+		logger.SysInfo.Debug().
+			AnErr("count_error", errCount).
+			AnErr("max_error", errMax).
+			Msg("Conntrack counters are not available")
+	}
+
+	low, high, err := readPortRange()
+	if err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("Ephemeral port range is not available")
+		return info, nil
+	}
+	info.PortRangeAvailable = true
+	info.PortRangeLow = low
+	info.PortRangeHigh = high
+
+	conns, err := net.ConnectionsWithContext(ctx, "inet")
+	if err != nil && len(conns) == 0 {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get network connections")
+		return nil, fmt.Errorf("failed to get network connections: %v", err)
+	}
+
+	// Оценка: число различных локальных портов из эфемерного диапазона у не слушающих сокетов.
+	// Ядро может повторно использовать порт для разных удаленных адресов, поэтому это верхняя оценка занятости
+	used := make(map[uint32]bool)
+	for _, conn := range conns {
+		if conn.Status == "LISTEN" || conn.Laddr.Port < low || conn.Laddr.Port > high {
+			continue
+		}
+		used[conn.Laddr.Port] = true
+	}
+	info.EphemeralPortsInUse = len(used)
+	info.EphemeralPortsUsedPercent = float64(len(used)) / float64(high-low+1) * 100
+
+	logger.SysInfo.Debug().
+		Bool("conntrack_available", info.ConntrackAvailable).
+		Uint64("conntrack_count", info.ConntrackCount).
+		Int("ephemeral_ports_in_use", info.EphemeralPortsInUse).
+		Msg("Got conntrack information")
+
+	return info, nil
+}
+
+// readProcUint читает одно число из файла /proc
+func readProcUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// readPortRange читает диапазон эфемерных портов "low\thigh"
+func readPortRange() (uint32, uint32, error) {
+	data, err := os.ReadFile(portRangePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected %s format: %q", portRangePath, data)
+	}
+	low, errLow := strconv.ParseUint(fields[0], 10, 16)
+	high, errHigh := strconv.ParseUint(fields[1], 10, 16)
+	if errLow != nil || errHigh != nil || low > high {
+		return 0, 0, fmt.Errorf("unexpected %s format: %q", portRangePath, data)
+	}
+
+	return uint32(low), uint32(high), nil
+}
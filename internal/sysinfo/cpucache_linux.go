@@ -0,0 +1,61 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+)
+
+// cpuCacheRoot каталог описаний кешей первого процессора в sysfs
+const cpuCacheRoot = "/sys/devices/system/cpu/cpu0/cache"
+
+// readCPUCaches читает уровни, типы и размеры кешей первого процессора из sysfs.
+// Пусто, если ядро не экспортирует топологию кешей (часть ARM плат и контейнеров)
+func readCPUCaches() []CPUCache {
+	dirs, err := filepath.Glob(filepath.Join(cpuCacheRoot, "index[0-9]*"))
+	if err != nil {
+		return nil
+	}
+
+	var caches []CPUCache
+	for _, dir := range dirs {
+		read := func(name string) string {
+			data, _ := os.ReadFile(filepath.Join(dir, name))
+			return strings.TrimSpace(string(data))
+		}
+
+		level, err := strconv.Atoi(read("level"))
+		if err != nil {
+			continue
+		}
+		size, err := parseCacheSize(read("size"))
+		if err != nil {
+			logger.SysInfo.Warn().
+				Err(err).
+				Str("cache", dir).
+				Msg("Failed to parse CPU cache size")
+			continue
+		}
+
+		caches = append(caches, CPUCache{
+			Level:     level,
+			Type:      read("type"),
+			Size:      size,
+			SharedCPU: read("shared_cpu_list"),
+		})
+	}
+
+	sort.Slice(caches, func(i, j int) bool {
+		if caches[i].Level != caches[j].Level {
+			return caches[i].Level < caches[j].Level
+		}
+		return caches[i].Type < caches[j].Type
+	})
+	return caches
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package sysinfo
+
+// readCPUCaches топология кешей читается только из sysfs Linux
+func readCPUCaches() []CPUCache {
+	return nil
+}
@@ -0,0 +1,132 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// keyCPUFeatures флаги, которые чаще всего требуют рабочие нагрузки, по архитектуре.
+// Имена как в /proc/cpuinfo, одна возможность может называться несколькими флагами
+var keyCPUFeatures = map[string][]CPUFeatureCheck{
+	"amd64": {
+		{Name: "SSE4.2", Flags: []string{"sse4_2"}},
+		{Name: "POPCNT", Flags: []string{"popcnt"}},
+		{Name: "AVX", Flags: []string{"avx"}},
+		{Name: "AVX2", Flags: []string{"avx2"}},
+		{Name: "AVX-512F", Flags: []string{"avx512f"}},
+		{Name: "FMA", Flags: []string{"fma"}},
+		{Name: "BMI2", Flags: []string{"bmi2"}},
+		{Name: "AES-NI", Flags: []string{"aes", "aesni"}},
+		{Name: "SHA", Flags: []string{"sha_ni", "sha"}},
+		{Name: "Hardware virtualization", Flags: []string{"vmx", "svm"}},
+	},
+	"arm64": {
+		{Name: "NEON (ASIMD)", Flags: []string{"asimd"}},
+		{Name: "AES", Flags: []string{"aes"}},
+		{Name: "SHA-256", Flags: []string{"sha2"}},
+		{Name: "CRC32", Flags: []string{"crc32"}},
+		{Name: "Atomics (LSE)", Flags: []string{"atomics"}},
+		{Name: "SVE", Flags: []string{"sve"}},
+	},
+}
+
+// GetCPUFeatures возвращает флаги возможностей процессора и размеры кешей L1/L2/L3 без замера загрузки.
+// Флаги - пересечение по всем процессорам: возможность отмечается, только если ее поддерживают все
+func GetCPUFeatures(ctx context.Context) (*CPUFeatures, error) {
+	infos, err := cpu.InfoWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get CPU information")
+		return nil, fmt.Errorf("failed to get CPU information: %v", err)
+	}
+
+	features := &CPUFeatures{
+		Platform: runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Flags:    commonCPUFlags(infos),
+		Caches:   readCPUCaches(),
+	}
+	if len(infos) > 0 {
+		features.VendorID = infos[0].VendorID
+		features.ModelName = infos[0].ModelName
+		if len(features.Caches) == 0 && infos[0].CacheSize > 0 {
+			// Без топологии кешей остается только размер, который сообщает cpu.Info (обычно последний уровень)
+			features.Caches = []CPUCache{{Type: "Unified", Size: uint64(infos[0].CacheSize) * 1024}}
+		}
+	}
+
+	present := make(map[string]bool, len(features.Flags))
+	for _, flag := range features.Flags {
+		present[flag] = true
+	}
+	for _, check := range keyCPUFeatures[runtime.GOARCH] {
+		for _, flag := range check.Flags {
+			check.Supported = check.Supported || present[flag]
+		}
+		features.Key = append(features.Key, check)
+	}
+
+	logger.SysInfo.Debug().
+		Int("flags", len(features.Flags)).
+		Int("caches", len(features.Caches)).
+		Msg("Got CPU features")
+
+	return features, nil
+}
+
+// commonCPUFlags возвращает отсортированные флаги, общие для всех записей cpu.Info, в нижнем регистре
+func commonCPUFlags(infos []cpu.InfoStat) []string {
+	if len(infos) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, info := range infos {
+		seen := make(map[string]bool, len(info.Flags))
+		for _, flag := range info.Flags {
+			flag = strings.ToLower(strings.TrimSpace(flag))
+			if flag != "" && !seen[flag] {
+				seen[flag] = true
+				counts[flag]++
+			}
+		}
+	}
+
+	var flags []string
+	for flag, count := range counts {
+		if count == len(infos) {
+			flags = append(flags, flag)
+		}
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// parseCacheSize разбирает размер кеша из sysfs ("32K", "1024K", "8M") в байты
+func parseCacheSize(value string) (uint64, error) {
+	value = strings.TrimSpace(value)
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(value, "K"):
+		multiplier = 1024
+	case strings.HasSuffix(value, "M"):
+		multiplier = 1024 * 1024
+	case strings.HasSuffix(value, "G"):
+		multiplier = 1024 * 1024 * 1024
+	}
+
+	size, err := strconv.ParseUint(strings.TrimRight(value, "KMG"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache size %q: %v", value, err)
+	}
+	return size * multiplier, nil
+}
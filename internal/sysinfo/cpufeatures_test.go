@@ -0,0 +1,57 @@
+package sysinfo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+func TestCommonCPUFlags(t *testing.T) {
+	infos := []cpu.InfoStat{
+		{Flags: []string{"sse4_2", "AVX2", "aes", "avx512f"}},
+		{Flags: []string{"aes", "avx2", "sse4_2", "sse4_2"}},
+	}
+	want := []string{"aes", "avx2", "sse4_2"}
+	if got := commonCPUFlags(infos); !reflect.DeepEqual(got, want) {
+		t.Errorf("commonCPUFlags = %v, want %v", got, want)
+	}
+	if got := commonCPUFlags(nil); got != nil {
+		t.Errorf("commonCPUFlags(nil) = %v, want nil", got)
+	}
+}
+
+func TestParseCacheSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    uint64
+		wantErr bool
+	}{
+		{value: "32K\n", want: 32 * 1024},
+		{value: "8M", want: 8 * 1024 * 1024},
+		{value: "512", want: 512},
+		{value: "", wantErr: true},
+		{value: "xK", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCacheSize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseCacheSize(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("parseCacheSize(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCPUFeaturesFormatTextWithoutFlags(t *testing.T) {
+	features := &CPUFeatures{Platform: "windows", Arch: "amd64", ModelName: "Test CPU"}
+	text := features.FormatText()
+	for _, want := range []string{"Caches:\n- Not exposed on this platform", "Feature flags: not exposed on this platform (windows/amd64)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("FormatText() missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// staticCPUInfo кеш статической информации о процессоре, она не меняется за время жизни процесса
+var staticCPUInfo struct {
+	mu   sync.Mutex
+	info *StaticCPUInfo
+}
+
+// GetStaticCPUInfo возвращает модель, количество ядер, частоту и кеш процессоров без замера загрузки.
+// Результат кешируется после первого успешного чтения
+func GetStaticCPUInfo(ctx context.Context) (*StaticCPUInfo, error) {
+	staticCPUInfo.mu.Lock()
+	defer staticCPUInfo.mu.Unlock()
+
+	if staticCPUInfo.info != nil {
+		return staticCPUInfo.info, nil
+	}
+
+	infos, err := cpu.InfoWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get CPU information")
+		return nil, fmt.Errorf("failed to get CPU information: %v", err)
+	}
+
+	physical, err := cpu.CountsWithContext(ctx, false)
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to get physical core count")
+	}
+	logical, err := cpu.CountsWithContext(ctx, true)
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to get logical core count")
+	}
+
+	info := &StaticCPUInfo{
+		PhysicalCores: physical,
+		LogicalCores:  logical,
+		Sockets:       groupSockets(infos),
+	}
+
+	logger.SysInfo.Debug().
+		Int("physical_cores", info.PhysicalCores).
+		Int("logical_cores", info.LogicalCores).
+		Int("sockets", len(info.Sockets)).
+		Msg("Got static CPU information")
+
+	staticCPUInfo.info = info
+	return info, nil
+}
+
+// GetHostSummary возвращает модель и количество CPU и объем памяти. В отличие от Get не замеряет
+// загрузку CPU и steal, поэтому не сбрасывает их базу между сэмплами стрима
+func GetHostSummary(ctx context.Context) (*HostSummary, error) {
+	cpuInfo, err := GetStaticCPUInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	memInfo, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory information: %v", err)
+	}
+
+	summary := &HostSummary{
+		CPUCount:    runtime.NumCPU(),
+		MemoryTotal: memInfo.Total,
+	}
+	if len(cpuInfo.Sockets) > 0 {
+		summary.CPUModel = cpuInfo.Sockets[0].ModelName
+	}
+	return summary, nil
+}
+
+// groupSockets группирует записи cpu.Info по физическому пакету.
+// На Linux каждая запись - логический процессор, на Windows и macOS - целый пакет
+func groupSockets(infos []cpu.InfoStat) []CPUSocket {
+	type socketAcc struct {
+		socket  CPUSocket
+		coreIDs map[string]bool
+		cores   int
+	}
+
+	sockets := make(map[string]*socketAcc)
+	for _, stat := range infos {
+		acc, ok := sockets[stat.PhysicalID]
+		if !ok {
+			acc = &socketAcc{
+				socket: CPUSocket{
+					PhysicalID:  stat.PhysicalID,
+					VendorID:    stat.VendorID,
+					ModelName:   stat.ModelName,
+					Family:      stat.Family,
+					Model:       stat.Model,
+					Stepping:    stat.Stepping,
+					Mhz:         stat.Mhz,
+					CacheSizeKB: stat.CacheSize,
+				},
+				coreIDs: make(map[string]bool),
+			}
+			sockets[stat.PhysicalID] = acc
+		}
+
+		acc.socket.Threads++
+		acc.cores += int(stat.Cores)
+		if stat.CoreID != "" {
+			acc.coreIDs[stat.CoreID] = true
+		}
+	}
+
+	result := make([]CPUSocket, 0, len(sockets))
+	for _, acc := range sockets {
+		if len(acc.coreIDs) > 0 {
+			acc.socket.Cores = len(acc.coreIDs)
+		} else {
+			// Записи на уровне пакета содержат число ядер, но не потоков
+			acc.socket.Cores = acc.cores
+			acc.socket.Threads = 0
+		}
+		result = append(result, acc.socket)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PhysicalID < result[j].PhysicalID
+	})
+
+	return result
+}
@@ -0,0 +1,140 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// cpuTimesFields поля cpu.TimesStat, которые платформа действительно заполняет помимо user/system/idle
+type cpuTimesFields struct {
+	nice, iowait, irq, softirq, steal bool
+}
+
+// platformCPUTimesFields возвращает поля, доступные на текущей платформе
+func platformCPUTimesFields() cpuTimesFields {
+	switch runtime.GOOS {
+	case "linux":
+		return cpuTimesFields{nice: true, iowait: true, irq: true, softirq: true, steal: true}
+	case "windows":
+		return cpuTimesFields{irq: true}
+	default:
+		return cpuTimesFields{nice: true}
+	}
+}
+
+// GetCPUTimes возвращает распределение времени CPU по состояниям за interval: суммарно и,
+// при perCore, по каждому ядру. Вызов блокируется на interval между двумя чтениями счетчиков
+func GetCPUTimes(ctx context.Context, interval time.Duration, perCore bool) (*CPUTimesInfo, error) {
+	before, err := cpuTimes(ctx, perCore)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-time.After(interval):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	after, err := cpuTimes(ctx, perCore)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := platformCPUTimesFields()
+	info := &CPUTimesInfo{
+		Interval: interval,
+		Total:    cpuTimesBreakdown(before[0], after[0], fields),
+	}
+	if perCore {
+		for i := 1; i < len(before) && i < len(after); i++ {
+			info.PerCore = append(info.PerCore, cpuTimesBreakdown(before[i], after[i], fields))
+		}
+	}
+
+	logger.SysInfo.Debug().
+		Dur("interval", interval).
+		Float64("user", info.Total.User).
+		Float64("system", info.Total.System).
+		Float64("idle", info.Total.Idle).
+		Int("cores", len(info.PerCore)).
+		Msg("Got CPU times breakdown")
+
+	return info, nil
+}
+
+// cpuTimes читает суммарные счетчики CPU, а при perCore добавляет после них счетчики каждого ядра
+func cpuTimes(ctx context.Context, perCore bool) ([]cpu.TimesStat, error) {
+	total, err := cpu.TimesWithContext(ctx, false)
+	if err != nil || len(total) == 0 {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get CPU times")
+		return nil, fmt.Errorf("failed to get CPU times: %v", err)
+	}
+	if !perCore {
+		return total, nil
+	}
+
+	cores, err := cpu.TimesWithContext(ctx, true)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get per-core CPU times")
+		return nil, fmt.Errorf("failed to get per-core CPU times: %v", err)
+	}
+	return append(total, cores...), nil
+}
+
+// cpuTimesBreakdown переводит прирост счетчиков между двумя замерами в проценты времени
+func cpuTimesBreakdown(before, after cpu.TimesStat, fields cpuTimesFields) CPUTimesBreakdown {
+	delta := func(b, a float64) float64 {
+		if a < b {
+			return 0
+		}
+		return a - b
+	}
+
+	user := delta(before.User, after.User)
+	system := delta(before.System, after.System)
+	idle := delta(before.Idle, after.Idle)
+	nice := delta(before.Nice, after.Nice)
+	iowait := delta(before.Iowait, after.Iowait)
+	irq := delta(before.Irq, after.Irq)
+	softirq := delta(before.Softirq, after.Softirq)
+	steal := delta(before.Steal, after.Steal)
+
+	// Guest время уже учтено в User, поэтому не суммируется повторно
+	total := user + system + idle + nice + iowait + irq + softirq + steal
+	percent := func(value float64) float64 {
+		if total <= 0 {
+			return 0
+		}
+		return value / total * 100
+	}
+	optional := func(available bool, value float64) *float64 {
+		if !available {
+			return nil
+		}
+		p := percent(value)
+		return &p
+	}
+
+	return CPUTimesBreakdown{
+		CPU:     after.CPU,
+		User:    percent(user),
+		System:  percent(system),
+		Idle:    percent(idle),
+		Nice:    optional(fields.nice, nice),
+		Iowait:  optional(fields.iowait, iowait),
+		Irq:     optional(fields.irq, irq),
+		Softirq: optional(fields.softirq, softirq),
+		Steal:   optional(fields.steal, steal),
+	}
+}
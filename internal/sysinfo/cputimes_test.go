@@ -0,0 +1,29 @@
+package sysinfo
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+func TestCPUTimesBreakdown(t *testing.T) {
+	before := cpu.TimesStat{CPU: "cpu-total", User: 100, System: 50, Idle: 800, Iowait: 10}
+	after := cpu.TimesStat{CPU: "cpu-total", User: 120, System: 60, Idle: 860, Iowait: 20}
+
+	linux := cpuTimesBreakdown(before, after, cpuTimesFields{nice: true, iowait: true, irq: true, softirq: true, steal: true})
+	if linux.User != 20 || linux.System != 10 || linux.Idle != 60 {
+		t.Fatalf("user/system/idle = %.1f/%.1f/%.1f, want 20/10/60", linux.User, linux.System, linux.Idle)
+	}
+	if linux.Iowait == nil || *linux.Iowait != 10 {
+		t.Fatalf("iowait = %v, want 10", linux.Iowait)
+	}
+
+	windows := cpuTimesBreakdown(before, after, cpuTimesFields{irq: true})
+	if windows.Iowait != nil || windows.Steal != nil || windows.Irq == nil {
+		t.Fatalf("unsupported fields must be omitted: %+v", windows)
+	}
+
+	if idle := cpuTimesBreakdown(after, after, cpuTimesFields{}); idle.User != 0 || idle.Idle != 0 {
+		t.Fatalf("breakdown without elapsed time = %+v, want zeros", idle)
+	}
+}
@@ -0,0 +1,87 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// GetDiskIO вычисляет iostat-подобные метрики по двум замерам disk.IOCounters с интервалом
+func GetDiskIO(ctx context.Context, interval time.Duration) (*DiskIOInfo, error) {
+	first, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get disk I/O counters")
+		return nil, fmt.Errorf("failed to get disk I/O counters: %v", err)
+	}
+
+	select {
+	case <-time.After(interval):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	second, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get disk I/O counters")
+		return nil, fmt.Errorf("failed to get disk I/O counters: %v", err)
+	}
+
+	// Время занятости устройства (io_ticks) отдается ядром Linux
+	info := &DiskIOInfo{
+		Interval:             interval,
+		UtilizationAvailable: runtime.GOOS == "linux",
+	}
+
+	intervalMs := float64(interval.Milliseconds())
+	seconds := interval.Seconds()
+
+	for name, after := range second {
+		before, ok := first[name]
+		if !ok {
+			continue
+		}
+
+		reads := after.ReadCount - before.ReadCount
+		writes := after.WriteCount - before.WriteCount
+		stats := DiskIOStats{
+			Device:        name,
+			ReadsPerSec:   float64(reads) / seconds,
+			WritesPerSec:  float64(writes) / seconds,
+			ReadBytesSec:  float64(after.ReadBytes-before.ReadBytes) / seconds,
+			WriteBytesSec: float64(after.WriteBytes-before.WriteBytes) / seconds,
+		}
+
+		if ops := reads + writes; ops > 0 {
+			stats.AwaitMs = float64((after.ReadTime-before.ReadTime)+(after.WriteTime-before.WriteTime)) / float64(ops)
+		}
+
+		if info.UtilizationAvailable {
+			stats.UtilizationPercent = min(float64(after.IoTime-before.IoTime)/intervalMs*100, 100)
+			stats.AvgQueueSize = float64(after.WeightedIO-before.WeightedIO) / intervalMs
+		}
+
+		info.Devices = append(info.Devices, stats)
+	}
+
+	sort.Slice(info.Devices, func(i, j int) bool {
+		return info.Devices[i].Device < info.Devices[j].Device
+	})
+
+	logger.SysInfo.Debug().
+		Int("device_count", len(info.Devices)).
+		Dur("interval", interval).
+		Bool("utilization_available", info.UtilizationAvailable).
+		Msg("Got disk I/O statistics")
+
+	return info, nil
+}
@@ -0,0 +1,84 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// probeBlockSize размер блока записи и чтения пробы
+const probeBlockSize = 64 * 1024
+
+// ProbeDiskLatency записывает во временный файл в dir size байт блоками с fsync, читает его обратно
+// и удаляет. Чтение может обслуживаться из page cache, поэтому показательна в первую очередь запись
+func ProbeDiskLatency(ctx context.Context, dir string, size int) (*DiskLatencyInfo, error) {
+	file, err := os.CreateTemp(dir, "mcp-disk-probe-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create probe file: %v", err)
+	}
+	path := file.Name()
+	defer func() {
+		file.Close()
+		if err := os.Remove(path); err != nil {
+			logger.SysInfo.Warn().
+				Err(err).
+				Str("path", path).
+				Msg("Failed to remove disk probe file")
+		}
+	}()
+
+	block := make([]byte, probeBlockSize)
+	for i := range block {
+		block[i] = byte(i)
+	}
+
+	info := &DiskLatencyInfo{Dir: dir, SizeBytes: size, BlockSize: probeBlockSize}
+
+	start := time.Now()
+	for written := 0; written < size; written += probeBlockSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n := min(probeBlockSize, size-written)
+		if _, err := file.Write(block[:n]); err != nil {
+			return nil, fmt.Errorf("failed to write probe file: %v", err)
+		}
+	}
+	// fsync гарантирует, что измеряется запись на устройство, а не в page cache
+	if err := file.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to sync probe file: %v", err)
+	}
+	info.WriteLatency = time.Since(start)
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind probe file: %v", err)
+	}
+
+	start = time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		_, err := file.Read(block)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read probe file: %v", err)
+		}
+	}
+	info.ReadLatency = time.Since(start)
+
+	logger.SysInfo.Debug().
+		Str("dir", dir).
+		Int("size_bytes", size).
+		Dur("write_latency", info.WriteLatency).
+		Dur("read_latency", info.ReadLatency).
+		Msg("Disk latency probe completed")
+
+	return info, nil
+}
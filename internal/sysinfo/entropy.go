@@ -0,0 +1,48 @@
+package sysinfo
+
+import (
+	"path/filepath"
+
+	"mcp-system-info/internal/logger"
+)
+
+const (
+	// entropyDir каталог счетчиков пула энтропии ядра Linux
+	entropyDir = "/proc/sys/kernel/random"
+	// entropyLowBits порог доступной энтропии, ниже которого чтение /dev/random может блокироваться
+	entropyLowBits = 200
+)
+
+// GetEntropyInfo читает доступную энтропию и размер пула ядра. Вне Linux файлов нет,
+// и раздел помечается недоступным
+func GetEntropyInfo() *EntropyInfo {
+	return readEntropyInfo(entropyDir)
+}
+
+// readEntropyInfo читает entropy_avail и poolsize из каталога dir
+func readEntropyInfo(dir string) *EntropyInfo {
+	info := &EntropyInfo{LowThreshold: entropyLowBits}
+
+	available, err := readProcUint(filepath.Join(dir, "entropy_avail"))
+	if err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("Entropy counters are not available")
+		return info
+	}
+	info.Available = true
+	info.EntropyBits = available
+	info.Low = available < entropyLowBits
+
+	// poolsize необязателен: без него сообщаем только доступную энтропию
+	if poolSize, err := readProcUint(filepath.Join(dir, "poolsize")); err == nil {
+		info.PoolSizeBits = poolSize
+	}
+
+	logger.SysInfo.Debug().
+		Uint64("entropy_avail", info.EntropyBits).
+		Uint64("poolsize", info.PoolSizeBits).
+		Msg("Entropy information collected")
+
+	return info
+}
@@ -0,0 +1,36 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadEntropyInfo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "entropy_avail"), []byte("150\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "poolsize"), []byte("256\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info := readEntropyInfo(dir)
+	if !info.Available || info.EntropyBits != 150 || info.PoolSizeBits != 256 || !info.Low {
+		t.Fatalf("readEntropyInfo() = %+v, want 150/256 low", info)
+	}
+	if text := info.FormatText(); !strings.Contains(text, "Warning: entropy is low") {
+		t.Fatalf("FormatText() has no low-entropy warning:\n%s", text)
+	}
+}
+
+func TestReadEntropyInfoMissing(t *testing.T) {
+	info := readEntropyInfo(filepath.Join(t.TempDir(), "missing"))
+	if info.Available {
+		t.Fatalf("readEntropyInfo() = %+v, want unavailable", info)
+	}
+	if text := info.FormatText(); !strings.Contains(text, "unavailable") {
+		t.Fatalf("FormatText() = %q, want unavailable", text)
+	}
+}
@@ -0,0 +1,73 @@
+//go:build !windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"mcp-system-info/internal/logger"
+)
+
+// GetFDInfo собирает информацию о файловых дескрипторах текущего процесса и системы
+func GetFDInfo() (*FDInfo, error) {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get RLIMIT_NOFILE")
+		return nil, fmt.Errorf("failed to get RLIMIT_NOFILE: %v", err)
+	}
+
+	info := &FDInfo{
+		Platform:  runtime.GOOS,
+		Supported: true,
+		OpenFDs:   countOpenFDs(),
+		SoftLimit: uint64(rlimit.Cur),
+		HardLimit: uint64(rlimit.Max),
+	}
+
+	// Системная статистика доступна только в Linux
+	if data, err := os.ReadFile("/proc/sys/fs/file-nr"); err == nil {
+		fields := strings.Fields(string(data))
+		if len(fields) == 3 {
+			allocated, errAllocated := strconv.ParseUint(fields[0], 10, 64)
+			maxFiles, errMax := strconv.ParseUint(fields[2], 10, 64)
+			if errAllocated == nil && errMax == nil {
+				info.SystemAvailable = true
+				info.SystemAllocated = allocated
+				info.SystemMax = maxFiles
+			}
+		}
+	} else {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("System-wide file-nr is not readable")
+	}
+
+	logger.SysInfo.Debug().
+		Int("open_fds", info.OpenFDs).
+		Uint64("soft_limit", info.SoftLimit).
+		Uint64("hard_limit", info.HardLimit).
+		Bool("system_available", info.SystemAvailable).
+		Msg("Got file descriptor information")
+
+	return info, nil
+}
+
+// countOpenFDs считает открытые дескрипторы процесса, -1 если подсчет недоступен
+func countOpenFDs() int {
+	for _, dir := range []string{"/proc/self/fd", "/dev/fd"} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		// Исключаем дескриптор, открытый самим os.ReadDir
+		return len(entries) - 1
+	}
+	return -1
+}
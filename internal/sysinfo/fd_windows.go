@@ -0,0 +1,42 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"mcp-system-info/internal/logger"
+)
+
+var procGetProcessHandleCount = syscall.NewLazyDLL("kernel32.dll").NewProc("GetProcessHandleCount")
+
+// GetFDInfo собирает информацию о handle текущего процесса (в Windows нет RLIMIT_NOFILE)
+func GetFDInfo() (*FDInfo, error) {
+	info := &FDInfo{
+		Platform: runtime.GOOS,
+		OpenFDs:  -1,
+	}
+
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		logger.SysInfo.Warn().Err(err).Msg("Failed to get current process handle")
+		return info, nil
+	}
+
+	var count uint32
+	if ret, _, callErr := procGetProcessHandleCount.Call(uintptr(process), uintptr(unsafe.Pointer(&count))); ret == 0 {
+		logger.SysInfo.Warn().Err(callErr).Msg("GetProcessHandleCount is unsupported")
+		return info, nil
+	}
+
+	info.Supported = true
+	info.OpenFDs = int(count)
+
+	logger.SysInfo.Debug().
+		Int("handle_count", info.OpenFDs).
+		Msg("Got process handle count")
+
+	return info, nil
+}
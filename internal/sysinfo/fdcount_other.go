@@ -0,0 +1,14 @@
+//go:build !windows
+
+package sysinfo
+
+import (
+	"context"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// processFDCount возвращает число открытых дескрипторов процесса (на Linux по /proc/<pid>/fd)
+func processFDCount(ctx context.Context, p *process.Process) (int32, error) {
+	return p.NumFDsWithContext(ctx)
+}
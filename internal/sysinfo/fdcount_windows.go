@@ -0,0 +1,30 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"context"
+	"syscall"
+	"unsafe"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// processQueryLimitedInformation право доступа, достаточное для GetProcessHandleCount
+const processQueryLimitedInformation = 0x1000
+
+// processFDCount возвращает число handle процесса: gopsutil не реализует NumFDs в Windows
+func processFDCount(_ context.Context, p *process.Process) (int32, error) {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(p.Pid))
+	if err != nil {
+		return 0, err
+	}
+	defer syscall.CloseHandle(handle)
+
+	var count uint32
+	if ret, _, callErr := procGetProcessHandleCount.Call(uintptr(handle), uintptr(unsafe.Pointer(&count))); ret == 0 {
+		return 0, callErr
+	}
+
+	return int32(count), nil
+}
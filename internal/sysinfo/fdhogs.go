@@ -0,0 +1,63 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// GetFDHogs возвращает limit процессов с наибольшим числом открытых дескрипторов
+// (handle в Windows). Процессы, счетчик которых не читается (права, завершились), пропускаются
+func GetFDHogs(ctx context.Context, limit int) (*FDHogsInfo, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to list processes")
+		return nil, fmt.Errorf("failed to list processes: %v", err)
+	}
+
+	info := &FDHogsInfo{Platform: runtime.GOOS}
+	for _, p := range procs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		count, err := processFDCount(ctx, p)
+		if IsPermissionError(err) {
+			info.Denied++
+			continue
+		}
+		if err != nil {
+			info.Skipped++
+			continue
+		}
+
+		name, _ := p.NameWithContext(ctx)
+		info.Processes = append(info.Processes, FDHog{PID: p.Pid, Name: name, OpenFDs: int(count)})
+	}
+
+	sort.Slice(info.Processes, func(i, j int) bool {
+		if info.Processes[i].OpenFDs != info.Processes[j].OpenFDs {
+			return info.Processes[i].OpenFDs > info.Processes[j].OpenFDs
+		}
+		return info.Processes[i].PID < info.Processes[j].PID
+	})
+	info.Scanned = len(info.Processes)
+	if len(info.Processes) > limit {
+		info.Processes = info.Processes[:limit]
+	}
+
+	logger.SysInfo.Debug().
+		Int("scanned", info.Scanned).
+		Int("permission_denied", info.Denied).
+		Int("skipped", info.Skipped).
+		Msg("Got processes by open file descriptors")
+
+	return info, nil
+}
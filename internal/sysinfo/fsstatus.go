@@ -0,0 +1,110 @@
+package sysinfo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// readOnlyFstypes файловые системы, которые всегда монтируются только для чтения и не считаются проблемой
+var readOnlyFstypes = []string{"squashfs", "iso9660", "udf", "erofs", "cramfs"}
+
+// mountinfoUnescaper восстанавливает пробелы и спецсимволы путей, экранированные в /proc/*/mountinfo
+var mountinfoUnescaper = strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+// GetFSStatus возвращает только проблемные физические файловые системы: смонтированные только для чтения
+// (кроме всегда read-only типов вроде squashfs) и с признаками ошибок
+func GetFSStatus(ctx context.Context) (*FSStatusInfo, error) {
+	partitions, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get disk partitions")
+		return nil, fmt.Errorf("failed to get disk partitions: %v", err)
+	}
+
+	superReadOnly := readSuperblockReadOnly()
+
+	info := &FSStatusInfo{}
+	for _, p := range partitions {
+		if slices.Contains(readOnlyFstypes, p.Fstype) {
+			continue
+		}
+		info.Checked++
+
+		status := mountStatus(p, superReadOnly)
+		if status.ReadOnly || status.Degraded {
+			info.Problems = append(info.Problems, status)
+		}
+	}
+
+	sort.Slice(info.Problems, func(i, j int) bool {
+		return info.Problems[i].Mountpoint < info.Problems[j].Mountpoint
+	})
+
+	logger.SysInfo.Debug().
+		Int("checked", info.Checked).
+		Int("problems", len(info.Problems)).
+		Msg("Got filesystem status")
+
+	return info, nil
+}
+
+// mountStatus определяет состояние точки монтирования по ее опциям и признакам ошибок платформы
+func mountStatus(p disk.PartitionStat, superReadOnly map[string]bool) FSStatus {
+	status := FSStatus{
+		Mountpoint: p.Mountpoint,
+		Device:     p.Device,
+		Fstype:     p.Fstype,
+		ReadOnly:   slices.Contains(p.Opts, "ro") || superReadOnly[p.Mountpoint],
+	}
+	if status.ReadOnly {
+		status.Reasons = append(status.Reasons, "mounted read-only")
+	}
+
+	// Ядро при ошибках (errors=remount-ro) переводит в read-only суперблок, а опции самого монтирования остаются rw
+	if superReadOnly[p.Mountpoint] && !slices.Contains(p.Opts, "ro") {
+		status.Degraded = true
+		status.Reasons = append(status.Reasons, "superblock remounted read-only while mount is rw (likely after I/O errors)")
+	}
+	if reason := fsErrorReason(p); reason != "" {
+		status.Degraded = true
+		status.Reasons = append(status.Reasons, reason)
+	}
+
+	return status
+}
+
+// parseSuperblockReadOnly разбирает mountinfo и возвращает точки монтирования, суперблок которых
+// только для чтения. Строка mountinfo: "36 35 98:0 / /mnt rw,noatime master:1 - ext3 /dev/root ro,errors=remount-ro"
+func parseSuperblockReadOnly(r io.Reader) (map[string]bool, error) {
+	readOnly := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		mountFields, superFields, found := strings.Cut(scanner.Text(), " - ")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(mountFields)
+		super := strings.Fields(superFields)
+		if len(fields) < 5 || len(super) < 3 {
+			continue
+		}
+
+		if slices.Contains(strings.Split(super[2], ","), "ro") {
+			readOnly[mountinfoUnescaper.Replace(fields[4])] = true
+		}
+	}
+
+	return readOnly, scanner.Err()
+}
@@ -0,0 +1,52 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// readSuperblockReadOnly читает состояние суперблоков из /proc/self/mountinfo, при ошибке возвращает nil
+func readSuperblockReadOnly() map[string]bool {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("Failed to open mountinfo")
+		return nil
+	}
+	defer file.Close()
+
+	readOnly, err := parseSuperblockReadOnly(file)
+	if err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("Failed to parse mountinfo")
+	}
+	return readOnly
+}
+
+// fsErrorReason сообщает о зафиксированных ядром ошибках ext4 (/sys/fs/ext4/<dev>/errors_count)
+func fsErrorReason(p disk.PartitionStat) string {
+	if p.Fstype != "ext4" {
+		return ""
+	}
+
+	data, err := os.ReadFile(filepath.Join("/sys/fs/ext4", filepath.Base(p.Device), "errors_count"))
+	if err != nil {
+		return ""
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count) + " ext4 errors recorded in superblock"
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package sysinfo
+
+import "github.com/shirou/gopsutil/v3/disk"
+
+// readSuperblockReadOnly состояние суперблока доступно только через mountinfo Linux
+func readSuperblockReadOnly() map[string]bool {
+	return nil
+}
+
+// fsErrorReason счетчики ошибок файловых систем читаются только из sysfs Linux
+func fsErrorReason(_ disk.PartitionStat) string {
+	return ""
+}
@@ -0,0 +1,58 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+func TestParseSuperblockReadOnly(t *testing.T) {
+	mountinfo := `23 28 0:22 / /proc rw,relatime - proc proc rw
+36 28 98:0 / /data rw,noatime master:1 - xfs /dev/sdb1 ro,attr2
+37 28 98:1 / /mnt/with\040space ro,relatime - ext4 /dev/sdc1 ro,errors=remount-ro
+38 28 98:2 / /srv rw,relatime - ext4 /dev/sdd1 rw,errors=remount-ro
+malformed line without separator
+`
+
+	readOnly, err := parseSuperblockReadOnly(strings.NewReader(mountinfo))
+	if err != nil {
+		t.Fatalf("parseSuperblockReadOnly() error = %v", err)
+	}
+
+	for _, mount := range []string{"/data", "/mnt/with space"} {
+		if !readOnly[mount] {
+			t.Errorf("%s superblock should be read-only", mount)
+		}
+	}
+	for _, mount := range []string{"/proc", "/srv"} {
+		if readOnly[mount] {
+			t.Errorf("%s superblock should be writable", mount)
+		}
+	}
+}
+
+func TestMountStatus(t *testing.T) {
+	superReadOnly := map[string]bool{"/data": true, "/ro": true}
+
+	tests := []struct {
+		name         string
+		partition    disk.PartitionStat
+		wantReadOnly bool
+		wantDegraded bool
+	}{
+		{"writable", disk.PartitionStat{Mountpoint: "/srv", Fstype: "xfs", Opts: []string{"rw"}}, false, false},
+		{"mounted read-only", disk.PartitionStat{Mountpoint: "/ro", Fstype: "xfs", Opts: []string{"ro"}}, true, false},
+		{"remounted after errors", disk.PartitionStat{Mountpoint: "/data", Fstype: "xfs", Opts: []string{"rw"}}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := mountStatus(tt.partition, superReadOnly)
+			if status.ReadOnly != tt.wantReadOnly || status.Degraded != tt.wantDegraded {
+				t.Fatalf("mountStatus() readonly=%t degraded=%t, want readonly=%t degraded=%t (reasons: %v)",
+					status.ReadOnly, status.Degraded, tt.wantReadOnly, tt.wantDegraded, status.Reasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// Компоненты оценки здоровья системы
+const (
+	HealthCPU    = "cpu"
+	HealthMemory = "memory"
+	HealthSwap   = "swap"
+	HealthDisk   = "disk"
+	HealthLoad   = "load"
+)
+
+// HealthComponents все компоненты оценки в порядке вывода
+var HealthComponents = []string{HealthCPU, HealthMemory, HealthSwap, HealthDisk, HealthLoad}
+
+// healthSampleInterval окно замера загрузки CPU и активности swap для оценки
+const healthSampleInterval = 500 * time.Millisecond
+
+// GetHealthScore собирает метрики и сводит их в оценку 0-100. Каждый компонент дает штраф 0-100
+// (0 - в норме, 100 - критично), оценка равна 100 минус взвешенное среднее штрафов.
+// Недоступные на платформе компоненты (swap, load) исключаются из среднего
+func GetHealthScore(ctx context.Context, weights map[string]float64, mounts []string) (*HealthScore, error) {
+	var swap *SwapActivityInfo
+	var swapErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		swap, swapErr = GetSwapActivity(ctx, healthSampleInterval)
+	}()
+
+	info, err := GetWithOptions(Options{CPUSampleInterval: healthSampleInterval})
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	health := &HealthScore{}
+	add := func(name, detail string, value, penalty float64) {
+		health.Components = append(health.Components, HealthComponent{
+			Name:    name,
+			Value:   value,
+			Penalty: penalty,
+			Weight:  weights[name],
+			Detail:  detail,
+		})
+	}
+
+	add(HealthCPU, fmt.Sprintf("CPU usage %.1f%%", info.CPU.UsagePercent),
+		info.CPU.UsagePercent, ramp(info.CPU.UsagePercent, 50, 95))
+	add(HealthMemory, fmt.Sprintf("memory used %.1f%%", info.Memory.UsedPercent),
+		info.Memory.UsedPercent, ramp(info.Memory.UsedPercent, 60, 95))
+
+	if swapErr == nil && swap.Available {
+		rate := swap.SwapInPagesPerSec + swap.SwapOutPagesPerSec
+		add(HealthSwap, fmt.Sprintf("swapping %.1f pages/s", rate), rate, ramp(rate, 0, 1000))
+	} else if swapErr != nil {
+		logger.SysInfo.Warn().
+			Err(swapErr).
+			Msg("Swap activity unavailable for health score")
+	}
+
+	if usage, err := GetMountUsage(ctx, mounts); err == nil && len(usage.Mounts) > 0 {
+		fullest := usage.Mounts[0]
+		for _, mount := range usage.Mounts[1:] {
+			if mount.UsedPercent > fullest.UsedPercent {
+				fullest = mount
+			}
+		}
+		add(HealthDisk, fmt.Sprintf("%s is %.1f%% full", fullest.Mountpoint, fullest.UsedPercent),
+			fullest.UsedPercent, ramp(fullest.UsedPercent, 70, 95))
+	} else if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Disk usage unavailable for health score")
+	}
+
+	if info.Load.Available && info.CPU.Count > 0 {
+		perCore := info.Load.Load1 / float64(info.CPU.Count)
+		add(HealthLoad, fmt.Sprintf("load average %.2f per core", perCore), perCore, ramp(perCore, 0.7, 2))
+	}
+
+	var weighted, totalWeight, worst float64
+	for _, c := range health.Components {
+		weighted += c.Weight * c.Penalty
+		totalWeight += c.Weight
+		if contribution := c.Weight * c.Penalty; contribution > worst {
+			worst = contribution
+			health.BiggestFactor = c.Name
+			health.Explanation = fmt.Sprintf("%s is the biggest factor: %s", c.Name, c.Detail)
+		}
+	}
+
+	health.Score = 100
+	if totalWeight > 0 {
+		health.Score = 100 - weighted/totalWeight
+	}
+	if health.BiggestFactor == "" {
+		health.Explanation = "All components are within healthy ranges"
+	}
+
+	logger.SysInfo.Debug().
+		Float64("score", health.Score).
+		Str("biggest_factor", health.BiggestFactor).
+		Msg("Computed health score")
+
+	return health, nil
+}
+
+// ramp линейно переводит значение в штраф: 0 до порога good, 100 от порога bad
+func ramp(value, good, bad float64) float64 {
+	switch {
+	case value <= good:
+		return 0
+	case value >= bad:
+		return 100
+	default:
+		return (value - good) / (bad - good) * 100
+	}
+}
@@ -0,0 +1,310 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// ErrHistoryDisabled фоновая история метрик не запущена (METRIC_HISTORY_INTERVAL=0)
+var ErrHistoryDisabled = errors.New("metric history sampler is not running (METRIC_HISTORY_INTERVAL=0)")
+
+// MetricSample значения метрик в момент замера
+type MetricSample struct {
+	At time.Time
+	// Used используемая память в байтах
+	Used        uint64
+	UsedPercent float64
+	// CPUPercent загрузка CPU с предыдущего замера, HasCPU=false у первого замера истории
+	CPUPercent float64
+	HasCPU     bool
+}
+
+// MetricHistory кольцевой буфер замеров CPU и памяти за последнее окно
+type MetricHistory struct {
+	interval time.Duration
+
+	mu      sync.RWMutex
+	samples []MetricSample
+	next    int
+	full    bool
+	started time.Time
+
+	// lastTimes счетчики CPU предыдущего замера: собственная база, а не общая cpu.Percent(0),
+	// которую сбрасывают другие вызовы
+	lastTimes *cpu.TimesStat
+}
+
+// NewMetricHistory создает историю, хранящую замеры с периодом interval не дольше window
+func NewMetricHistory(interval, window time.Duration) *MetricHistory {
+	return &MetricHistory{
+		interval: interval,
+		samples:  make([]MetricSample, int(window/interval)+1),
+	}
+}
+
+// Interval период замеров истории
+func (h *MetricHistory) Interval() time.Duration {
+	return h.interval
+}
+
+// Window максимальный промежуток времени, покрываемый историей
+func (h *MetricHistory) Window() time.Duration {
+	return time.Duration(len(h.samples)-1) * h.interval
+}
+
+// Started время начала сбора истории
+func (h *MetricHistory) Started() time.Time {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.started
+}
+
+// Record сохраняет замер, вытесняя самый старый при заполненном буфере
+func (h *MetricHistory) Record(sample MetricSample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.started.IsZero() {
+		h.started = sample.At
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Since возвращает замеры не старше since в хронологическом порядке
+func (h *MetricHistory) Since(since time.Time) []MetricSample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ordered := h.samples[:h.next]
+	if h.full {
+		ordered = append(append([]MetricSample{}, h.samples[h.next:]...), h.samples[:h.next]...)
+	}
+
+	var result []MetricSample
+	for _, sample := range ordered {
+		if !sample.At.Before(since) {
+			result = append(result, sample)
+		}
+	}
+	return result
+}
+
+// Start запускает фоновые замеры до отмены ctx. Ошибка отдельного замера только логгируется
+func (h *MetricHistory) Start(ctx context.Context) {
+	logger.SysInfo.Info().
+		Dur("interval", h.interval).
+		Dur("window", h.Window()).
+		Msg("Starting metric history sampler")
+
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			h.sample(ctx)
+
+			select {
+			case <-ctx.Done():
+				logger.SysInfo.Debug().Msg("Metric history sampler stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// sample добавляет в историю текущее использование памяти и загрузку CPU с прошлого замера
+func (h *MetricHistory) sample(ctx context.Context) {
+	vm, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to sample memory for metric history")
+		return
+	}
+	sample := MetricSample{At: time.Now(), Used: vm.Used, UsedPercent: vm.UsedPercent}
+
+	times, err := cpu.TimesWithContext(ctx, false)
+	if err != nil || len(times) == 0 {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to sample CPU times for metric history")
+	} else {
+		if h.lastTimes != nil {
+			breakdown := cpuTimesBreakdown(*h.lastTimes, times[0], platformCPUTimesFields())
+			sample.CPUPercent = 100 - breakdown.Idle
+			if breakdown.Iowait != nil {
+				sample.CPUPercent -= *breakdown.Iowait
+			}
+			sample.CPUPercent = math.Max(sample.CPUPercent, 0)
+			sample.HasCPU = true
+		}
+		h.lastTimes = &times[0]
+	}
+
+	h.Record(sample)
+}
+
+// metricHistory история, запущенная StartMetricHistory, nil - сбор отключен
+var metricHistory *MetricHistory
+
+// StartMetricHistory запускает общую фоновую историю метрик, которую читают GetMemoryTrend и GetUsagePercentiles
+func StartMetricHistory(ctx context.Context, interval, window time.Duration) {
+	metricHistory = NewMetricHistory(interval, window)
+	metricHistory.Start(ctx)
+}
+
+// Параметры оценки тренда памяти
+const (
+	// minTrendSamples минимум замеров для регрессии
+	minTrendSamples = 5
+	// minTrendCoverage доля запрошенного окна, которую должны покрывать замеры
+	minTrendCoverage = 0.5
+)
+
+// GetMemoryTrend оценивает тренд используемой памяти за последние window по общей фоновой истории
+func GetMemoryTrend(window time.Duration, stableThresholdMBPerMin float64) (*MemoryTrend, error) {
+	if metricHistory == nil {
+		return nil, ErrHistoryDisabled
+	}
+	return metricHistory.Trend(time.Now(), window, stableThresholdMBPerMin)
+}
+
+// Trend считает наклон линейной регрессии используемой памяти (MB/мин) по замерам за window до now.
+// Ошибка, если замеров меньше minTrendSamples или они покрывают меньше половины окна
+func (h *MetricHistory) Trend(now time.Time, window time.Duration, stableThresholdMBPerMin float64) (*MemoryTrend, error) {
+	if window > h.Window() {
+		return nil, fmt.Errorf("window %v exceeds the metric history window %v (METRIC_HISTORY_WINDOW)", window, h.Window())
+	}
+
+	samples := h.Since(now.Add(-window))
+	var span time.Duration
+	if len(samples) > 0 {
+		span = samples[len(samples)-1].At.Sub(samples[0].At)
+	}
+	if len(samples) < minTrendSamples || span < time.Duration(float64(window)*minTrendCoverage) {
+		return nil, fmt.Errorf("insufficient metric history: %d samples over %v, need at least %d samples over %v; history started %v ago, retry later",
+			len(samples), span.Round(time.Second), minTrendSamples, time.Duration(float64(window)*minTrendCoverage).Round(time.Second), now.Sub(h.Started()).Round(time.Second))
+	}
+
+	// Наименьшие квадраты: x - минуты от первого замера, y - используемая память в MB
+	first := samples[0].At
+	n := float64(len(samples))
+	var sumX, sumY, sumXY, sumXX, sumYY float64
+	for _, sample := range samples {
+		x := sample.At.Sub(first).Minutes()
+		y := float64(sample.Used) / (1024 * 1024)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+		sumYY += y * y
+	}
+	varX := n*sumXX - sumX*sumX
+	varY := n*sumYY - sumY*sumY
+	covXY := n*sumXY - sumX*sumY
+
+	trend := &MemoryTrend{
+		Window:    window,
+		Span:      span,
+		Samples:   len(samples),
+		FirstUsed: samples[0].Used,
+		LastUsed:  samples[len(samples)-1].Used,
+		Threshold: stableThresholdMBPerMin,
+	}
+	if varX > 0 {
+		trend.SlopeMBPerMin = covXY / varX
+	}
+	if varX > 0 && varY > 0 {
+		trend.RSquared = covXY * covXY / (varX * varY)
+	}
+
+	switch {
+	case trend.SlopeMBPerMin > stableThresholdMBPerMin:
+		trend.Classification = TrendRising
+	case trend.SlopeMBPerMin < -stableThresholdMBPerMin:
+		trend.Classification = TrendFalling
+	default:
+		trend.Classification = TrendStable
+	}
+
+	return trend, nil
+}
+
+// Метрики GetUsagePercentiles
+const (
+	PercentileMetricCPU    = "cpu"
+	PercentileMetricMemory = "memory"
+)
+
+// GetUsagePercentiles считает перцентили загрузки CPU или используемой памяти за последние window по общей фоновой истории
+func GetUsagePercentiles(metric string, window time.Duration) (*UsagePercentiles, error) {
+	if metricHistory == nil {
+		return nil, ErrHistoryDisabled
+	}
+	return metricHistory.Percentiles(time.Now(), metric, window)
+}
+
+// Percentiles считает min, max и p50/p90/p99 (nearest-rank) метрики metric по замерам за window до now.
+// Ошибка, если история собирается меньше window: перцентили по части окна выдавали бы себя за все окно
+func (h *MetricHistory) Percentiles(now time.Time, metric string, window time.Duration) (*UsagePercentiles, error) {
+	if window > h.Window() {
+		return nil, fmt.Errorf("window %v exceeds the metric history window %v (METRIC_HISTORY_WINDOW)", window, h.Window())
+	}
+
+	// Допуск в один интервал: первый замер делается не раньше старта сэмплера
+	covered := now.Sub(h.Started())
+	if h.Started().IsZero() || covered < window-h.interval {
+		if h.Started().IsZero() {
+			covered = 0
+		}
+		return nil, fmt.Errorf("insufficient metric history: collected %v of the requested %v window, retry in %v",
+			covered.Round(time.Second), window, (window - h.interval - covered).Round(time.Second))
+	}
+
+	var values []float64
+	for _, sample := range h.Since(now.Add(-window)) {
+		switch metric {
+		case PercentileMetricMemory:
+			values = append(values, sample.UsedPercent)
+		default:
+			if sample.HasCPU {
+				values = append(values, sample.CPUPercent)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no %s samples in the last %v, the window is shorter than METRIC_HISTORY_INTERVAL %v", metric, window, h.interval)
+	}
+	sort.Float64s(values)
+
+	rank := func(p float64) float64 {
+		index := int(math.Ceil(p/100*float64(len(values)))) - 1
+		return values[max(index, 0)]
+	}
+
+	return &UsagePercentiles{
+		Metric:  metric,
+		Window:  window,
+		Samples: len(values),
+		Min:     values[0],
+		Max:     values[len(values)-1],
+		P50:     rank(50),
+		P90:     rank(90),
+		P99:     rank(99),
+	}, nil
+}
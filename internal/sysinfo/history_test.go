@@ -0,0 +1,112 @@
+package sysinfo
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryHistoryRingBuffer(t *testing.T) {
+	history := NewMetricHistory(time.Second, 3*time.Second)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		history.Record(MetricSample{At: start.Add(time.Duration(i) * time.Second), Used: uint64(i)})
+	}
+
+	samples := history.Since(start)
+	if len(samples) != 4 || samples[0].Used != 2 || samples[3].Used != 5 {
+		t.Fatalf("Since = %+v, want the last 4 samples in order", samples)
+	}
+	if samples := history.Since(start.Add(4 * time.Second)); len(samples) != 2 {
+		t.Fatalf("Since(+4s) = %+v, want 2 samples", samples)
+	}
+}
+
+func TestMemoryHistoryTrend(t *testing.T) {
+	const mb = 1024 * 1024
+	start := time.Now()
+
+	tests := []struct {
+		name      string
+		perMinute float64
+		want      string
+	}{
+		{"rising", 5, TrendRising},
+		{"falling", -5, TrendFalling},
+		{"stable", 0.2, TrendStable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := NewMetricHistory(time.Minute, time.Hour)
+			for i := 0; i <= 10; i++ {
+				used := 1000 + tt.perMinute*float64(i)
+				history.Record(MetricSample{At: start.Add(time.Duration(i) * time.Minute), Used: uint64(used * mb)})
+			}
+
+			trend, err := history.Trend(start.Add(10*time.Minute), 10*time.Minute, 1)
+			if err != nil {
+				t.Fatalf("Trend: %v", err)
+			}
+			if math.Abs(trend.SlopeMBPerMin-tt.perMinute) > 0.01 {
+				t.Errorf("SlopeMBPerMin = %.3f, want %.3f", trend.SlopeMBPerMin, tt.perMinute)
+			}
+			if trend.Classification != tt.want {
+				t.Errorf("Classification = %q, want %q", trend.Classification, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryHistoryTrendInsufficientData(t *testing.T) {
+	start := time.Now()
+	history := NewMetricHistory(time.Minute, time.Hour)
+	for i := 0; i < 3; i++ {
+		history.Record(MetricSample{At: start.Add(time.Duration(i) * time.Minute), Used: 1})
+	}
+
+	if _, err := history.Trend(start.Add(2*time.Minute), 15*time.Minute, 1); err == nil || !strings.Contains(err.Error(), "insufficient metric history") {
+		t.Fatalf("Trend error = %v, want insufficient history", err)
+	}
+	if _, err := history.Trend(start, 2*time.Hour, 1); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("Trend error = %v, want window exceeded", err)
+	}
+}
+
+func TestMetricHistoryPercentiles(t *testing.T) {
+	start := time.Now()
+	history := NewMetricHistory(time.Minute, time.Hour)
+	for i := 0; i <= 10; i++ {
+		history.Record(MetricSample{
+			At:          start.Add(time.Duration(i) * time.Minute),
+			UsedPercent: 40,
+			CPUPercent:  float64(i * 10),
+			HasCPU:      i > 0,
+		})
+	}
+	now := start.Add(10 * time.Minute)
+
+	cpu, err := history.Percentiles(now, PercentileMetricCPU, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Percentiles: %v", err)
+	}
+	// Первый замер без CPU пропускается: значения 10..100
+	if cpu.Samples != 10 || cpu.Min != 10 || cpu.Max != 100 || cpu.P50 != 50 || cpu.P90 != 90 || cpu.P99 != 100 {
+		t.Errorf("cpu percentiles = %+v", cpu)
+	}
+
+	memory, err := history.Percentiles(now, PercentileMetricMemory, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Percentiles(memory): %v", err)
+	}
+	if memory.Samples != 6 || memory.P50 != 40 || memory.Max != 40 {
+		t.Errorf("memory percentiles = %+v", memory)
+	}
+
+	if _, err := history.Percentiles(now, PercentileMetricCPU, 30*time.Minute); err == nil || !strings.Contains(err.Error(), "insufficient metric history") {
+		t.Errorf("Percentiles over uncovered window error = %v, want insufficient history", err)
+	}
+	if _, err := NewMetricHistory(time.Minute, time.Hour).Percentiles(now, PercentileMetricCPU, time.Minute); err == nil {
+		t.Error("Percentiles on empty history succeeded, want error")
+	}
+}
@@ -0,0 +1,44 @@
+package sysinfo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sync"
+)
+
+// redactHostname заменять имя хоста хешем в выводе и логах
+var redactHostname bool
+
+// SetRedactHostname включает замену имени хоста стабильным в пределах запуска хешем
+func SetRedactHostname(redact bool) {
+	redactHostname = redact
+}
+
+// hostnameSalt случайная соль запуска: хеш стабилен внутри процесса, но не подбирается
+// по словарю типичных имен хостов и не совпадает между запусками
+var hostnameSalt = sync.OnceValue(func() []byte {
+	salt := make([]byte, 16)
+	_, _ = rand.Read(salt)
+	return salt
+})
+
+// Hostname возвращает имя хоста для вывода и логов, при REDACT_HOSTNAME - его хеш вида host-<hex>.
+// Пустая строка, если имя хоста недоступно
+func Hostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return RedactHostname(hostname)
+}
+
+// RedactHostname заменяет имя хоста хешем, если редактирование включено, иначе возвращает как есть
+func RedactHostname(hostname string) string {
+	if !redactHostname || hostname == "" {
+		return hostname
+	}
+	sum := sha256.Sum256(append(hostnameSalt(), hostname...))
+	return "host-" + hex.EncodeToString(sum[:6])
+}
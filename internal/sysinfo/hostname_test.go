@@ -0,0 +1,30 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactHostname(t *testing.T) {
+	t.Cleanup(func() { SetRedactHostname(false) })
+
+	SetRedactHostname(false)
+	if got := RedactHostname("db-prod-01"); got != "db-prod-01" {
+		t.Errorf("RedactHostname without redaction = %q, want unchanged", got)
+	}
+
+	SetRedactHostname(true)
+	redacted := RedactHostname("db-prod-01")
+	if !strings.HasPrefix(redacted, "host-") || strings.Contains(redacted, "db-prod") {
+		t.Errorf("RedactHostname = %q, want host-<hash>", redacted)
+	}
+	if again := RedactHostname("db-prod-01"); again != redacted {
+		t.Errorf("RedactHostname is not stable within a run: %q != %q", again, redacted)
+	}
+	if other := RedactHostname("db-prod-02"); other == redacted {
+		t.Errorf("RedactHostname gave the same hash %q for different hosts", other)
+	}
+	if got := RedactHostname(""); got != "" {
+		t.Errorf("RedactHostname(\"\") = %q, want empty", got)
+	}
+}
@@ -0,0 +1,41 @@
+package sysinfo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// influxMeasurementEscaper экранирует имя measurement в InfluxDB line protocol
+var influxMeasurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+
+// influxTagEscaper экранирует ключи и значения тегов в InfluxDB line protocol
+var influxTagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+
+// FormatInfluxLine formats system information as one InfluxDB line protocol point
+// with a host tag and a nanosecond timestamp. Integers get the "i" suffix, load is omitted when unavailable
+func (s *SystemInfo) FormatInfluxLine(measurement, host string, at time.Time) string {
+	fields := []string{
+		fmt.Sprintf("cpu_count=%di", s.CPU.Count),
+		fmt.Sprintf("cpu_available_cores=%di", s.CPU.AvailableCores),
+		fmt.Sprintf("cpu_usage_percent=%g", s.CPU.UsagePercent),
+		fmt.Sprintf("cpu_steal_percent=%g", s.CPU.StealPercent),
+		fmt.Sprintf("mem_total_bytes=%di", s.Memory.Total),
+		fmt.Sprintf("mem_available_bytes=%di", s.Memory.Available),
+		fmt.Sprintf("mem_used_bytes=%di", s.Memory.Used),
+		fmt.Sprintf("mem_used_percent=%g", s.Memory.UsedPercent),
+	}
+	if s.Load.Available {
+		fields = append(fields,
+			fmt.Sprintf("load1=%g", s.Load.Load1),
+			fmt.Sprintf("load5=%g", s.Load.Load5),
+			fmt.Sprintf("load15=%g", s.Load.Load15),
+		)
+	}
+
+	line := influxMeasurementEscaper.Replace(measurement)
+	if host != "" {
+		line += ",host=" + influxTagEscaper.Replace(host)
+	}
+	return fmt.Sprintf("%s %s %d\n", line, strings.Join(fields, ","), at.UnixNano())
+}
@@ -0,0 +1,29 @@
+package sysinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatInfluxLine(t *testing.T) {
+	info := &SystemInfo{
+		CPU:    CPUInfo{Count: 8, AvailableCores: 4, UsagePercent: 12.5},
+		Memory: MemoryInfo{Total: 1000, Available: 400, Used: 600, UsedPercent: 60},
+		Load:   LoadInfo{Available: true, Load1: 0.5, Load5: 0.25, Load15: 1},
+	}
+	at := time.Unix(1700000000, 42)
+
+	want := "system,host=web\\ 1\\,eu\\=a cpu_count=8i,cpu_available_cores=4i,cpu_usage_percent=12.5,cpu_steal_percent=0," +
+		"mem_total_bytes=1000i,mem_available_bytes=400i,mem_used_bytes=600i,mem_used_percent=60," +
+		"load1=0.5,load5=0.25,load15=1 1700000000000000042\n"
+	if got := info.FormatInfluxLine("system", "web 1,eu=a", at); got != want {
+		t.Errorf("FormatInfluxLine =\n%q\nwant\n%q", got, want)
+	}
+
+	info.Load.Available = false
+	want = "host\\ metrics cpu_count=8i,cpu_available_cores=4i,cpu_usage_percent=12.5,cpu_steal_percent=0," +
+		"mem_total_bytes=1000i,mem_available_bytes=400i,mem_used_bytes=600i,mem_used_percent=60 1700000000000000042\n"
+	if got := info.FormatInfluxLine("host metrics", "", at); got != want {
+		t.Errorf("FormatInfluxLine without load or host =\n%q\nwant\n%q", got, want)
+	}
+}
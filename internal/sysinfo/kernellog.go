@@ -0,0 +1,135 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KernelLogSeverities уровни syslog в порядке убывания важности, индекс совпадает с числовым уровнем
+var KernelLogSeverities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// KernelLogSources источники журнала ядра: auto пробует /dev/kmsg, затем journalctl
+var KernelLogSources = []string{"auto", "kmsg", "journalctl"}
+
+const (
+	// KernelLogMaxLines предел числа возвращаемых строк журнала ядра
+	KernelLogMaxLines = 200
+	// kernelLogTimeout ограничение времени чтения журнала ядра
+	kernelLogTimeout = 10 * time.Second
+)
+
+// KernelSeverityLevel возвращает числовой уровень syslog по имени
+func KernelSeverityLevel(name string) (int, error) {
+	level := slices.Index(KernelLogSeverities, name)
+	if level < 0 {
+		return 0, fmt.Errorf("invalid severity %q: must be one of %s", name, strings.Join(KernelLogSeverities, ", "))
+	}
+	return level, nil
+}
+
+// kernelLogCollector считает записи не ниже порога важности по уровням
+// и хранит последние limit из них
+type kernelLogCollector struct {
+	maxLevel int
+	limit    int
+	result   *KernelWarnings
+}
+
+// newKernelLogCollector создает сборщик записей с уровнем не больше maxLevel
+func newKernelLogCollector(source string, maxLevel, limit int) *kernelLogCollector {
+	return &kernelLogCollector{
+		maxLevel: maxLevel,
+		limit:    limit,
+		result: &KernelWarnings{
+			Supported: true,
+			Available: true,
+			Source:    source,
+			Severity:  KernelLogSeverities[maxLevel],
+			Counts:    make(map[string]int),
+		},
+	}
+}
+
+// add учитывает запись, если она не ниже порога важности
+func (c *kernelLogCollector) add(entry KernelLogEntry, level int) {
+	if level > c.maxLevel {
+		return
+	}
+
+	c.result.Total++
+	c.result.Counts[entry.Severity]++
+	c.result.Entries = append(c.result.Entries, entry)
+	if len(c.result.Entries) > c.limit {
+		c.result.Entries = c.result.Entries[1:]
+	}
+}
+
+// parseKmsgRecord разбирает запись /dev/kmsg вида "prio,seq,usec,flags;message".
+// Продолжения записи (строки с пробелом в начале, поля словаря) отбрасываются
+func parseKmsgRecord(record string) (KernelLogEntry, int, bool) {
+	header, message, found := strings.Cut(record, ";")
+	if !found {
+		return KernelLogEntry{}, 0, false
+	}
+	message, _, _ = strings.Cut(message, "\n")
+
+	fields := strings.Split(header, ",")
+	if len(fields) < 3 {
+		return KernelLogEntry{}, 0, false
+	}
+	prio, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return KernelLogEntry{}, 0, false
+	}
+	usec, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return KernelLogEntry{}, 0, false
+	}
+
+	// Младшие 3 бита - уровень, остальные - facility
+	level := prio & 7
+	return KernelLogEntry{
+		Timestamp: fmt.Sprintf("%.6f", float64(usec)/1e6),
+		Severity:  KernelLogSeverities[level],
+		Message:   message,
+		sinceBoot: time.Duration(usec) * time.Microsecond,
+	}, level, true
+}
+
+// journalRecord поля записи journalctl -o json, используемые для журнала ядра
+type journalRecord struct {
+	Priority  string          `json:"PRIORITY"`
+	Message   json.RawMessage `json:"MESSAGE"`
+	Timestamp string          `json:"__REALTIME_TIMESTAMP"`
+}
+
+// parseJournalRecord разбирает строку journalctl -o json. Бинарные сообщения (массив байт) пропускаются
+func parseJournalRecord(line []byte) (KernelLogEntry, int, bool) {
+	var record journalRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return KernelLogEntry{}, 0, false
+	}
+
+	var message string
+	if err := json.Unmarshal(record.Message, &message); err != nil {
+		return KernelLogEntry{}, 0, false
+	}
+	level, err := strconv.Atoi(record.Priority)
+	if err != nil || level < 0 || level >= len(KernelLogSeverities) {
+		return KernelLogEntry{}, 0, false
+	}
+
+	entry := KernelLogEntry{
+		Severity: KernelLogSeverities[level],
+		Message:  message,
+	}
+	if usec, err := strconv.ParseInt(record.Timestamp, 10, 64); err == nil {
+		entry.at = time.UnixMicro(usec)
+		entry.Timestamp = entry.at.Format(time.RFC3339)
+	}
+	return entry, level, true
+}
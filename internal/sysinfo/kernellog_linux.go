@@ -0,0 +1,163 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// kmsgPath устройство кольцевого буфера ядра, чтение требует CAP_SYSLOG при kernel.dmesg_restrict=1
+const kmsgPath = "/dev/kmsg"
+
+// systemCommandEnv окружение системных утилит (journalctl, chronyc): окружение сервера (с API ключом) не передается
+var systemCommandEnv = []string{"PATH=/usr/sbin:/usr/bin:/sbin:/bin", "LC_ALL=C"}
+
+// GetKernelWarnings читает записи журнала ядра с уровнем не больше maxLevel и возвращает последние limit.
+// Недоступный источник (нет прав, нет journalctl) не является ошибкой: причина сообщается в Reason
+func GetKernelWarnings(ctx context.Context, source string, maxLevel, limit int) *KernelWarnings {
+	collector := newKernelLogCollector(source, maxLevel, limit)
+	used, err := readKernelLog(ctx, source, maxLevel, time.Time{}, collector.add)
+	if err != nil {
+		return &KernelWarnings{
+			Supported: true,
+			Severity:  KernelLogSeverities[maxLevel],
+			Reason:    kernelLogUnavailableReason(err),
+		}
+	}
+	collector.result.Source = used
+
+	logger.SysInfo.Debug().
+		Str("source", used).
+		Int("total", collector.result.Total).
+		Msg("Kernel warnings collected")
+
+	return collector.result
+}
+
+// readKernelLog передает visit записи журнала ядра из source (auto, kmsg или journalctl) и возвращает
+// фактически прочитанный источник. journalctl отдает только записи с уровнем не больше maxLevel
+// и не старше since (нулевое since - вся текущая загрузка), kmsg всегда отдает весь буфер
+func readKernelLog(ctx context.Context, source string, maxLevel int, since time.Time, visit func(KernelLogEntry, int)) (string, error) {
+	var err error
+	switch source {
+	case "kmsg":
+		err = readKmsg(visit)
+	case "journalctl":
+		err = readJournal(ctx, maxLevel, since, visit)
+	default:
+		source = "kmsg"
+		err = readKmsg(visit)
+		if err != nil {
+			logger.SysInfo.Debug().
+				Err(err).
+				Msg("Kernel ring buffer is not readable, falling back to journalctl")
+			kmsgErr := err
+			source = "journalctl"
+			err = readJournal(ctx, maxLevel, since, visit)
+			if err != nil {
+				err = fmt.Errorf("%s: %v; journalctl: %v", kmsgPath, kmsgErr, err)
+			}
+		}
+	}
+
+	if err != nil {
+		logger.SysInfo.Warn().
+			Err(err).
+			Str("source", source).
+			Msg("Kernel log is not available")
+		return "", err
+	}
+	return source, nil
+}
+
+// kernelLogUnavailableReason причина недоступности журнала ядра с подсказкой о правах
+func kernelLogUnavailableReason(err error) string {
+	reason := err.Error()
+	if IsPermissionError(err) {
+		reason += " (reading the kernel log requires CAP_SYSLOG or membership in the systemd-journal/adm group)"
+	}
+	return reason
+}
+
+// readKmsg читает текущее содержимое кольцевого буфера ядра без ожидания новых записей
+func readKmsg(visit func(KernelLogEntry, int)) error {
+	fd, err := syscall.Open(kmsgPath, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	// Каждый read возвращает ровно одну запись, запись ядра не длиннее 8 КБ
+	buf := make([]byte, 8192)
+	for {
+		n, err := syscall.Read(fd, buf)
+		switch {
+		case errors.Is(err, syscall.EAGAIN):
+			return nil
+		case errors.Is(err, syscall.EPIPE), errors.Is(err, syscall.EINTR):
+			// EPIPE: запись перезаписана в буфере во время чтения, продолжаем со следующей
+			continue
+		case err != nil:
+			return err
+		}
+
+		if entry, level, ok := parseKmsgRecord(string(buf[:n])); ok {
+			visit(entry, level)
+		}
+	}
+}
+
+// readJournal читает сообщения ядра текущей загрузки не старше since через journalctl
+func readJournal(ctx context.Context, maxLevel int, since time.Time, visit func(KernelLogEntry, int)) error {
+	runCtx, cancel := context.WithTimeout(ctx, kernelLogTimeout)
+	defer cancel()
+
+	args := []string{"-k", "-b", "-q", "--no-pager", "-o", "json", "-p", KernelLogSeverities[maxLevel]}
+	if !since.IsZero() {
+		args = append(args, "--since", fmt.Sprintf("@%d", since.Unix()))
+	}
+	cmd := exec.CommandContext(runCtx, "journalctl", args...)
+	cmd.Env = systemCommandEnv
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if entry, level, ok := parseJournalRecord(scanner.Bytes()); ok {
+			visit(entry, level)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		// Недочитанный вывод заблокировал бы journalctl до таймаута
+		cancel()
+		_ = cmd.Wait()
+		return fmt.Errorf("failed to read journalctl output: %v", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%v: %s", err, message)
+		}
+		return err
+	}
+
+	return nil
+}
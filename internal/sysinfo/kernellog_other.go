@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysinfo
+
+import "context"
+
+// GetKernelWarnings журнал ядра читается только в Linux
+func GetKernelWarnings(_ context.Context, _ string, maxLevel, _ int) *KernelWarnings {
+	return &KernelWarnings{Supported: false, Severity: KernelLogSeverities[maxLevel]}
+}
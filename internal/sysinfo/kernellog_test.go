@@ -0,0 +1,60 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseKmsgRecord(t *testing.T) {
+	entry, level, ok := parseKmsgRecord("3,1234,5678901,-;EDAC MC0: 1 CE memory read error\n SUBSYSTEM=edac\n")
+	if !ok {
+		t.Fatal("parseKmsgRecord() rejected a valid record")
+	}
+	if level != 3 || entry.Severity != "err" || entry.Timestamp != "5.678901" || entry.Message != "EDAC MC0: 1 CE memory read error" {
+		t.Fatalf("parseKmsgRecord() = %+v, level %d", entry, level)
+	}
+
+	// facility в старших битах не влияет на уровень
+	if _, level, _ := parseKmsgRecord("12,1,0,-;user message"); level != 4 {
+		t.Fatalf("level = %d, want 4", level)
+	}
+	if _, _, ok := parseKmsgRecord("garbage"); ok {
+		t.Fatal("parseKmsgRecord() accepted a record without header")
+	}
+}
+
+func TestParseJournalRecord(t *testing.T) {
+	entry, level, ok := parseJournalRecord([]byte(`{"PRIORITY":"4","MESSAGE":"blk_update_request: I/O error","__REALTIME_TIMESTAMP":"1700000000000000"}`))
+	if !ok || level != 4 || entry.Severity != "warning" || entry.Message != "blk_update_request: I/O error" || entry.Timestamp == "" {
+		t.Fatalf("parseJournalRecord() = %+v, level %d, ok %v", entry, level, ok)
+	}
+
+	if _, _, ok := parseJournalRecord([]byte(`{"PRIORITY":"4","MESSAGE":[104,105]}`)); ok {
+		t.Fatal("parseJournalRecord() accepted a binary message")
+	}
+}
+
+func TestKernelLogCollectorKeepsMostRecent(t *testing.T) {
+	collector := newKernelLogCollector("kmsg", 4, 2)
+	records := []string{
+		"3,1,1000000,-;first error",
+		"6,2,2000000,-;info is filtered",
+		"4,3,3000000,-;second warning",
+		"2,4,4000000,-;third critical",
+	}
+	for _, record := range records {
+		entry, level, _ := parseKmsgRecord(record)
+		collector.add(entry, level)
+	}
+
+	result := collector.result
+	if result.Total != 3 || result.Counts["err"] != 1 || result.Counts["warning"] != 1 || result.Counts["crit"] != 1 {
+		t.Fatalf("counts = %d %v, want 3 matching", result.Total, result.Counts)
+	}
+	if len(result.Entries) != 2 || result.Entries[0].Message != "second warning" || result.Entries[1].Message != "third critical" {
+		t.Fatalf("entries = %+v, want the last two", result.Entries)
+	}
+	if text := result.FormatText(); !strings.Contains(text, "Most recent 2:") || strings.Contains(text, "info is filtered") {
+		t.Fatalf("unexpected FormatText():\n%s", text)
+	}
+}
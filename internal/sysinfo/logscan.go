@@ -0,0 +1,139 @@
+package sysinfo
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// LogScanOptions ограничения сканирования каталогов логов
+type LogScanOptions struct {
+	// Limit количество крупнейших файлов, каталогов и устаревших файлов в результате
+	Limit int
+	// MaxDepth максимальная глубина рекурсии относительно каждого корня
+	MaxDepth int
+	// StaleAfter файлы без изменений дольше этого срока считаются устаревшими
+	StaleAfter time.Duration
+}
+
+// ScanLogDirs обходит корневые каталоги логов без перехода по символическим ссылкам и возвращает общий размер,
+// крупнейшие файлы и каталоги и отдельно устаревшие файлы. Недоступный корень не прерывает сканирование
+// остальных. При отмене контекста возвращается частичный результат с Truncated=true
+func ScanLogDirs(ctx context.Context, roots []string, opts LogScanOptions) *LogDiskUsage {
+	now := time.Now()
+	usage := &LogDiskUsage{StaleAfter: opts.StaleAfter}
+	isStale := func(file LogFileEntry) bool {
+		return opts.StaleAfter > 0 && now.Sub(file.ModTime) > opts.StaleAfter
+	}
+
+	var files []LogFileEntry
+	dirSizes := make(map[string]int64)
+	for _, root := range roots {
+		rootUsage := LogRootUsage{Path: root}
+		resolved, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			rootUsage.Error = err.Error()
+			usage.Roots = append(usage.Roots, rootUsage)
+			continue
+		}
+
+		rootFiles, skipped, truncated := walkLogRoot(ctx, resolved, opts.MaxDepth, dirSizes)
+		for _, file := range rootFiles {
+			rootUsage.Size += file.Size
+			if isStale(file) {
+				usage.StaleFiles++
+				usage.StaleSize += file.Size
+			}
+		}
+		rootUsage.Files = len(rootFiles)
+		rootUsage.Skipped = skipped
+		usage.Total += rootUsage.Size
+		usage.Skipped += skipped
+		usage.Truncated = usage.Truncated || truncated
+		usage.Roots = append(usage.Roots, rootUsage)
+		files = append(files, rootFiles...)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Size > files[j].Size
+	})
+	for _, file := range files {
+		if isStale(file) && len(usage.Stale) < opts.Limit {
+			usage.Stale = append(usage.Stale, file)
+		}
+	}
+	usage.LargestFiles = files[:min(len(files), opts.Limit)]
+
+	for path, size := range dirSizes {
+		usage.LargestDirs = append(usage.LargestDirs, LogDirEntry{Path: path, Size: size})
+	}
+	sort.Slice(usage.LargestDirs, func(i, j int) bool {
+		if usage.LargestDirs[i].Size != usage.LargestDirs[j].Size {
+			return usage.LargestDirs[i].Size > usage.LargestDirs[j].Size
+		}
+		return usage.LargestDirs[i].Path < usage.LargestDirs[j].Path
+	})
+	usage.LargestDirs = usage.LargestDirs[:min(len(usage.LargestDirs), opts.Limit)]
+
+	logger.SysInfo.Debug().
+		Strs("roots", roots).
+		Int("files", len(files)).
+		Int("skipped", usage.Skipped).
+		Bool("truncated", usage.Truncated).
+		Msg("Log directory scan completed")
+
+	return usage
+}
+
+// walkLogRoot собирает обычные файлы под root и добавляет их размер в dirSizes всех вложенных каталогов
+// (сам root не учитывается: его размер есть в LogRootUsage)
+func walkLogRoot(ctx context.Context, root string, maxDepth int, dirSizes map[string]int64) (files []LogFileEntry, skipped int, truncated bool) {
+	baseDepth := strings.Count(root, string(filepath.Separator))
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// Нет прав на чтение каталога или файл исчез во время обхода
+			skipped++
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if maxDepth > 0 && strings.Count(path, string(filepath.Separator))-baseDepth >= maxDepth {
+				truncated = true
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		fileInfo, err := d.Info()
+		if err != nil {
+			skipped++
+			return nil
+		}
+
+		files = append(files, LogFileEntry{Path: path, Size: fileInfo.Size(), ModTime: fileInfo.ModTime()})
+		for dir := filepath.Dir(path); len(dir) > len(root); dir = filepath.Dir(dir) {
+			dirSizes[dir] += fileInfo.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		truncated = true
+	}
+
+	return files, skipped, truncated
+}
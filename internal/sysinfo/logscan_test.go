@@ -0,0 +1,73 @@
+package sysinfo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLogFile(t *testing.T, path string, size int, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanLogDirs(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+
+	writeLogFile(t, filepath.Join(root, "syslog"), 300, now)
+	writeLogFile(t, filepath.Join(root, "syslog.1"), 200, old)
+	writeLogFile(t, filepath.Join(root, "nginx", "access.log"), 500, now)
+	writeLogFile(t, filepath.Join(root, "nginx", "access.log.2.gz"), 100, old)
+	writeLogFile(t, filepath.Join(root, "app", "deep", "trace.log"), 50, now)
+	if err := os.Symlink("/etc", filepath.Join(root, "etc-link")); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(root, "missing")
+	usage := ScanLogDirs(context.Background(), []string{root, missing}, LogScanOptions{Limit: 2, StaleAfter: 7 * 24 * time.Hour})
+
+	if usage.Total != 1150 {
+		t.Errorf("Total = %d, want 1150", usage.Total)
+	}
+	if len(usage.Roots) != 2 || usage.Roots[0].Files != 5 || usage.Roots[1].Error == "" {
+		t.Fatalf("Roots = %+v", usage.Roots)
+	}
+
+	if len(usage.LargestFiles) != 2 || usage.LargestFiles[0].Size != 500 || usage.LargestFiles[1].Size != 300 {
+		t.Errorf("LargestFiles = %+v", usage.LargestFiles)
+	}
+	if len(usage.LargestDirs) != 2 || !strings.HasSuffix(usage.LargestDirs[0].Path, "nginx") || usage.LargestDirs[0].Size != 600 {
+		t.Errorf("LargestDirs = %+v", usage.LargestDirs)
+	}
+
+	if usage.StaleFiles != 2 || usage.StaleSize != 300 {
+		t.Errorf("stale = %d files, %d bytes, want 2 files, 300 bytes", usage.StaleFiles, usage.StaleSize)
+	}
+	if len(usage.Stale) != 2 || !strings.HasSuffix(usage.Stale[0].Path, "syslog.1") {
+		t.Errorf("Stale = %+v", usage.Stale)
+	}
+}
+
+func TestScanLogDirsMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeLogFile(t, filepath.Join(root, "top.log"), 10, time.Now())
+	writeLogFile(t, filepath.Join(root, "a", "b", "deep.log"), 10, time.Now())
+
+	usage := ScanLogDirs(context.Background(), []string{root}, LogScanOptions{Limit: 10, MaxDepth: 1})
+	if usage.Total != 10 || !usage.Truncated {
+		t.Errorf("Total = %d, Truncated = %v, want 10 and true", usage.Total, usage.Truncated)
+	}
+}
@@ -0,0 +1,76 @@
+package sysinfo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// mountUsageCacheTTL время жизни закешированной заполненности файловых систем, 0 - без кеша
+var mountUsageCacheTTL = 5 * time.Second
+
+// SetMountUsageCacheTTL задает время жизни кеша GetCachedMountUsage
+func SetMountUsageCacheTTL(ttl time.Duration) {
+	mountUsageCacheTTL = ttl
+}
+
+// mountUsageEntry закешированный отчет и время его сбора
+type mountUsageEntry struct {
+	info *MountUsageInfo
+	at   time.Time
+}
+
+// mountUsageCache отчеты GetMountUsage по набору точек монтирования
+var mountUsageCache struct {
+	mu      sync.Mutex
+	entries map[string]mountUsageEntry
+}
+
+// GetCachedMountUsage возвращает заполненность файловых систем из кеша, если отчет по тем же mounts
+// собран не раньше mountUsageCacheTTL назад, иначе собирает заново. fresh обходит кеш, но обновляет его.
+// Ошибки не кешируются. У отчета из кеша заполнен Age
+func GetCachedMountUsage(ctx context.Context, mounts []string, fresh bool) (*MountUsageInfo, error) {
+	ttl := mountUsageCacheTTL
+	if ttl <= 0 {
+		return GetMountUsage(ctx, mounts)
+	}
+
+	key := strings.Join(mounts, ",")
+	now := time.Now()
+
+	mountUsageCache.mu.Lock()
+	entry, ok := mountUsageCache.entries[key]
+	mountUsageCache.mu.Unlock()
+	if ok && !fresh && now.Sub(entry.at) < ttl {
+		cached := *entry.info
+		cached.Age = now.Sub(entry.at)
+		logger.SysInfo.Debug().
+			Str("mounts", key).
+			Dur("age", cached.Age).
+			Msg("Serving filesystem usage from cache")
+		return &cached, nil
+	}
+
+	info, err := GetMountUsage(ctx, mounts)
+	if err != nil {
+		return nil, err
+	}
+
+	mountUsageCache.mu.Lock()
+	defer mountUsageCache.mu.Unlock()
+	if mountUsageCache.entries == nil {
+		mountUsageCache.entries = make(map[string]mountUsageEntry)
+	}
+	// Устаревшие отчеты по другим наборам mounts удаляются, чтобы кеш не рос от разных аргументов
+	for cachedKey, cachedEntry := range mountUsageCache.entries {
+		if now.Sub(cachedEntry.at) >= ttl {
+			delete(mountUsageCache.entries, cachedKey)
+		}
+	}
+	mountUsageCache.entries[key] = mountUsageEntry{info: info, at: now}
+
+	return info, nil
+}
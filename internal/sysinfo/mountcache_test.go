@@ -0,0 +1,41 @@
+package sysinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetCachedMountUsage(t *testing.T) {
+	t.Cleanup(func() { SetMountUsageCacheTTL(5 * time.Second) })
+	ctx := context.Background()
+
+	SetMountUsageCacheTTL(time.Minute)
+	first, err := GetCachedMountUsage(ctx, nil, true)
+	if err != nil {
+		t.Fatalf("GetCachedMountUsage: %v", err)
+	}
+	if first.Age != 0 {
+		t.Errorf("fresh result Age = %v, want 0", first.Age)
+	}
+
+	cached, err := GetCachedMountUsage(ctx, nil, false)
+	if err != nil {
+		t.Fatalf("GetCachedMountUsage: %v", err)
+	}
+	if cached.Age <= 0 || len(cached.Mounts) != len(first.Mounts) {
+		t.Errorf("second result Age = %v with %d mounts, want a cached copy of %d mounts", cached.Age, len(cached.Mounts), len(first.Mounts))
+	}
+	if first.Age != 0 {
+		t.Errorf("serving from cache modified the stored result: Age = %v", first.Age)
+	}
+
+	if refreshed, _ := GetCachedMountUsage(ctx, nil, true); refreshed.Age != 0 {
+		t.Errorf("fresh=true result Age = %v, want 0", refreshed.Age)
+	}
+
+	SetMountUsageCacheTTL(0)
+	if uncached, _ := GetCachedMountUsage(ctx, nil, false); uncached.Age != 0 {
+		t.Errorf("result with caching disabled Age = %v, want 0", uncached.Age)
+	}
+}
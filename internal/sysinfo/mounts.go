@@ -0,0 +1,111 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// ErrUnknownMount возвращается, если запрошенный путь не является точкой монтирования
+var ErrUnknownMount = errors.New("not a mount point")
+
+// inodeHighPercent доля занятых inode, начиная с которой файловая система помечается как почти исчерпанная
+const inodeHighPercent = 90.0
+
+// GetMountUsage возвращает заполненность файловых систем. Без mounts отчет строится по всем
+// физическим разделам, иначе только по указанным точкам монтирования (включая виртуальные)
+func GetMountUsage(ctx context.Context, mounts []string) (*MountUsageInfo, error) {
+	partitions, err := disk.PartitionsWithContext(ctx, len(mounts) > 0)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get disk partitions")
+		return nil, fmt.Errorf("failed to get disk partitions: %v", err)
+	}
+
+	if len(mounts) > 0 {
+		byMountpoint := make(map[string]disk.PartitionStat, len(partitions))
+		for _, p := range partitions {
+			byMountpoint[p.Mountpoint] = p
+		}
+
+		var selected []disk.PartitionStat
+		var unknown []string
+		for _, mount := range mounts {
+			p, ok := byMountpoint[filepath.Clean(mount)]
+			if !ok {
+				unknown = append(unknown, mount)
+				continue
+			}
+			selected = append(selected, p)
+		}
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownMount, strings.Join(unknown, ", "))
+		}
+		partitions = selected
+	}
+
+	superReadOnly := readSuperblockReadOnly()
+
+	info := &MountUsageInfo{}
+	for _, p := range partitions {
+		usage, err := disk.UsageWithContext(ctx, p.Mountpoint)
+		if err != nil {
+			logger.SysInfo.Warn().
+				Err(err).
+				Str("mountpoint", p.Mountpoint).
+				Msg("Failed to get filesystem usage")
+			info.Unreadable = append(info.Unreadable, p.Mountpoint)
+			continue
+		}
+
+		status := mountStatus(p, superReadOnly)
+		info.Mounts = append(info.Mounts, MountUsage{
+			Mountpoint:  p.Mountpoint,
+			Device:      p.Device,
+			Fstype:      p.Fstype,
+			Total:       usage.Total,
+			Used:        usage.Used,
+			Free:        usage.Free,
+			UsedPercent: usage.UsedPercent,
+			Inodes:      inodeUsage(usage),
+			ReadOnly:    status.ReadOnly,
+			Degraded:    status.Degraded,
+		})
+	}
+
+	sort.Slice(info.Mounts, func(i, j int) bool {
+		return info.Mounts[i].Mountpoint < info.Mounts[j].Mountpoint
+	})
+
+	logger.SysInfo.Debug().
+		Int("mounts", len(info.Mounts)).
+		Int("unreadable", len(info.Unreadable)).
+		Msg("Got filesystem usage")
+
+	return info, nil
+}
+
+// inodeUsage возвращает использование inode. В Windows inode нет, а файловые системы с динамическими
+// inode (btrfs, vfat) сообщают нулевой объем, в обоих случаях возвращается nil
+func inodeUsage(usage *disk.UsageStat) *InodeUsage {
+	if runtime.GOOS == "windows" || usage.InodesTotal == 0 {
+		return nil
+	}
+
+	return &InodeUsage{
+		Total:       usage.InodesTotal,
+		Used:        usage.InodesUsed,
+		Free:        usage.InodesFree,
+		UsedPercent: usage.InodesUsedPercent,
+		High:        usage.InodesUsedPercent >= inodeHighPercent,
+	}
+}
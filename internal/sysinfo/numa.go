@@ -0,0 +1,69 @@
+package sysinfo
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseNodeMeminfo разбирает /sys/devices/system/node/nodeN/meminfo.
+// Строки имеют вид "Node 0 MemTotal:  5209848 kB", значения переводятся в байты
+func parseNodeMeminfo(data string) (total, free uint64, err error) {
+	var haveTotal, haveFree bool
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		var target *uint64
+		switch fields[2] {
+		case "MemTotal:":
+			target, haveTotal = &total, true
+		case "MemFree:":
+			target, haveFree = &free, true
+		default:
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s value %q: %v", strings.TrimSuffix(fields[2], ":"), fields[3], err)
+		}
+		*target = value * 1024
+	}
+
+	if !haveTotal || !haveFree {
+		return 0, 0, fmt.Errorf("MemTotal or MemFree not found")
+	}
+	return total, free, nil
+}
+
+// countCPUList считает процессоры в списке формата cpulist ("0-3,8,10-11")
+func countCPUList(list string) (int, error) {
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return 0, nil
+	}
+
+	count := 0
+	for _, part := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cpulist %q: %v", list, err)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil || end < start {
+				return 0, fmt.Errorf("invalid cpulist range %q", part)
+			}
+		}
+		count += end - start + 1
+	}
+
+	return count, nil
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+)
+
+// numaNodeRoot каталог узлов NUMA в sysfs
+const numaNodeRoot = "/sys/devices/system/node"
+
+// GetNUMAInfo читает память и список процессоров каждого узла NUMA из sysfs.
+// Ядро без поддержки NUMA не создает каталог узлов, такой хост возвращается без узлов
+func GetNUMAInfo(_ context.Context) (*NUMAInfo, error) {
+	info := &NUMAInfo{Supported: true}
+
+	dirs, err := filepath.Glob(filepath.Join(numaNodeRoot, "node[0-9]*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NUMA nodes: %v", err)
+	}
+
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "meminfo"))
+		if err != nil {
+			logger.SysInfo.Error().
+				Err(err).
+				Int("node", id).
+				Msg("Failed to read NUMA node meminfo")
+			return nil, fmt.Errorf("failed to read meminfo of NUMA node %d: %v", id, err)
+		}
+		total, free, err := parseNodeMeminfo(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse meminfo of NUMA node %d: %v", id, err)
+		}
+
+		node := NUMANode{ID: id, Total: total, Free: free, Used: total - free}
+		if cpus, err := os.ReadFile(filepath.Join(dir, "cpulist")); err == nil {
+			node.CPUs = strings.TrimSpace(string(cpus))
+			if node.CPUCount, err = countCPUList(node.CPUs); err != nil {
+				logger.SysInfo.Warn().
+					Err(err).
+					Int("node", id).
+					Msg("Failed to parse NUMA node cpulist")
+			}
+		}
+
+		info.Nodes = append(info.Nodes, node)
+	}
+
+	sort.Slice(info.Nodes, func(i, j int) bool {
+		return info.Nodes[i].ID < info.Nodes[j].ID
+	})
+
+	logger.SysInfo.Debug().
+		Int("nodes", len(info.Nodes)).
+		Msg("Got NUMA information")
+
+	return info, nil
+}
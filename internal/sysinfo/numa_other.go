@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysinfo
+
+import "context"
+
+// GetNUMAInfo топология NUMA читается только из sysfs Linux
+func GetNUMAInfo(_ context.Context) (*NUMAInfo, error) {
+	return &NUMAInfo{Supported: false}, nil
+}
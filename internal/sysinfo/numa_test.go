@@ -0,0 +1,61 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNodeMeminfo(t *testing.T) {
+	data := `Node 0 MemTotal:        5209848 kB
+Node 0 MemFree:         3372476 kB
+Node 0 MemUsed:         1837372 kB
+Node 0 SwapCached:            0 kB
+`
+	total, free, err := parseNodeMeminfo(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 5209848*1024 || free != 3372476*1024 {
+		t.Fatalf("total, free = %d, %d", total, free)
+	}
+
+	if _, _, err := parseNodeMeminfo("Node 0 MemUsed: 1 kB\n"); err == nil {
+		t.Fatal("expected error for meminfo without MemTotal/MemFree")
+	}
+}
+
+func TestCountCPUList(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    int
+		wantErr bool
+	}{
+		{list: "", want: 0},
+		{list: "0", want: 1},
+		{list: "0-3", want: 4},
+		{list: "0-3,8,10-11\n", want: 7},
+		{list: "3-1", wantErr: true},
+		{list: "a-b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := countCPUList(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("countCPUList(%q) error = %v, wantErr %v", tt.list, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("countCPUList(%q) = %d, want %d", tt.list, got, tt.want)
+		}
+	}
+}
+
+func TestParseCpusAllowedList(t *testing.T) {
+	status := "Name:\tmcp-system-info\nCpus_allowed:\t0f\nCpus_allowed_list:\t0-1,3\nMems_allowed_list:\t0\n"
+	if count, ok := parseCpusAllowedList(strings.NewReader(status)); !ok || count != 3 {
+		t.Fatalf("parseCpusAllowedList() = %d, %t, want 3, true", count, ok)
+	}
+
+	if _, ok := parseCpusAllowedList(strings.NewReader("Name:\tinit\n")); ok {
+		t.Fatal("status without Cpus_allowed_list must not report a count")
+	}
+}
@@ -0,0 +1,90 @@
+package sysinfo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// OOMMaxLookback предел окна поиска событий OOM killer
+	OOMMaxLookback = 7 * 24 * time.Hour
+	// oomKillMaxLevel уровень syslog сообщений "Killed process" (err)
+	oomKillMaxLevel = 3
+)
+
+// Области OOM убийства
+const (
+	// OOMScopeSystem память закончилась на всем хосте
+	OOMScopeSystem = "system"
+	// OOMScopeCgroup процесс превысил лимит памяти своей cgroup (контейнера)
+	OOMScopeCgroup = "cgroup"
+)
+
+// oomKillPattern сообщение ядра о жертве OOM killer:
+// "Out of memory: Killed process 1234 (java) total-vm:..., anon-rss:...kB, ..." или
+// "Memory cgroup out of memory: Killed process ...". Старые ядра пишут "Killed process" без префикса
+var oomKillPattern = regexp.MustCompile(`Killed process (\d+) \((.*?)\)`)
+
+// oomAnonRSSPattern резидентная анонимная память жертвы в момент убийства
+var oomAnonRSSPattern = regexp.MustCompile(`anon-rss:(\d+)kB`)
+
+// parseOOMKill разбирает сообщение о жертве OOM killer, время события заполняет вызывающий
+func parseOOMKill(message string) (OOMEvent, bool) {
+	match := oomKillPattern.FindStringSubmatch(message)
+	if match == nil {
+		return OOMEvent{}, false
+	}
+	pid, err := strconv.ParseInt(match[1], 10, 32)
+	if err != nil {
+		return OOMEvent{}, false
+	}
+
+	event := OOMEvent{PID: int32(pid), Process: match[2], Scope: OOMScopeSystem}
+	if strings.HasPrefix(strings.ToLower(message), "memory cgroup") {
+		event.Scope = OOMScopeCgroup
+	}
+	if rss := oomAnonRSSPattern.FindStringSubmatch(message); rss != nil {
+		if kb, err := strconv.ParseUint(rss[1], 10, 64); err == nil {
+			event.AnonRSS = kb * 1024
+		}
+	}
+	return event, true
+}
+
+// oomCollector считает убийства OOM killer не старше since и хранит последние limit
+type oomCollector struct {
+	boot   time.Time
+	since  time.Time
+	limit  int
+	result *OOMEvents
+}
+
+// add учитывает запись журнала ядра, если это убийство OOM killer внутри окна
+func (c *oomCollector) add(entry KernelLogEntry, level int) {
+	if level > oomKillMaxLevel {
+		return
+	}
+	event, ok := parseOOMKill(entry.Message)
+	if !ok {
+		return
+	}
+	event.Timestamp = entry.Timestamp
+	if c.boot.IsZero() && entry.at.IsZero() {
+		// Время загрузки неизвестно: метку kmsg нельзя привязать ко времени, окно не применяется
+		c.result.WindowNotApplied = true
+	} else {
+		event.KilledAt = entry.Time(c.boot)
+		if event.KilledAt.Before(c.since) {
+			return
+		}
+	}
+
+	c.result.Total++
+	c.result.Victims[event.Process]++
+	c.result.Events = append(c.result.Events, event)
+	if len(c.result.Events) > c.limit {
+		c.result.Events = c.result.Events[1:]
+	}
+}
@@ -0,0 +1,55 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// GetOOMEvents ищет в журнале ядра убийства OOM killer за последние lookback и возвращает
+// последние limit из них. Недоступный источник не является ошибкой: причина сообщается в Reason
+func GetOOMEvents(ctx context.Context, source string, lookback time.Duration, limit int) *OOMEvents {
+	now := time.Now()
+	collector := &oomCollector{
+		since: now.Add(-lookback),
+		limit: limit,
+		result: &OOMEvents{
+			Supported: true,
+			Available: true,
+			Lookback:  lookback,
+			Victims:   make(map[string]int),
+		},
+	}
+
+	// Метки kmsg отсчитываются от загрузки, для сравнения с окном нужно время загрузки
+	if bootTime, err := host.BootTimeWithContext(ctx); err == nil {
+		collector.boot = time.Unix(int64(bootTime), 0)
+	} else {
+		logger.SysInfo.Warn().
+			Err(err).
+			Msg("Failed to get boot time, OOM events from kmsg are listed without the lookback window")
+	}
+
+	used, err := readKernelLog(ctx, source, oomKillMaxLevel, collector.since, collector.add)
+	if err != nil {
+		return &OOMEvents{
+			Supported: true,
+			Lookback:  lookback,
+			Reason:    kernelLogUnavailableReason(err),
+		}
+	}
+	collector.result.Source = used
+
+	logger.SysInfo.Debug().
+		Str("source", used).
+		Dur("lookback", lookback).
+		Int("total", collector.result.Total).
+		Msg("OOM events collected")
+
+	return collector.result
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package sysinfo
+
+import (
+	"context"
+	"time"
+)
+
+// GetOOMEvents журнал ядра и OOM killer есть только в Linux
+func GetOOMEvents(_ context.Context, _ string, lookback time.Duration, _ int) *OOMEvents {
+	return &OOMEvents{Supported: false, Lookback: lookback}
+}
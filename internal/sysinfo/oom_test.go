@@ -0,0 +1,100 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseOOMKill(t *testing.T) {
+	tests := []struct {
+		message string
+		want    OOMEvent
+		ok      bool
+	}{
+		{
+			message: "Out of memory: Killed process 4242 (java) total-vm:8388608kB, anon-rss:2097152kB, file-rss:0kB, shmem-rss:0kB, UID:1000 pgtables:4200kB oom_score_adj:0",
+			want:    OOMEvent{PID: 4242, Process: "java", Scope: OOMScopeSystem, AnonRSS: 2097152 * 1024},
+			ok:      true,
+		},
+		{
+			message: "Memory cgroup out of memory: Killed process 77 (node worker) total-vm:1024kB, anon-rss:512kB",
+			want:    OOMEvent{PID: 77, Process: "node worker", Scope: OOMScopeCgroup, AnonRSS: 512 * 1024},
+			ok:      true,
+		},
+		// Старые ядра пишут жертву отдельной строкой без префикса
+		{
+			message: "Killed process 900 (postgres) total-vm:100kB",
+			want:    OOMEvent{PID: 900, Process: "postgres", Scope: OOMScopeSystem},
+			ok:      true,
+		},
+		{message: "Out of memory: Kill process 900 (postgres) score 900 or sacrifice child"},
+		{message: "oom-kill:constraint=CONSTRAINT_NONE,task=java,pid=4242,uid=1000"},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseOOMKill(tt.message)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseOOMKill(%q) = %+v, %v; want %+v, %v", tt.message, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOOMCollectorAppliesLookbackAndLimit(t *testing.T) {
+	boot := time.Now().Add(-48 * time.Hour)
+	collector := &oomCollector{
+		boot:   boot,
+		since:  boot.Add(24 * time.Hour),
+		limit:  2,
+		result: &OOMEvents{Supported: true, Available: true, Source: "kmsg", Lookback: 24 * time.Hour, Victims: make(map[string]int)},
+	}
+
+	records := []string{
+		// Старше окна: через час после загрузки
+		"3,1,3600000000,-;Out of memory: Killed process 10 (old) total-vm:1kB",
+		"3,2,90000000000,-;Out of memory: Killed process 11 (java) total-vm:1kB",
+		"6,3,91000000000,-;oom-kill:constraint=CONSTRAINT_NONE,task=java,pid=11",
+		"3,4,92000000000,-;Memory cgroup out of memory: Killed process 12 (java) total-vm:1kB",
+		"3,5,93000000000,-;Out of memory: Killed process 13 (redis) total-vm:1kB",
+	}
+	for _, record := range records {
+		entry, level, _ := parseKmsgRecord(record)
+		collector.add(entry, level)
+	}
+
+	result := collector.result
+	if result.Total != 3 || result.Victims["java"] != 2 || result.Victims["redis"] != 1 || result.Victims["old"] != 0 {
+		t.Fatalf("total = %d, victims = %v; want 3 kills in the window", result.Total, result.Victims)
+	}
+	if len(result.Events) != 2 || result.Events[0].PID != 12 || result.Events[1].PID != 13 {
+		t.Fatalf("events = %+v, want the last two", result.Events)
+	}
+	if want := boot.Add(93000 * time.Second); !result.Events[1].KilledAt.Equal(want) {
+		t.Errorf("KilledAt = %v, want %v", result.Events[1].KilledAt, want)
+	}
+
+	text := result.FormatText()
+	for _, want := range []string{"OOM Killer Events (last 1d, source: kmsg)", "- java: 2", "redis (pid 13), system", "java (pid 12), cgroup", "ring buffer"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("FormatText() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestOOMCollectorWithoutBootTime(t *testing.T) {
+	collector := &oomCollector{
+		since:  time.Now().Add(-time.Hour),
+		limit:  10,
+		result: &OOMEvents{Supported: true, Available: true, Source: "kmsg", Lookback: time.Hour, Victims: make(map[string]int)},
+	}
+	entry, level, _ := parseKmsgRecord("3,1,5000000,-;Out of memory: Killed process 10 (java) total-vm:1kB")
+	collector.add(entry, level)
+
+	result := collector.result
+	if result.Total != 1 || !result.WindowNotApplied || !result.Events[0].KilledAt.IsZero() {
+		t.Fatalf("result = %+v, want the kill listed without the lookback window", result)
+	}
+	if text := result.FormatText(); !strings.Contains(text, "[5.000000s since boot] java") || !strings.Contains(text, "lookback window was not applied") {
+		t.Errorf("unexpected FormatText():\n%s", text)
+	}
+}
@@ -0,0 +1,131 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// PermissionDenied пометка данных, которые нельзя прочитать без повышенных прав
+const PermissionDenied = "unavailable: permission denied"
+
+const (
+	// PermissionOK проба выполнена полностью
+	PermissionOK = "ok"
+	// PermissionUnavailable проба не удалась по причине, не связанной с правами (не поддерживается платформой)
+	PermissionUnavailable = "unavailable"
+)
+
+// IsPermissionError проверяет, что ошибка вызвана недостатком прав.
+// gopsutil не всегда оборачивает системные ошибки, поэтому дополнительно проверяется текст
+func IsPermissionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, os.ErrPermission) || errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "permission denied") ||
+		strings.Contains(message, "operation not permitted") ||
+		strings.Contains(message, "access is denied")
+}
+
+// probePermissions проверяет источники данных, которые без повышенных прав читаются частично
+func probePermissions(ctx context.Context) []PermissionProbe {
+	processStatus := probeProcessAccess(ctx)
+	return []PermissionProbe{
+		{Name: "process_info", Status: processStatus},
+		{Name: "connection_owners", Status: probeConnectionOwners(ctx, processStatus)},
+		{Name: "sensors", Status: probeSensorAccess(ctx)},
+	}
+}
+
+// probeProcessAccess пытается прочитать число дескрипторов каждого процесса (как list_fd_hogs)
+func probeProcessAccess(ctx context.Context) string {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return permissionStatus(err)
+	}
+
+	for _, p := range procs {
+		if _, err := processFDCount(ctx, p); IsPermissionError(err) {
+			return PermissionDenied
+		}
+	}
+	return PermissionOK
+}
+
+// probeConnectionOwners проверяет, что у всех слушающих сокетов читается процесс-владелец (как get_listening_ports).
+// Владелец определяется по дескрипторам процессов, поэтому сокет без владельца считается следствием прав,
+// только если дескрипторы чужих процессов не читаются: иначе это сокеты ядра или другого namespace
+func probeConnectionOwners(ctx context.Context, processStatus string) string {
+	conns, err := net.ConnectionsWithContext(ctx, "inet")
+	if err != nil {
+		return permissionStatus(err)
+	}
+
+	for _, conn := range conns {
+		if conn.Status == "LISTEN" && conn.Pid == 0 && processStatus == PermissionDenied {
+			return PermissionDenied
+		}
+	}
+	return PermissionOK
+}
+
+// probeSensorAccess проверяет, что датчики температуры читаются без ошибок прав
+func probeSensorAccess(ctx context.Context) string {
+	_, err := host.SensorsTemperaturesWithContext(ctx)
+	if err == nil {
+		return PermissionOK
+	}
+
+	var warnings *host.Warnings
+	if errors.As(err, &warnings) {
+		for _, warning := range warnings.List {
+			if IsPermissionError(warning) {
+				return PermissionDenied
+			}
+		}
+		return PermissionOK
+	}
+	return permissionStatus(err)
+}
+
+// permissionStatus переводит ошибку пробы в статус отчета
+func permissionStatus(err error) string {
+	if IsPermissionError(err) {
+		return PermissionDenied
+	}
+	return PermissionUnavailable
+}
+
+// LogPermissionsReport однократно при старте проверяет возможности хоста (заполняя кеш get_capabilities)
+// и пишет одно сообщение со списком проб, ограниченных правами
+func LogPermissionsReport(ctx context.Context) {
+	var restricted []string
+	for _, probe := range GetCapabilities(ctx).Permissions {
+		if probe.Status == PermissionDenied {
+			restricted = append(restricted, probe.Name)
+		}
+	}
+
+	if len(restricted) == 0 {
+		logger.SysInfo.Info().Msg("All permission-sensitive probes are available")
+		return
+	}
+
+	logger.SysInfo.Warn().
+		Strs("restricted", restricted).
+		Int("uid", os.Getuid()).
+		Msg("Running without elevated permissions: some data will be reported as unavailable")
+}
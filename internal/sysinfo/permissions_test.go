@@ -0,0 +1,33 @@
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestIsPermissionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "os.ErrPermission", err: fmt.Errorf("open: %w", os.ErrPermission), want: true},
+		{name: "EACCES", err: &os.PathError{Op: "open", Path: "/proc/1/fd", Err: syscall.EACCES}, want: true},
+		{name: "EPERM", err: syscall.EPERM, want: true},
+		{name: "unwrapped text", err: errors.New("open /proc/1/fd: permission denied"), want: true},
+		{name: "not exist", err: os.ErrNotExist, want: false},
+		{name: "other", err: errors.New("process does not exist"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermissionError(tt.err); got != tt.want {
+				t.Fatalf("IsPermissionError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
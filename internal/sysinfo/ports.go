@@ -0,0 +1,119 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"syscall"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// PortsOptions фильтры списка слушающих портов
+type PortsOptions struct {
+	IncludeIPv4 bool
+	IncludeIPv6 bool
+}
+
+// GetListeningPorts возвращает TCP порты в состоянии LISTEN и привязанные UDP сокеты с владеющими процессами.
+// Если владельца прочитать нельзя (недостаточно прав), порт возвращается без PID
+func GetListeningPorts(ctx context.Context, opts PortsOptions) (*ListeningPortsInfo, error) {
+	conns, err := net.ConnectionsWithContext(ctx, "inet")
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get network connections")
+		return nil, fmt.Errorf("failed to get network connections: %v", err)
+	}
+
+	info := &ListeningPortsInfo{}
+	names := make(map[int32]string)
+	seen := make(map[ListeningPort]bool)
+
+	for _, conn := range conns {
+		ipVersion := 4
+		if conn.Family == syscall.AF_INET6 {
+			ipVersion = 6
+		}
+		if (ipVersion == 4 && !opts.IncludeIPv4) || (ipVersion == 6 && !opts.IncludeIPv6) {
+			continue
+		}
+
+		var protocol string
+		switch conn.Type {
+		case syscall.SOCK_STREAM:
+			if conn.Status != "LISTEN" {
+				continue
+			}
+			protocol = "tcp"
+		case syscall.SOCK_DGRAM:
+			// UDP не имеет состояния LISTEN: слушающий сокет не связан с удаленным адресом
+			if conn.Raddr.Port != 0 {
+				continue
+			}
+			protocol = "udp"
+		default:
+			continue
+		}
+
+		port := ListeningPort{
+			Protocol:  fmt.Sprintf("%s%d", protocol, ipVersion),
+			Address:   conn.Laddr.IP,
+			Port:      conn.Laddr.Port,
+			PID:       conn.Pid,
+			IPVersion: ipVersion,
+		}
+		if port.PID > 0 {
+			port.ProcessName = processName(ctx, names, port.PID)
+		}
+
+		// Один и тот же сокет может быть виден из нескольких процессов (fork)
+		if seen[port] {
+			continue
+		}
+		seen[port] = true
+		if port.PID <= 0 {
+			info.UnknownOwners++
+		}
+
+		info.Ports = append(info.Ports, port)
+	}
+
+	sort.Slice(info.Ports, func(i, j int) bool {
+		a, b := info.Ports[i], info.Ports[j]
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return a.Address < b.Address
+	})
+
+	info.Total = len(info.Ports)
+
+	logger.SysInfo.Debug().
+		Int("port_count", len(info.Ports)).
+		Int("unknown_owners", info.UnknownOwners).
+		Msg("Got listening ports")
+
+	return info, nil
+}
+
+// processName возвращает имя процесса по PID с кешированием в рамках одного вызова
+func processName(ctx context.Context, cache map[int32]string, pid int32) string {
+	if name, ok := cache[pid]; ok {
+		return name
+	}
+
+	var name string
+	if proc, err := process.NewProcessWithContext(ctx, pid); err == nil {
+		name, _ = proc.NameWithContext(ctx)
+	}
+
+	cache[pid] = name
+	return name
+}
@@ -0,0 +1,161 @@
+package sysinfo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// batteryReading счетчики одной батареи из sysfs. Единицы зависят от драйвера:
+// energy_* в мкВт·ч и power_now в мкВт либо charge_* в мкА·ч и current_now в мкА
+type batteryReading struct {
+	now, full, rate float64
+}
+
+// readPowerSupplies читает источники питания из каталога в формате /sys/class/power_supply
+func readPowerSupplies(root string) (*PowerInfo, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return &PowerInfo{Supported: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list power supplies: %v", err)
+	}
+
+	info := &PowerInfo{Supported: true}
+	var readings []batteryReading
+	for _, entry := range entries {
+		dir := filepath.Join(root, entry.Name())
+
+		switch readSysfsString(dir, "type") {
+		case "Mains", "USB":
+			online := readSysfsString(dir, "online") == "1"
+			if info.ACOnline == nil || online {
+				info.ACOnline = &online
+			}
+
+		case "Battery":
+			// Батареи периферии (мыши, клавиатуры) не питают хост
+			if readSysfsString(dir, "scope") == "Device" {
+				continue
+			}
+			battery, reading := readBattery(dir, entry.Name())
+			info.Batteries = append(info.Batteries, battery)
+			readings = append(readings, reading)
+		}
+	}
+
+	sort.Slice(info.Batteries, func(i, j int) bool {
+		return info.Batteries[i].Name < info.Batteries[j].Name
+	})
+
+	for _, battery := range info.Batteries {
+		if battery.Status == "Discharging" {
+			info.OnBattery = true
+		}
+	}
+	if info.ACOnline != nil && *info.ACOnline {
+		info.OnBattery = false
+	}
+	if len(info.Batteries) > 1 {
+		info.Aggregate = aggregateBatteries(info.Batteries, readings)
+	}
+
+	return info, nil
+}
+
+// readBattery читает заряд, состояние и оценку оставшегося времени одной батареи
+func readBattery(dir, name string) (Battery, batteryReading) {
+	battery := Battery{Name: name, Status: readSysfsString(dir, "status")}
+	if battery.Status == "" {
+		battery.Status = "Unknown"
+	}
+
+	var reading batteryReading
+	if now, ok := readSysfsFloat(dir, "energy_now"); ok {
+		reading.now = now
+		reading.full, _ = readSysfsFloat(dir, "energy_full")
+		reading.rate, _ = readSysfsFloat(dir, "power_now")
+	} else if now, ok := readSysfsFloat(dir, "charge_now"); ok {
+		reading.now = now
+		reading.full, _ = readSysfsFloat(dir, "charge_full")
+		reading.rate, _ = readSysfsFloat(dir, "current_now")
+	}
+
+	if capacity, ok := readSysfsFloat(dir, "capacity"); ok {
+		battery.Percent = capacity
+	} else if reading.full > 0 {
+		battery.Percent = reading.now / reading.full * 100
+	}
+
+	battery.TimeRemainingSeconds, battery.TimeToFullSeconds = batteryTimes(battery.Status, reading)
+	return battery, reading
+}
+
+// batteryTimes оценивает время до разряда при разрядке и до полного заряда при зарядке
+func batteryTimes(status string, reading batteryReading) (*int64, *int64) {
+	if reading.rate <= 0 {
+		return nil, nil
+	}
+
+	switch status {
+	case "Discharging":
+		seconds := int64(reading.now / reading.rate * 3600)
+		return &seconds, nil
+	case "Charging":
+		if reading.full <= reading.now {
+			return nil, nil
+		}
+		seconds := int64((reading.full - reading.now) / reading.rate * 3600)
+		return nil, &seconds
+	}
+	return nil, nil
+}
+
+// aggregateBatteries суммирует заряд нескольких батарей: процент по суммарной емкости,
+// если она известна у всех батарей, иначе среднее значение процентов
+func aggregateBatteries(batteries []Battery, readings []batteryReading) *BatteryAggregate {
+	var now, full, rate, percentSum float64
+	knownCapacity := true
+	status := ""
+	for i, battery := range batteries {
+		now += readings[i].now
+		full += readings[i].full
+		percentSum += battery.Percent
+		if readings[i].full <= 0 {
+			knownCapacity = false
+		}
+		if battery.Status == "Discharging" {
+			rate += readings[i].rate
+			status = "Discharging"
+		}
+	}
+
+	aggregate := &BatteryAggregate{Percent: percentSum / float64(len(batteries))}
+	if knownCapacity && full > 0 {
+		aggregate.Percent = now / full * 100
+		aggregate.TimeRemainingSeconds, _ = batteryTimes(status, batteryReading{now: now, full: full, rate: rate})
+	}
+	return aggregate
+}
+
+// readSysfsString читает значение атрибута sysfs без завершающего перевода строки
+func readSysfsString(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readSysfsFloat читает числовой атрибут sysfs
+func readSysfsFloat(dir, name string) (float64, bool) {
+	value, err := strconv.ParseFloat(readSysfsString(dir, name), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
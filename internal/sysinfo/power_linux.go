@@ -0,0 +1,31 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+)
+
+// powerSupplyRoot каталог источников питания в sysfs
+const powerSupplyRoot = "/sys/class/power_supply"
+
+// GetPowerStatus читает состояние батарей и сетевого питания из sysfs.
+// Хост без батарей (сервер, десктоп) возвращается с пустым списком батарей
+func GetPowerStatus(_ context.Context) (*PowerInfo, error) {
+	info, err := readPowerSupplies(powerSupplyRoot)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to read power supplies")
+		return nil, err
+	}
+
+	logger.SysInfo.Debug().
+		Int("batteries", len(info.Batteries)).
+		Bool("on_battery", info.OnBattery).
+		Msg("Got power status")
+
+	return info, nil
+}
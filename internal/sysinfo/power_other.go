@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sysinfo
+
+import "context"
+
+// GetPowerStatus состояние батарей читается только из sysfs Linux
+func GetPowerStatus(_ context.Context) (*PowerInfo, error) {
+	return &PowerInfo{Supported: false}, nil
+}
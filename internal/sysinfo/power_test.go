@@ -0,0 +1,83 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writePowerSupply создает каталог источника питания с атрибутами sysfs
+func writePowerSupply(t *testing.T, root, name string, attrs map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for attr, value := range attrs {
+		if err := os.WriteFile(filepath.Join(dir, attr), []byte(value+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadPowerSuppliesWithoutBattery(t *testing.T) {
+	root := t.TempDir()
+	writePowerSupply(t, root, "AC", map[string]string{"type": "Mains", "online": "1"})
+
+	info, err := readPowerSupplies(root)
+	if err != nil {
+		t.Fatalf("readPowerSupplies() error = %v", err)
+	}
+	if len(info.Batteries) != 0 || info.OnBattery {
+		t.Fatalf("info = %+v, want AC without batteries", info)
+	}
+	if text := info.FormatText(); !strings.Contains(text, "no battery") {
+		t.Fatalf("FormatText() = %q, want no battery message", text)
+	}
+
+	missing, err := readPowerSupplies(filepath.Join(root, "missing"))
+	if err != nil || len(missing.Batteries) != 0 {
+		t.Fatalf("missing power_supply dir = %+v, %v, want no batteries", missing, err)
+	}
+}
+
+func TestReadPowerSuppliesOnBattery(t *testing.T) {
+	root := t.TempDir()
+	writePowerSupply(t, root, "AC", map[string]string{"type": "Mains", "online": "0"})
+	writePowerSupply(t, root, "BAT0", map[string]string{
+		"type": "Battery", "status": "Discharging", "capacity": "50",
+		"energy_now": "20000000", "energy_full": "40000000", "power_now": "10000000",
+	})
+	writePowerSupply(t, root, "BAT1", map[string]string{
+		"type": "Battery", "status": "Full",
+		"energy_now": "40000000", "energy_full": "40000000", "power_now": "0",
+	})
+	writePowerSupply(t, root, "hidpp_battery_0", map[string]string{"type": "Battery", "scope": "Device", "capacity": "5"})
+
+	info, err := readPowerSupplies(root)
+	if err != nil {
+		t.Fatalf("readPowerSupplies() error = %v", err)
+	}
+	if !info.OnBattery {
+		t.Fatal("host with AC offline and a discharging battery must be on battery")
+	}
+	if len(info.Batteries) != 2 {
+		t.Fatalf("batteries = %+v, want BAT0 and BAT1 without peripheral battery", info.Batteries)
+	}
+
+	bat0 := info.Batteries[0]
+	if bat0.Percent != 50 || bat0.TimeRemainingSeconds == nil || *bat0.TimeRemainingSeconds != 2*3600 {
+		t.Fatalf("BAT0 = %+v, want 50%% and 2h remaining", bat0)
+	}
+	if info.Batteries[1].Percent != 100 {
+		t.Fatalf("BAT1 percent = %v, want 100 from energy counters", info.Batteries[1].Percent)
+	}
+
+	if info.Aggregate == nil || info.Aggregate.Percent != 75 {
+		t.Fatalf("aggregate = %+v, want 75%%", info.Aggregate)
+	}
+	if info.Aggregate.TimeRemainingSeconds == nil || *info.Aggregate.TimeRemainingSeconds != 6*3600 {
+		t.Fatalf("aggregate time remaining = %v, want 6h", info.Aggregate.TimeRemainingSeconds)
+	}
+}
@@ -0,0 +1,93 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// ErrProcessExited процесс завершился (или его PID занят другим процессом)
+var ErrProcessExited = errors.New("process has exited")
+
+// ProcessWatcher снимает показатели одного процесса. Хранит gopsutil процесс между замерами:
+// загрузка CPU считается по разнице времен с предыдущего вызова Sample
+type ProcessWatcher struct {
+	proc *process.Process
+	name string
+}
+
+// NewProcessWatcher начинает наблюдение за процессом pid, ErrProcessExited если его уже нет
+func NewProcessWatcher(ctx context.Context, pid int32) (*ProcessWatcher, error) {
+	proc, err := process.NewProcessWithContext(ctx, pid)
+	if errors.Is(err, process.ErrorProcessNotRunning) {
+		return nil, ErrProcessExited
+	}
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Int32("pid", pid).
+			Msg("Failed to open process")
+		return nil, fmt.Errorf("failed to open process %d: %v", pid, err)
+	}
+
+	name, _ := proc.NameWithContext(ctx)
+	return watchProcess(ctx, proc, name), nil
+}
+
+// watchProcess начинает наблюдение за уже открытым процессом
+func watchProcess(ctx context.Context, proc *process.Process, name string) *ProcessWatcher {
+	// Первый вызов фиксирует начальные времена CPU, следующий Sample вернет загрузку за интервал
+	if _, err := proc.PercentWithContext(ctx, 0); err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Int32("pid", proc.Pid).
+			Msg("Process CPU times are not readable")
+	}
+
+	return &ProcessWatcher{proc: proc, name: name}
+}
+
+// PID наблюдаемого процесса
+func (w *ProcessWatcher) PID() int32 {
+	return w.proc.Pid
+}
+
+// Name имя наблюдаемого процесса на момент начала наблюдения
+func (w *ProcessWatcher) Name() string {
+	return w.name
+}
+
+// Sample возвращает текущие показатели процесса или ErrProcessExited.
+// Zombie процесс считается завершенным: он уже не выполняется, а ждет wait родителя
+func (w *ProcessWatcher) Sample(ctx context.Context) (*ProcessSample, error) {
+	running, err := w.proc.IsRunningWithContext(ctx)
+	if err != nil || !running {
+		return nil, ErrProcessExited
+	}
+	if status, err := w.proc.StatusWithContext(ctx); err == nil && slices.Contains(status, process.Zombie) {
+		return nil, ErrProcessExited
+	}
+
+	cpuPercent, err := w.proc.PercentWithContext(ctx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CPU usage of process %d: %v", w.proc.Pid, err)
+	}
+
+	memInfo, err := w.proc.MemoryInfoWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory of process %d: %v", w.proc.Pid, err)
+	}
+	memPercent, _ := w.proc.MemoryPercentWithContext(ctx)
+
+	return &ProcessSample{
+		PID:           w.proc.Pid,
+		CPUPercent:    cpuPercent,
+		RSS:           memInfo.RSS,
+		MemoryPercent: float64(memPercent),
+	}, nil
+}
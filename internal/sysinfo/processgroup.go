@@ -0,0 +1,99 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// processGroupCPUWindow окно замера загрузки CPU процессов, найденных по имени
+const processGroupCPUWindow = 500 * time.Millisecond
+
+// matchProcessName сравнивает имя процесса с запросом без учета регистра:
+// по подстроке или, при exact, целиком
+func matchProcessName(name, query string, exact bool) bool {
+	if exact {
+		return strings.EqualFold(name, query)
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(query))
+}
+
+// GetProcessesByName находит процессы по имени и возвращает суммарные CPU и память всех совпадений
+// и разбивку по limit самым загруженным. Загрузка CPU замеряется за processGroupCPUWindow
+func GetProcessesByName(ctx context.Context, query string, exact bool, limit int) (*ProcessGroupInfo, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to list processes")
+		return nil, fmt.Errorf("failed to list processes: %v", err)
+	}
+
+	var watchers []*ProcessWatcher
+	for _, p := range procs {
+		name, err := p.NameWithContext(ctx)
+		if err != nil || !matchProcessName(name, query, exact) {
+			continue
+		}
+		watchers = append(watchers, watchProcess(ctx, p, name))
+	}
+
+	info := &ProcessGroupInfo{Query: query, Exact: exact}
+	if len(watchers) == 0 {
+		return info, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(processGroupCPUWindow):
+	}
+
+	for _, w := range watchers {
+		sample, err := w.Sample(ctx)
+		if errors.Is(err, ErrProcessExited) {
+			continue
+		}
+		if err != nil {
+			if IsPermissionError(err) {
+				info.Denied++
+			}
+			logger.SysInfo.Debug().
+				Err(err).
+				Int32("pid", w.PID()).
+				Msg("Skipping unreadable matching process")
+			continue
+		}
+
+		info.CPUPercent += sample.CPUPercent
+		info.RSS += sample.RSS
+		info.MemoryPercent += sample.MemoryPercent
+		info.Processes = append(info.Processes, NamedProcessSample{Name: w.Name(), ProcessSample: *sample})
+	}
+
+	sort.Slice(info.Processes, func(i, j int) bool {
+		if info.Processes[i].CPUPercent != info.Processes[j].CPUPercent {
+			return info.Processes[i].CPUPercent > info.Processes[j].CPUPercent
+		}
+		return info.Processes[i].PID < info.Processes[j].PID
+	})
+	info.Matches = len(info.Processes)
+	if len(info.Processes) > limit {
+		info.Processes = info.Processes[:limit]
+	}
+
+	logger.SysInfo.Debug().
+		Str("query", query).
+		Int("matches", info.Matches).
+		Int("permission_denied", info.Denied).
+		Msg("Got processes by name")
+
+	return info, nil
+}
@@ -0,0 +1,47 @@
+package sysinfo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchProcessName(t *testing.T) {
+	tests := []struct {
+		name, query string
+		exact, want bool
+	}{
+		{"nginx", "NGINX", false, true},
+		{"nginx: worker", "nginx", false, true},
+		{"nginx: worker", "nginx", true, false},
+		{"Nginx", "nginx", true, true},
+		{"sshd", "nginx", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := matchProcessName(tt.name, tt.query, tt.exact); got != tt.want {
+			t.Errorf("matchProcessName(%q, %q, %v) = %v, want %v", tt.name, tt.query, tt.exact, got, tt.want)
+		}
+	}
+}
+
+func TestGetProcessesByNameFindsSelf(t *testing.T) {
+	self := filepath.Base(os.Args[0])
+	info, err := GetProcessesByName(context.Background(), self, true, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Matches == 0 || info.RSS == 0 {
+		t.Fatalf("GetProcessesByName(%q) = %+v, want the test process", self, info)
+	}
+
+	missing, err := GetProcessesByName(context.Background(), "no-such-process-name", false, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := missing.FormatText(); !strings.Contains(text, "No matching process") {
+		t.Fatalf("FormatText() = %q, want no matching process", text)
+	}
+}
@@ -0,0 +1,42 @@
+package sysinfo
+
+import (
+	"runtime"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// GetRuntimeInfo собирает статистику Go runtime процесса сервера (не хоста)
+func GetRuntimeInfo() *RuntimeInfo {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	info := &RuntimeInfo{
+		GoVersion:    runtime.Version(),
+		GOOS:         runtime.GOOS,
+		GOARCH:       runtime.GOARCH,
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		Goroutines:   runtime.NumGoroutine(),
+		HeapAlloc:    memStats.HeapAlloc,
+		HeapSys:      memStats.HeapSys,
+		HeapObjects:  memStats.HeapObjects,
+		Sys:          memStats.Sys,
+		NumGC:        memStats.NumGC,
+		GCPauseTotal: time.Duration(memStats.PauseTotalNs),
+	}
+
+	if memStats.NumGC > 0 {
+		info.LastGCPause = time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256])
+		info.LastGC = time.Unix(0, int64(memStats.LastGC))
+	}
+
+	logger.SysInfo.Debug().
+		Int("goroutines", info.Goroutines).
+		Uint64("heap_alloc", info.HeapAlloc).
+		Uint32("num_gc", info.NumGC).
+		Msg("Got Go runtime information")
+
+	return info
+}
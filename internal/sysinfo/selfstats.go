@@ -0,0 +1,79 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// selfProcess процесс сервера. Хранится между вызовами, чтобы загрузка CPU считалась
+// по разнице времен с предыдущего вызова GetSelfStats
+var selfProcess struct {
+	mu   sync.Mutex
+	proc *process.Process
+}
+
+// GetSelfStats собирает потребление ресурсов процессом самого сервера через gopsutil по его PID
+func GetSelfStats(ctx context.Context) (*SelfStats, error) {
+	selfProcess.mu.Lock()
+	defer selfProcess.mu.Unlock()
+
+	firstCall := selfProcess.proc == nil
+	if firstCall {
+		proc, err := process.NewProcessWithContext(ctx, int32(os.Getpid()))
+		if err != nil {
+			logger.SysInfo.Error().
+				Err(err).
+				Msg("Failed to open server process")
+			return nil, fmt.Errorf("failed to open server process: %v", err)
+		}
+		selfProcess.proc = proc
+	}
+	proc := selfProcess.proc
+
+	memInfo, err := proc.MemoryInfoWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get server process memory")
+		return nil, fmt.Errorf("failed to get server process memory: %v", err)
+	}
+
+	stats := &SelfStats{
+		PID:     proc.Pid,
+		RSS:     memInfo.RSS,
+		VMS:     memInfo.VMS,
+		OpenFDs: -1,
+	}
+
+	// Первый вызов Percent только запоминает времена CPU, значение за интервал появится со следующего вызова
+	if percent, err := proc.PercentWithContext(ctx, 0); err == nil && !firstCall {
+		stats.CPUPercent = &percent
+	}
+	if average, err := proc.CPUPercentWithContext(ctx); err == nil {
+		stats.CPUAveragePercent = average
+	}
+	if threads, err := proc.NumThreadsWithContext(ctx); err == nil {
+		stats.Threads = threads
+	}
+	if fds, err := processFDCount(ctx, proc); err == nil {
+		stats.OpenFDs = int(fds)
+	}
+	if created, err := proc.CreateTimeWithContext(ctx); err == nil {
+		stats.Uptime = time.Since(time.UnixMilli(created)).Truncate(time.Second)
+	}
+
+	logger.SysInfo.Debug().
+		Uint64("rss", stats.RSS).
+		Int32("threads", stats.Threads).
+		Int("open_fds", stats.OpenFDs).
+		Msg("Got server process resource usage")
+
+	return stats, nil
+}
@@ -0,0 +1,36 @@
+package sysinfo
+
+import "sync"
+
+// StealBaseline предыдущий замер времени CPU для расчета steal между вызовами одного потребителя
+// (например, одного стрима мониторинга). Общая база для всех вызовов не используется: параллельные
+// вызовы сбрасывали бы ее друг другу, и каждый steal покрывал бы произвольное окно
+type StealBaseline struct {
+	mu    sync.Mutex
+	steal float64
+	total float64
+	valid bool
+}
+
+// NewStealBaseline создает пустую базу, первый замер с ней возвращает среднее с момента загрузки
+func NewStealBaseline() *StealBaseline {
+	return &StealBaseline{}
+}
+
+// delta возвращает прирост steal и общего времени с предыдущего замера и запоминает текущий
+func (b *StealBaseline) delta(steal, total float64) (float64, float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	deltaSteal, deltaTotal := steal, total
+	if b.valid && total > b.total {
+		deltaSteal = steal - b.steal
+		deltaTotal = total - b.total
+	}
+
+	b.steal = steal
+	b.total = total
+	b.valid = true
+
+	return deltaSteal, deltaTotal
+}
@@ -0,0 +1,63 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// readStealPercent возвращает долю времени CPU, отобранного гипервизором. С interval > 0 steal
+// замеряется за это окно, иначе с предыдущего вызова с той же baseline. Без окна и baseline
+// возвращается среднее с момента загрузки системы
+func readStealPercent(ctx context.Context, baseline *StealBaseline, interval time.Duration) (float64, bool, error) {
+	steal, total, ok, err := readStealTimes(ctx)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+
+	if interval > 0 {
+		// Собственное окно вызова, как у замера загрузки за CPUSampleInterval
+		baseline = NewStealBaseline()
+		baseline.delta(steal, total)
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return 0, false, ctx.Err()
+		}
+
+		if steal, total, ok, err = readStealTimes(ctx); err != nil || !ok {
+			return 0, ok, err
+		}
+	}
+
+	deltaSteal, deltaTotal := steal, total
+	if baseline != nil {
+		deltaSteal, deltaTotal = baseline.delta(steal, total)
+	}
+	if deltaTotal <= 0 {
+		return 0, true, nil
+	}
+
+	return deltaSteal / deltaTotal * 100, true, nil
+}
+
+// readStealTimes читает суммарные steal и общее время CPU из /proc/stat
+func readStealTimes(ctx context.Context) (float64, float64, bool, error) {
+	times, err := cpu.TimesWithContext(ctx, false)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("failed to get CPU times: %v", err)
+	}
+	if len(times) == 0 {
+		return 0, 0, false, nil
+	}
+
+	t := times[0]
+	// Guest время уже учтено в User, поэтому не суммируется повторно
+	total := t.User + t.System + t.Idle + t.Nice + t.Iowait + t.Irq + t.Softirq + t.Steal
+	return t.Steal, total, true, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package sysinfo
+
+import (
+	"context"
+	"time"
+)
+
+// readStealPercent steal time доступно только в /proc/stat Linux
+func readStealPercent(_ context.Context, _ *StealBaseline, _ time.Duration) (float64, bool, error) {
+	return 0, false, nil
+}
@@ -0,0 +1,20 @@
+package sysinfo
+
+import "testing"
+
+func TestStealBaselinesAreIndependent(t *testing.T) {
+	first, second := NewStealBaseline(), NewStealBaseline()
+
+	if steal, total := first.delta(10, 1000); steal != 10 || total != 1000 {
+		t.Fatalf("first sample delta = %v/%v, want totals since boot 10/1000", steal, total)
+	}
+	second.delta(12, 1100)
+
+	// Замер второй базы не сдвигает окно первой
+	if steal, total := first.delta(15, 1200); steal != 5 || total != 200 {
+		t.Errorf("first baseline delta = %v/%v, want 5/200", steal, total)
+	}
+	if steal, total := second.delta(15, 1200); steal != 3 || total != 100 {
+		t.Errorf("second baseline delta = %v/%v, want 3/100", steal, total)
+	}
+}
@@ -0,0 +1,74 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// swapPageSize размер страницы, в которой gopsutil пересчитывает счетчики pswpin/pswpout в байты
+const swapPageSize = 4 * 1024
+
+// GetSwapActivity вычисляет скорость подкачки по двум замерам счетчиков swap in/out с интервалом.
+// Счетчики есть только на Linux (/proc/vmstat), на остальных платформах возвращается только объем swap
+func GetSwapActivity(ctx context.Context, interval time.Duration) (*SwapActivityInfo, error) {
+	first, err := mem.SwapMemoryWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get swap memory")
+		return nil, fmt.Errorf("failed to get swap memory: %v", err)
+	}
+
+	info := &SwapActivityInfo{
+		Total:       first.Total,
+		Used:        first.Used,
+		UsedPercent: first.UsedPercent,
+		Available:   runtime.GOOS == "linux",
+	}
+	if !info.Available {
+		return info, nil
+	}
+
+	select {
+	case <-time.After(interval):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	second, err := mem.SwapMemoryWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to get swap memory")
+		return nil, fmt.Errorf("failed to get swap memory: %v", err)
+	}
+
+	seconds := interval.Seconds()
+	info.Interval = interval
+	info.SwapInBytesPerSec = float64(counterDelta(first.Sin, second.Sin)) / seconds
+	info.SwapOutBytesPerSec = float64(counterDelta(first.Sout, second.Sout)) / seconds
+	info.SwapInPagesPerSec = info.SwapInBytesPerSec / swapPageSize
+	info.SwapOutPagesPerSec = info.SwapOutBytesPerSec / swapPageSize
+
+	logger.SysInfo.Debug().
+		Dur("interval", interval).
+		Float64("swap_in_pages_per_sec", info.SwapInPagesPerSec).
+		Float64("swap_out_pages_per_sec", info.SwapOutPagesPerSec).
+		Msg("Got swap activity")
+
+	return info, nil
+}
+
+// counterDelta разница монотонного счетчика, сброс счетчика дает 0
+func counterDelta(before, after uint64) uint64 {
+	if after < before {
+		return 0
+	}
+	return after - before
+}
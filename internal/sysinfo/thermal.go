@@ -0,0 +1,113 @@
+package sysinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// ThermalRisk уровень риска троттлинга по температуре
+type ThermalRisk string
+
+const (
+	ThermalRiskUnknown  ThermalRisk = "unknown"
+	ThermalRiskOK       ThermalRisk = "ok"
+	ThermalRiskWarning  ThermalRisk = "warning"
+	ThermalRiskHigh     ThermalRisk = "high"
+	ThermalRiskCritical ThermalRisk = "critical"
+)
+
+// thermalWarningMargin запас в градусах до порога, при котором риск считается warning
+const thermalWarningMargin = 10.0
+
+// thermalRiskRank порядок уровней риска для выбора худшего
+var thermalRiskRank = map[ThermalRisk]int{
+	ThermalRiskUnknown:  0,
+	ThermalRiskOK:       1,
+	ThermalRiskWarning:  2,
+	ThermalRiskHigh:     3,
+	ThermalRiskCritical: 4,
+}
+
+// GetThermalStatus читает датчики температуры и оценивает риск троттлинга по порогам high/critical.
+// Частичные предупреждения gopsutil игнорируются, если хотя бы один датчик прочитан
+func GetThermalStatus(ctx context.Context) (*ThermalStatus, error) {
+	status := &ThermalStatus{Overall: ThermalRiskUnknown}
+
+	temps, err := host.SensorsTemperaturesWithContext(ctx)
+	if err != nil {
+		var warnings *host.Warnings
+		isWarnings := errors.As(err, &warnings)
+		if !isWarnings && IsPermissionError(err) {
+			logger.SysInfo.Debug().
+				Err(err).
+				Msg("Temperature sensors are not readable without elevated permissions")
+			status.Unavailable = PermissionDenied
+			return status, nil
+		}
+		if !isWarnings {
+			logger.SysInfo.Error().
+				Err(err).
+				Msg("Failed to read temperature sensors")
+			return nil, fmt.Errorf("failed to read temperature sensors: %v", err)
+		}
+
+		logger.SysInfo.Debug().
+			Err(err).
+			Int("sensors", len(temps)).
+			Msg("Some temperature sensors could not be read")
+		for _, warning := range warnings.List {
+			if IsPermissionError(warning) {
+				status.Unavailable = PermissionDenied
+				break
+			}
+		}
+	}
+
+	for _, t := range temps {
+		sensor := ThermalSensor{
+			Key:         t.SensorKey,
+			Temperature: t.Temperature,
+			High:        t.High,
+			Critical:    t.Critical,
+			Risk:        thermalRisk(t.Temperature, t.High, t.Critical),
+		}
+		if t.Critical > 0 {
+			margin := t.Critical - t.Temperature
+			sensor.MarginToCritical = &margin
+		}
+
+		if thermalRiskRank[sensor.Risk] > thermalRiskRank[status.Overall] {
+			status.Overall = sensor.Risk
+		}
+		status.Sensors = append(status.Sensors, sensor)
+	}
+
+	sort.Slice(status.Sensors, func(i, j int) bool {
+		return status.Sensors[i].Key < status.Sensors[j].Key
+	})
+
+	return status, nil
+}
+
+// thermalRisk оценивает риск для одного датчика, без порогов риск неизвестен
+func thermalRisk(temp, high, critical float64) ThermalRisk {
+	switch {
+	case critical > 0 && temp >= critical:
+		return ThermalRiskCritical
+	case high > 0 && temp >= high:
+		return ThermalRiskHigh
+	case high > 0 && high-temp <= thermalWarningMargin,
+		critical > 0 && critical-temp <= thermalWarningMargin:
+		return ThermalRiskWarning
+	case high > 0 || critical > 0:
+		return ThermalRiskOK
+	default:
+		return ThermalRiskUnknown
+	}
+}
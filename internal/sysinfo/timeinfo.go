@@ -0,0 +1,94 @@
+package sysinfo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+const (
+	// ClockSynchronized часы синхронизированы с источником точного времени
+	ClockSynchronized = "synchronized"
+	// ClockUnsynchronized ядро или NTP демон сообщает, что часы не синхронизированы
+	ClockUnsynchronized = "unsynchronized"
+	// ClockSyncUnknown статус синхронизации недоступен на этой платформе
+	ClockSyncUnknown = "unknown"
+)
+
+// processStart время запуска сервера с показанием монотонных часов
+var processStart = time.Now()
+
+// GetTimeInfo возвращает текущее время, часовой пояс, статус синхронизации часов
+// и расхождение настенных и монотонных часов с запуска сервера
+func GetTimeInfo(ctx context.Context) *TimeInfo {
+	now := time.Now()
+	zone, offset := now.Zone()
+
+	info := &TimeInfo{
+		Now:       now.Format(time.RFC3339Nano),
+		Timezone:  localTimezoneName(),
+		ZoneAbbr:  zone,
+		UTCOffset: now.Format("-07:00"),
+		Sync:      readClockSync(ctx),
+
+		// Round(0) отбрасывает монотонное показание: разница считается по настенным часам
+		WallElapsedSeconds:      now.Round(0).Sub(processStart.Round(0)).Seconds(),
+		MonotonicElapsedSeconds: now.Sub(processStart).Seconds(),
+	}
+	info.ClockStepSeconds = info.WallElapsedSeconds - info.MonotonicElapsedSeconds
+
+	logger.SysInfo.Debug().
+		Str("timezone", info.Timezone).
+		Int("utc_offset_seconds", offset).
+		Str("sync_status", info.Sync.Status).
+		Str("sync_source", info.Sync.Source).
+		Float64("clock_step_seconds", info.ClockStepSeconds).
+		Msg("Time information collected")
+
+	return info
+}
+
+// zoneinfoName извлекает имя часового пояса IANA из пути файла zoneinfo,
+// например /usr/share/zoneinfo/Europe/Moscow -> Europe/Moscow
+func zoneinfoName(path string) string {
+	_, name, found := strings.Cut(path, "zoneinfo/")
+	if !found {
+		return ""
+	}
+	return name
+}
+
+// parseChronyTracking разбирает вывод "chronyc -c tracking": CSV с полями reference ID, имя источника,
+// stratum, время ссылки, смещение системных часов (секунды), ..., leap status последним полем
+func parseChronyTracking(output string) (ClockSync, bool) {
+	fields := strings.Split(strings.TrimSpace(output), ",")
+	if len(fields) < 13 {
+		return ClockSync{}, false
+	}
+
+	stratum, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return ClockSync{}, false
+	}
+	offset, err := strconv.ParseFloat(fields[4], 64)
+	if err != nil {
+		return ClockSync{}, false
+	}
+
+	sync := ClockSync{
+		Status:        ClockSynchronized,
+		Source:        "chronyc",
+		Server:        fields[1],
+		Stratum:       stratum,
+		OffsetSeconds: &offset,
+	}
+	// Stratum 0 или leap status "Not synchronised": источника точного времени нет
+	if stratum == 0 || strings.EqualFold(fields[len(fields)-1], "Not synchronised") {
+		sync.Status = ClockUnsynchronized
+	}
+
+	return sync, true
+}
@@ -0,0 +1,83 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+const (
+	// staUnsync бит STA_UNSYNC статуса adjtimex: часы не синхронизированы
+	staUnsync = 0x0040
+	// staNano бит STA_NANO статуса adjtimex: смещение в наносекундах, иначе в микросекундах
+	staNano = 0x2000
+	// timeError состояние TIME_ERROR, которое возвращает adjtimex для несинхронизированных часов
+	timeError = 5
+	// chronycTimeout ограничение времени запроса к chronyd
+	chronycTimeout = 2 * time.Second
+)
+
+// localTimezoneName возвращает имя часового пояса IANA из TZ или ссылки /etc/localtime
+func localTimezoneName() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return tz
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if name := zoneinfoName(target); name != "" {
+			return name
+		}
+	}
+	return time.Local.String()
+}
+
+// readClockSync читает статус синхронизации часов. chronyc дает смещение относительно NTP сервера,
+// без chrony используется состояние ядра из adjtimex, которое выставляет любой NTP демон
+func readClockSync(ctx context.Context) ClockSync {
+	runCtx, cancel := context.WithTimeout(ctx, chronycTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, "chronyc", "-c", "tracking")
+	cmd.Env = systemCommandEnv
+	output, err := cmd.Output()
+	if err == nil {
+		if sync, ok := parseChronyTracking(string(output)); ok {
+			return sync
+		}
+	}
+	logger.SysInfo.Debug().
+		Err(err).
+		Msg("chronyc tracking is not available, falling back to adjtimex")
+
+	var tx syscall.Timex
+	state, err := syscall.Adjtimex(&tx)
+	if err != nil {
+		logger.SysInfo.Debug().
+			Err(err).
+			Msg("adjtimex is not available")
+		return ClockSync{Status: ClockSyncUnknown}
+	}
+
+	offset := float64(tx.Offset) / 1e6
+	if int64(tx.Status)&staNano != 0 {
+		offset = float64(tx.Offset) / 1e9
+	}
+	maxError := float64(tx.Maxerror) / 1e6
+
+	sync := ClockSync{
+		Status:          ClockSynchronized,
+		Source:          "adjtimex",
+		OffsetSeconds:   &offset,
+		MaxErrorSeconds: &maxError,
+	}
+	if state == timeError || int64(tx.Status)&staUnsync != 0 {
+		sync.Status = ClockUnsynchronized
+	}
+
+	return sync
+}
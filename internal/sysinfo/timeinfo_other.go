@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sysinfo
+
+import (
+	"context"
+	"time"
+)
+
+// localTimezoneName возвращает имя часового пояса, известное runtime
+func localTimezoneName() string {
+	return time.Local.String()
+}
+
+// readClockSync статус синхронизации часов читается только в Linux
+func readClockSync(_ context.Context) ClockSync {
+	return ClockSync{Status: ClockSyncUnknown}
+}
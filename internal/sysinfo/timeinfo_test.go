@@ -0,0 +1,44 @@
+package sysinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseChronyTracking(t *testing.T) {
+	sync, ok := parseChronyTracking("A9FEA97B,169.254.169.123,4,1700000000.123456789,-0.000012345,0.000001,0.000020,-3.2,0.001,0.05,0.0001,0.0005,64.5,Normal\n")
+	if !ok {
+		t.Fatal("parseChronyTracking() rejected valid output")
+	}
+	if sync.Status != ClockSynchronized || sync.Server != "169.254.169.123" || sync.Stratum != 4 || *sync.OffsetSeconds != -0.000012345 {
+		t.Fatalf("parseChronyTracking() = %+v", sync)
+	}
+
+	unsynced, ok := parseChronyTracking("00000000,,0,0.000000000,0.000000000,0,0,0,0,0,0,0,0,Not synchronised")
+	if !ok || unsynced.Status != ClockUnsynchronized {
+		t.Fatalf("parseChronyTracking() = %+v, %v, want unsynchronized", unsynced, ok)
+	}
+
+	if _, ok := parseChronyTracking("506 Cannot talk to daemon"); ok {
+		t.Fatal("parseChronyTracking() accepted an error message")
+	}
+}
+
+func TestZoneinfoName(t *testing.T) {
+	if got := zoneinfoName("/usr/share/zoneinfo/Europe/Moscow"); got != "Europe/Moscow" {
+		t.Fatalf("zoneinfoName() = %q, want Europe/Moscow", got)
+	}
+	if got := zoneinfoName("/etc/custom"); got != "" {
+		t.Fatalf("zoneinfoName() = %q, want empty", got)
+	}
+}
+
+func TestTimeInfoFormatTextUnknownSync(t *testing.T) {
+	info := GetTimeInfo(context.Background())
+	info.Sync = ClockSync{Status: ClockSyncUnknown}
+
+	if text := info.FormatText(); !strings.Contains(text, "sync status unknown") {
+		t.Fatalf("FormatText() does not report unknown sync:\n%s", text)
+	}
+}
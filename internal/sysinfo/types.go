@@ -1,16 +1,29 @@
 package sysinfo
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
 
 type SystemInfo struct {
-	CPU    CPUInfo    `json:"cpu"`
-	Memory MemoryInfo `json:"memory"`
+	// Hostname имя хоста, при REDACT_HOSTNAME - его хеш
+	Hostname string     `json:"hostname,omitempty"`
+	CPU      CPUInfo    `json:"cpu"`
+	Memory   MemoryInfo `json:"memory"`
+	Load     LoadInfo   `json:"load"`
 }
 
 type CPUInfo struct {
-	Count        int     `json:"count"`
-	ModelName    string  `json:"model_name"`
-	UsagePercent float64 `json:"usage_percent"`
+	Count int `json:"count"`
+	// AvailableCores процессоры, доступные процессу с учетом маски привязки (taskset, cpuset)
+	AvailableCores int     `json:"available_cores"`
+	ModelName      string  `json:"model_name"`
+	UsagePercent   float64 `json:"usage_percent"`
+	// StealPercent время, отобранное гипервизором, заполняется только на Linux
+	StealPercent float64 `json:"steal_percent,omitempty"`
 }
 
 type MemoryInfo struct {
@@ -20,14 +33,1656 @@ type MemoryInfo struct {
 	UsedPercent float64 `json:"used_percent"`
 }
 
+// LoadInfo средняя загрузка системы, Available=false на платформах без load average
+type LoadInfo struct {
+	Available bool    `json:"available"`
+	Load1     float64 `json:"load1"`
+	Load5     float64 `json:"load5"`
+	Load15    float64 `json:"load15"`
+}
+
+// SummaryFields поля, поддерживаемые FormatSummary
+var SummaryFields = []string{"cpu", "mem", "load", "cores"}
+
+// DefaultSummaryFields поля однострочной сводки по умолчанию
+var DefaultSummaryFields = []string{"cpu", "mem", "load"}
+
 // FormatText formats system information as human-readable text
 func (s *SystemInfo) FormatText() string {
-	return fmt.Sprintf("System Information:\n\nCPU:\n- Core count: %d\n- Model: %s\n- Usage: %.2f%%\n\nMemory:\n- Total: %.2f GB\n- Available: %.2f GB\n- Used: %.2f GB (%.2f%%)",
+	cpuText := fmt.Sprintf("- Core count: %d\n- Model: %s\n- Usage: %.2f%%",
 		s.CPU.Count,
-		s.CPU.ModelName,
-		s.CPU.UsagePercent,
+		TruncateModelName(s.CPU.ModelName),
+		s.CPU.UsagePercent)
+	if s.CPU.AvailableCores > 0 {
+		cpuText += fmt.Sprintf("\n- Available to process: %d (CPU affinity)", s.CPU.AvailableCores)
+	}
+	if s.CPU.StealPercent > 0 {
+		cpuText += fmt.Sprintf("\n- Steal (hypervisor): %.2f%%", s.CPU.StealPercent)
+	}
+
+	hostText := ""
+	if s.Hostname != "" {
+		hostText = fmt.Sprintf("Host: %s\n\n", s.Hostname)
+	}
+
+	return fmt.Sprintf("System Information:\n\n%sCPU:\n%s\n\nMemory:\n- Total: %.2f GB\n- Available: %.2f GB\n- Used: %.2f GB (%.2f%%)",
+		hostText,
+		cpuText,
 		float64(s.Memory.Total)/(1024*1024*1024),
 		float64(s.Memory.Available)/(1024*1024*1024),
 		float64(s.Memory.Used)/(1024*1024*1024),
 		s.Memory.UsedPercent)
 }
+
+// FormatSummary formats selected metrics as a compact single line, e.g. "CPU 23% | MEM 61% (9.8/16.0 GB) | LOAD 1.20".
+// Unknown fields are skipped; with no fields DefaultSummaryFields are used
+func (s *SystemInfo) FormatSummary(fields ...string) string {
+	if len(fields) == 0 {
+		fields = DefaultSummaryFields
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "cpu":
+			parts = append(parts, fmt.Sprintf("CPU %.0f%%", s.CPU.UsagePercent))
+		case "mem":
+			parts = append(parts, fmt.Sprintf("MEM %.0f%% (%.1f/%.1f GB)",
+				s.Memory.UsedPercent,
+				float64(s.Memory.Used)/(1024*1024*1024),
+				float64(s.Memory.Total)/(1024*1024*1024)))
+		case "load":
+			if s.Load.Available {
+				parts = append(parts, fmt.Sprintf("LOAD %.2f", s.Load.Load1))
+			} else {
+				parts = append(parts, "LOAD n/a")
+			}
+		case "cores":
+			parts = append(parts, fmt.Sprintf("CORES %d", s.CPU.Count))
+		}
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// FDInfo информация о файловых дескрипторах (handle в Windows) процесса сервера
+type FDInfo struct {
+	Platform        string `json:"platform"`
+	Supported       bool   `json:"supported"`
+	OpenFDs         int    `json:"open_fds"`
+	SoftLimit       uint64 `json:"soft_limit,omitempty"`
+	HardLimit       uint64 `json:"hard_limit,omitempty"`
+	SystemAvailable bool   `json:"system_available"`
+	SystemAllocated uint64 `json:"system_allocated,omitempty"`
+	SystemMax       uint64 `json:"system_max,omitempty"`
+}
+
+// FormatText formats file descriptor information as human-readable text
+func (f *FDInfo) FormatText() string {
+	if !f.Supported {
+		return fmt.Sprintf("File Descriptors:\n\n- Platform: %s\n- Status: unsupported", f.Platform)
+	}
+
+	if f.Platform == "windows" {
+		return fmt.Sprintf("Process Handles:\n\n- Platform: %s\n- Open handles: %d", f.Platform, f.OpenFDs)
+	}
+
+	text := fmt.Sprintf("File Descriptors:\n\nProcess:\n- Open: %d\n- Soft limit: %d\n- Hard limit: %d",
+		f.OpenFDs, f.SoftLimit, f.HardLimit)
+	if f.OpenFDs >= 0 && f.SoftLimit > 0 {
+		text += fmt.Sprintf("\n- Usage: %.2f%%", float64(f.OpenFDs)/float64(f.SoftLimit)*100)
+	}
+
+	if f.SystemAvailable {
+		text += fmt.Sprintf("\n\nSystem:\n- Allocated: %d\n- Max: %d", f.SystemAllocated, f.SystemMax)
+		if f.SystemMax > 0 {
+			text += fmt.Sprintf("\n- Usage: %.2f%%", float64(f.SystemAllocated)/float64(f.SystemMax)*100)
+		}
+	}
+
+	return text
+}
+
+// RuntimeInfo статистика Go runtime процесса сервера
+type RuntimeInfo struct {
+	GoVersion    string        `json:"go_version"`
+	GOOS         string        `json:"goos"`
+	GOARCH       string        `json:"goarch"`
+	NumCPU       int           `json:"num_cpu"`
+	GOMAXPROCS   int           `json:"gomaxprocs"`
+	Goroutines   int           `json:"goroutines"`
+	HeapAlloc    uint64        `json:"heap_alloc_bytes"`
+	HeapSys      uint64        `json:"heap_sys_bytes"`
+	HeapObjects  uint64        `json:"heap_objects"`
+	Sys          uint64        `json:"sys_bytes"`
+	NumGC        uint32        `json:"num_gc"`
+	GCPauseTotal time.Duration `json:"gc_pause_total_ns"`
+	LastGCPause  time.Duration `json:"last_gc_pause_ns"`
+	LastGC       time.Time     `json:"last_gc,omitempty"`
+}
+
+// FormatText formats Go runtime statistics as human-readable text
+func (r *RuntimeInfo) FormatText() string {
+	text := fmt.Sprintf("Server Process Runtime (Go, not host metrics):\n\nRuntime:\n- Go version: %s\n- Platform: %s/%s\n- CPUs: %d (GOMAXPROCS %d)\n- Goroutines: %d\n\nMemory:\n- Heap alloc: %.2f MB\n- Heap sys: %.2f MB\n- Heap objects: %d\n- Total sys: %.2f MB\n\nGC:\n- Cycles: %d\n- Total pause: %v",
+		r.GoVersion,
+		r.GOOS,
+		r.GOARCH,
+		r.NumCPU,
+		r.GOMAXPROCS,
+		r.Goroutines,
+		float64(r.HeapAlloc)/(1024*1024),
+		float64(r.HeapSys)/(1024*1024),
+		r.HeapObjects,
+		float64(r.Sys)/(1024*1024),
+		r.NumGC,
+		r.GCPauseTotal)
+
+	if r.NumGC > 0 {
+		text += fmt.Sprintf("\n- Last pause: %v\n- Last GC: %s", r.LastGCPause, r.LastGC.Format(time.RFC3339))
+	}
+
+	return text
+}
+
+// SelfStats потребление ресурсов процессом сервера (не хоста)
+type SelfStats struct {
+	PID int32 `json:"pid"`
+	// CPUPercent загрузка CPU с предыдущего вызова, nil при первом вызове
+	CPUPercent *float64 `json:"cpu_percent,omitempty"`
+	// CPUAveragePercent средняя загрузка CPU с момента запуска процесса
+	CPUAveragePercent float64       `json:"cpu_average_percent"`
+	RSS               uint64        `json:"rss_bytes"`
+	VMS               uint64        `json:"vms_bytes"`
+	Threads           int32         `json:"threads"`
+	OpenFDs           int           `json:"open_fds"`
+	Uptime            time.Duration `json:"uptime_ns"`
+}
+
+// FormatText formats the server's own resource usage as human-readable text
+func (s *SelfStats) FormatText() string {
+	cpuSinceLast := "n/a (first call, available from the next call)"
+	if s.CPUPercent != nil {
+		cpuSinceLast = fmt.Sprintf("%.1f%%", *s.CPUPercent)
+	}
+	openFDs := "unavailable"
+	if s.OpenFDs >= 0 {
+		openFDs = fmt.Sprintf("%d", s.OpenFDs)
+	}
+
+	return fmt.Sprintf("MCP Server Process Usage (this server's own usage, not host metrics):\n\n- PID: %d\n- CPU since last call: %s\n- CPU average since start: %.1f%%\n- RSS: %s\n- Virtual memory: %s\n- Threads: %d\n- Open file descriptors: %s\n- Uptime: %v",
+		s.PID,
+		cpuSinceLast,
+		s.CPUAveragePercent,
+		FormatBytes(int64(s.RSS)),
+		FormatBytes(int64(s.VMS)),
+		s.Threads,
+		openFDs,
+		s.Uptime)
+}
+
+// DiskIOInfo iostat-подобные метрики дисков за интервал замера
+type DiskIOInfo struct {
+	Interval             time.Duration `json:"interval_ns"`
+	UtilizationAvailable bool          `json:"utilization_available"`
+	Devices              []DiskIOStats `json:"devices"`
+}
+
+// DiskIOStats метрики одного блочного устройства
+type DiskIOStats struct {
+	Device             string  `json:"device"`
+	ReadsPerSec        float64 `json:"reads_per_sec"`
+	WritesPerSec       float64 `json:"writes_per_sec"`
+	ReadBytesSec       float64 `json:"read_bytes_per_sec"`
+	WriteBytesSec      float64 `json:"write_bytes_per_sec"`
+	AwaitMs            float64 `json:"await_ms"`
+	UtilizationPercent float64 `json:"utilization_percent,omitempty"`
+	AvgQueueSize       float64 `json:"avg_queue_size,omitempty"`
+}
+
+// FormatText formats disk I/O statistics as human-readable text
+func (d *DiskIOInfo) FormatText() string {
+	text := fmt.Sprintf("Disk I/O (sampled over %v):\n", d.Interval)
+	if len(d.Devices) == 0 {
+		return text + "\nNo block devices reported"
+	}
+
+	for _, dev := range d.Devices {
+		text += fmt.Sprintf("\n%s:\n- Reads: %.1f/s (%.2f MB/s)\n- Writes: %.1f/s (%.2f MB/s)\n- Avg I/O wait: %.2f ms",
+			dev.Device,
+			dev.ReadsPerSec,
+			dev.ReadBytesSec/(1024*1024),
+			dev.WritesPerSec,
+			dev.WriteBytesSec/(1024*1024),
+			dev.AwaitMs)
+		if d.UtilizationAvailable {
+			text += fmt.Sprintf("\n- Utilization: %.2f%%\n- Avg queue size: %.2f", dev.UtilizationPercent, dev.AvgQueueSize)
+		}
+		text += "\n"
+	}
+
+	if !d.UtilizationAvailable {
+		text += "\nNote: utilization and queue size are not available on this platform"
+	}
+
+	return text
+}
+
+// ListeningPortsInfo список слушающих портов хоста
+type ListeningPortsInfo struct {
+	Ports []ListeningPort `json:"ports"`
+	// Total число найденных сокетов, Ports может содержать только их часть
+	Total         int `json:"total"`
+	UnknownOwners int `json:"unknown_owners"`
+}
+
+// ListeningPort слушающий сокет и владеющий им процесс, PID=0 если владелец недоступен
+type ListeningPort struct {
+	Protocol    string `json:"protocol"`
+	Address     string `json:"address"`
+	Port        uint32 `json:"port"`
+	PID         int32  `json:"pid,omitempty"`
+	ProcessName string `json:"process_name,omitempty"`
+	IPVersion   int    `json:"ip_version"`
+}
+
+// FormatText formats listening ports as human-readable text
+func (l *ListeningPortsInfo) FormatText() string {
+	if l.Total == 0 {
+		return "Listening Ports:\n\nNo listening ports found"
+	}
+
+	text := fmt.Sprintf("Listening Ports (%d):\n", l.Total)
+	for _, p := range l.Ports {
+		owner := PermissionDenied
+		if p.PID > 0 {
+			owner = fmt.Sprintf("%d", p.PID)
+			if p.ProcessName != "" {
+				owner += " (" + p.ProcessName + ")"
+			}
+		}
+		text += fmt.Sprintf("\n- %s %s:%d  pid: %s", p.Protocol, p.Address, p.Port, owner)
+	}
+
+	if l.UnknownOwners > 0 {
+		text += fmt.Sprintf("\n\nNote: owner of %d socket(s) could not be read (insufficient permissions)", l.UnknownOwners)
+	}
+
+	return text
+}
+
+// HostSummary статические данные хоста для заголовка стримов мониторинга
+type HostSummary struct {
+	CPUModel    string `json:"cpu_model"`
+	CPUCount    int    `json:"cpu_count"`
+	MemoryTotal uint64 `json:"memory_total"`
+}
+
+// StaticCPUInfo статическая информация о процессорах без замера загрузки
+type StaticCPUInfo struct {
+	PhysicalCores int         `json:"physical_cores"`
+	LogicalCores  int         `json:"logical_cores"`
+	Sockets       []CPUSocket `json:"sockets"`
+}
+
+// CPUSocket информация об одном физическом процессорном пакете
+type CPUSocket struct {
+	PhysicalID  string  `json:"physical_id"`
+	VendorID    string  `json:"vendor_id"`
+	ModelName   string  `json:"model_name"`
+	Family      string  `json:"family"`
+	Model       string  `json:"model"`
+	Stepping    int32   `json:"stepping"`
+	Mhz         float64 `json:"mhz"`
+	CacheSizeKB int32   `json:"cache_size_kb"`
+	Cores       int     `json:"cores"`
+	Threads     int     `json:"threads,omitempty"`
+}
+
+// FormatText formats static CPU information as human-readable text
+func (c *StaticCPUInfo) FormatText() string {
+	text := fmt.Sprintf("CPU Information:\n\n- Physical cores: %d\n- Logical cores: %d\n- Sockets: %d",
+		c.PhysicalCores, c.LogicalCores, len(c.Sockets))
+
+	for i, s := range c.Sockets {
+		text += fmt.Sprintf("\n\nSocket %d", i)
+		if s.PhysicalID != "" {
+			text += fmt.Sprintf(" (physical id %s)", s.PhysicalID)
+		}
+		text += fmt.Sprintf(":\n- Model: %s\n- Vendor: %s\n- Family/Model/Stepping: %s/%s/%d\n- Base frequency: %.0f MHz\n- Cores: %d",
+			TruncateModelName(s.ModelName), s.VendorID, s.Family, s.Model, s.Stepping, s.Mhz, s.Cores)
+		if s.Threads > 0 {
+			text += fmt.Sprintf("\n- Threads: %d", s.Threads)
+		}
+		if s.CacheSizeKB > 0 {
+			text += fmt.Sprintf("\n- Cache: %d KB", s.CacheSizeKB)
+		}
+	}
+
+	return text
+}
+
+// DiskUsageInfo результат сканирования занимаемого места
+type DiskUsageInfo struct {
+	Path      string           `json:"path"`
+	Total     int64            `json:"total_bytes"`
+	Scanned   int              `json:"scanned"`
+	Skipped   int              `json:"skipped"`
+	Truncated bool             `json:"truncated"`
+	Entries   []DiskUsageEntry `json:"entries"`
+}
+
+// DiskUsageEntry размер непосредственной записи сканируемого каталога
+type DiskUsageEntry struct {
+	Name    string `json:"name"`
+	IsDir   bool   `json:"is_dir"`
+	Size    int64  `json:"size_bytes"`
+	Partial bool   `json:"partial,omitempty"`
+}
+
+// FormatText formats the disk usage scan as human-readable text, like sorted `du -sh *`
+func (d *DiskUsageInfo) FormatText() string {
+	text := fmt.Sprintf("Disk Usage: %s\n\n- Total: %s in %d entries", d.Path, FormatBytes(d.Total), d.Scanned)
+	if len(d.Entries) == 0 {
+		return text + "\n\nNo entries found"
+	}
+
+	text += "\n\nLargest entries:\n"
+	for _, e := range d.Entries {
+		name := e.Name
+		if e.IsDir {
+			name += "/"
+		}
+		marker := ""
+		if e.Partial {
+			marker = " (partial)"
+		}
+		text += fmt.Sprintf("\n%10s  %s%s", FormatBytes(e.Size), name, marker)
+	}
+
+	var notes []string
+	if d.Skipped > 0 {
+		notes = append(notes, fmt.Sprintf("Note: %d entries skipped (permission denied or unreadable)", d.Skipped))
+	}
+	if d.Truncated {
+		notes = append(notes, "Note: scan was limited by depth or time, sizes marked partial are lower bounds")
+	}
+	if len(notes) > 0 {
+		text += "\n\n" + strings.Join(notes, "\n")
+	}
+
+	return text
+}
+
+// FormatBytes formats a byte count using binary units (KiB, MiB, ...)
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// LogDiskUsage результат сканирования каталогов логов
+type LogDiskUsage struct {
+	Roots        []LogRootUsage `json:"roots"`
+	Total        int64          `json:"total_bytes"`
+	LargestFiles []LogFileEntry `json:"largest_files"`
+	LargestDirs  []LogDirEntry  `json:"largest_dirs"`
+	// StaleAfter срок без изменений, после которого файл считается устаревшим, 0 - не определяются
+	StaleAfter time.Duration  `json:"stale_after_ns"`
+	StaleFiles int            `json:"stale_files"`
+	StaleSize  int64          `json:"stale_bytes"`
+	Stale      []LogFileEntry `json:"stale"`
+	Skipped    int            `json:"skipped"`
+	Truncated  bool           `json:"truncated"`
+}
+
+// LogRootUsage занимаемое место одного корневого каталога логов
+type LogRootUsage struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size_bytes"`
+	Files   int    `json:"files"`
+	Skipped int    `json:"skipped"`
+	Error   string `json:"error,omitempty"`
+}
+
+// LogFileEntry файл лога
+type LogFileEntry struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size_bytes"`
+	ModTime time.Time `json:"modified"`
+}
+
+// LogDirEntry суммарный размер вложенного каталога логов
+type LogDirEntry struct {
+	Path string `json:"path"`
+	Size int64  `json:"size_bytes"`
+}
+
+// FormatText formats the log directory scan as human-readable text
+func (l *LogDiskUsage) FormatText() string {
+	text := fmt.Sprintf("Log Disk Usage\n\n- Total: %s", FormatBytes(l.Total))
+	for _, root := range l.Roots {
+		if root.Error != "" {
+			text += fmt.Sprintf("\n- %s: unavailable (%s)", root.Path, root.Error)
+			continue
+		}
+		text += fmt.Sprintf("\n- %s: %s in %d files", root.Path, FormatBytes(root.Size), root.Files)
+	}
+
+	if len(l.LargestFiles) > 0 {
+		text += "\n\nLargest files:\n"
+		for _, file := range l.LargestFiles {
+			text += fmt.Sprintf("\n%10s  %s", FormatBytes(file.Size), file.Path)
+		}
+	}
+	if len(l.LargestDirs) > 0 {
+		text += "\n\nLargest directories:\n"
+		for _, dir := range l.LargestDirs {
+			text += fmt.Sprintf("\n%10s  %s/", FormatBytes(dir.Size), dir.Path)
+		}
+	}
+
+	if l.StaleAfter > 0 {
+		text += fmt.Sprintf("\n\nStale logs (not modified for %s): %s in %d files",
+			formatStaleAge(l.StaleAfter), FormatBytes(l.StaleSize), l.StaleFiles)
+		if len(l.Stale) > 0 {
+			text += "\n"
+		}
+		for _, file := range l.Stale {
+			text += fmt.Sprintf("\n%10s  %s (modified %s)", FormatBytes(file.Size), file.Path, file.ModTime.Format("2006-01-02"))
+		}
+	}
+
+	var notes []string
+	if l.Skipped > 0 {
+		notes = append(notes, fmt.Sprintf("Note: %d entries skipped (permission denied or unreadable)", l.Skipped))
+	}
+	if l.Truncated {
+		notes = append(notes, "Note: scan was limited by depth or time, sizes are lower bounds")
+	}
+	if len(notes) > 0 {
+		text += "\n\n" + strings.Join(notes, "\n")
+	}
+
+	return text
+}
+
+// formatStaleAge форматирует срок устаревания в днях, если он кратен суткам
+func formatStaleAge(age time.Duration) string {
+	if age%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", age/(24*time.Hour))
+	}
+	return age.String()
+}
+
+// DiskLatencyInfo результат пробы задержки записи и чтения диска
+type DiskLatencyInfo struct {
+	Dir          string        `json:"dir"`
+	SizeBytes    int           `json:"size_bytes"`
+	BlockSize    int           `json:"block_size"`
+	WriteLatency time.Duration `json:"write_latency_ns"`
+	ReadLatency  time.Duration `json:"read_latency_ns"`
+}
+
+// FormatText formats the disk latency probe result as human-readable text
+func (d *DiskLatencyInfo) FormatText() string {
+	return fmt.Sprintf("Disk Latency Probe (%s):\n\n- Test size: %s in %s blocks\n\nWrite (with fsync):\n- Latency: %.2f ms\n- Throughput: %.2f MB/s\n\nRead:\n- Latency: %.2f ms\n- Throughput: %.2f MB/s\n\nNote: reads may be served from the OS page cache",
+		d.Dir,
+		FormatBytes(int64(d.SizeBytes)),
+		FormatBytes(int64(d.BlockSize)),
+		float64(d.WriteLatency.Microseconds())/1000,
+		throughputMBps(d.SizeBytes, d.WriteLatency),
+		float64(d.ReadLatency.Microseconds())/1000,
+		throughputMBps(d.SizeBytes, d.ReadLatency))
+}
+
+// throughputMBps считает пропускную способность в MB/s
+func throughputMBps(size int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(size) / (1024 * 1024) / elapsed.Seconds()
+}
+
+// ThermalSensor состояние одного датчика температуры
+type ThermalSensor struct {
+	Key         string  `json:"key"`
+	Temperature float64 `json:"temperature_celsius"`
+	High        float64 `json:"high_celsius,omitempty"`
+	Critical    float64 `json:"critical_celsius,omitempty"`
+	// MarginToCritical запас до критического порога, nil если порог не известен
+	MarginToCritical *float64    `json:"margin_to_critical_celsius,omitempty"`
+	Risk             ThermalRisk `json:"risk"`
+}
+
+// ThermalStatus риск троттлинга по датчикам и худший уровень среди них
+type ThermalStatus struct {
+	Overall ThermalRisk     `json:"overall"`
+	Sensors []ThermalSensor `json:"sensors"`
+	// Unavailable причина, по которой часть датчиков не прочитана (PermissionDenied), пусто - все доступны
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// FormatText formats the thermal status as human-readable text
+func (t *ThermalStatus) FormatText() string {
+	if len(t.Sensors) == 0 {
+		if t.Unavailable != "" {
+			return "Thermal Status:\n\nThermal sensors " + t.Unavailable
+		}
+		return "Thermal Status:\n\nNo thermal sensors available"
+	}
+
+	text := fmt.Sprintf("Thermal Status (%d sensors):\n\n- Overall risk: %s\n", len(t.Sensors), t.Overall)
+	for _, s := range t.Sensors {
+		text += fmt.Sprintf("\n- %s: %.1f°C, risk: %s", s.Key, s.Temperature, s.Risk)
+		if s.High > 0 {
+			text += fmt.Sprintf(", high: %.1f°C", s.High)
+		}
+		if s.Critical > 0 {
+			text += fmt.Sprintf(", critical: %.1f°C", s.Critical)
+		}
+		if s.MarginToCritical != nil {
+			text += fmt.Sprintf(", margin to critical: %.1f°C", *s.MarginToCritical)
+		}
+	}
+
+	if t.Unavailable != "" {
+		text += "\n\nNote: some sensors " + t.Unavailable
+	}
+
+	return text
+}
+
+// MountUsage заполненность одной файловой системы
+type MountUsage struct {
+	Mountpoint  string  `json:"mountpoint"`
+	Device      string  `json:"device"`
+	Fstype      string  `json:"fstype"`
+	Total       uint64  `json:"total_bytes"`
+	Used        uint64  `json:"used_bytes"`
+	Free        uint64  `json:"free_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+	// Inodes использование inode, nil в Windows и на файловых системах без фиксированной таблицы inode
+	Inodes *InodeUsage `json:"inodes,omitempty"`
+	// ReadOnly файловая система смонтирована или переведена ядром в режим только для чтения
+	ReadOnly bool `json:"readonly"`
+	// Degraded обнаружены признаки ошибок файловой системы (только Linux)
+	Degraded bool `json:"degraded"`
+}
+
+// InodeUsage использование inode файловой системы
+type InodeUsage struct {
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	UsedPercent float64 `json:"used_percent"`
+	// High inode почти исчерпаны: запись файлов невозможна, даже если место в байтах есть
+	High bool `json:"high"`
+}
+
+// MountUsageInfo заполненность файловых систем по точкам монтирования
+type MountUsageInfo struct {
+	Mounts []MountUsage `json:"mounts"`
+	// Unreadable точки монтирования, статистику которых прочитать не удалось
+	Unreadable []string `json:"unreadable,omitempty"`
+	// Age возраст отчета, отданного из кеша, 0 - собран только что
+	Age time.Duration `json:"age,omitempty"`
+}
+
+// FormatText formats filesystem usage as human-readable text
+func (m *MountUsageInfo) FormatText() string {
+	if len(m.Mounts) == 0 && len(m.Unreadable) == 0 {
+		return "Disk Usage:\n\nNo mounts found"
+	}
+
+	text := fmt.Sprintf("Disk Usage (%d mounts):\n", len(m.Mounts))
+	for _, mount := range m.Mounts {
+		text += fmt.Sprintf("\n- %s (%s, %s): %s used / %s total (%.1f%%), %s free",
+			mount.Mountpoint, mount.Device, mount.Fstype,
+			FormatBytes(int64(mount.Used)), FormatBytes(int64(mount.Total)),
+			mount.UsedPercent, FormatBytes(int64(mount.Free)))
+		if mount.Inodes != nil {
+			text += fmt.Sprintf("\n  inodes: %d used / %d total (%.1f%%), %d free",
+				mount.Inodes.Used, mount.Inodes.Total, mount.Inodes.UsedPercent, mount.Inodes.Free)
+		}
+		if mount.ReadOnly || mount.Degraded {
+			text += fmt.Sprintf("\n  state: readonly=%t degraded=%t", mount.ReadOnly, mount.Degraded)
+		}
+	}
+
+	var high []string
+	for _, mount := range m.Mounts {
+		if mount.Inodes != nil && mount.Inodes.High {
+			high = append(high, fmt.Sprintf("%s (%.1f%% inodes, %.1f%% bytes)", mount.Mountpoint, mount.Inodes.UsedPercent, mount.UsedPercent))
+		}
+	}
+	if len(high) > 0 {
+		text += fmt.Sprintf("\n\nWarning: inodes nearly exhausted on %s: new files cannot be created even if free space remains", strings.Join(high, ", "))
+	}
+
+	if len(m.Unreadable) > 0 {
+		text += fmt.Sprintf("\n\nNote: usage of %s could not be read", strings.Join(m.Unreadable, ", "))
+	}
+	if m.Age > 0 {
+		text += fmt.Sprintf("\n\nCached %v ago, call with fresh=true to re-read", m.Age.Round(time.Millisecond))
+	}
+
+	return text
+}
+
+// ConnectionStats количество TCP соединений по состояниям
+type ConnectionStats struct {
+	Total   int            `json:"total"`
+	ByState map[string]int `json:"by_state"`
+	// IPv4ByState и IPv6ByState заполняются только при разбивке по семействам адресов
+	IPv4ByState map[string]int `json:"ipv4_by_state,omitempty"`
+	IPv6ByState map[string]int `json:"ipv6_by_state,omitempty"`
+	// Partial часть соединений не прочитана (недостаточно прав)
+	Partial bool `json:"partial"`
+}
+
+// FormatText formats TCP connection counts as human-readable text
+func (c *ConnectionStats) FormatText() string {
+	text := fmt.Sprintf("TCP Connections (%d):\n%s", c.Total, formatStateCounts(c.ByState))
+
+	if c.IPv4ByState != nil {
+		text += "\n\nIPv4:" + formatStateCounts(c.IPv4ByState)
+		text += "\n\nIPv6:" + formatStateCounts(c.IPv6ByState)
+	}
+
+	if c.ByState["TIME_WAIT"] > 0 || c.ByState["CLOSE_WAIT"] > 0 {
+		text += "\n\nNote: a growing TIME_WAIT count points to many short-lived connections, a growing CLOSE_WAIT count to sockets not closed by the local application"
+	}
+	if c.Partial {
+		text += "\n\nNote: some connections could not be read (insufficient permissions), only visible ones are counted"
+	}
+
+	return text
+}
+
+// formatStateCounts форматирует счетчики состояний, самые частые первыми
+func formatStateCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "\n- none"
+	}
+
+	states := make([]string, 0, len(counts))
+	for state := range counts {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if counts[states[i]] != counts[states[j]] {
+			return counts[states[i]] > counts[states[j]]
+		}
+		return states[i] < states[j]
+	})
+
+	var text string
+	for _, state := range states {
+		text += fmt.Sprintf("\n- %s: %d", state, counts[state])
+	}
+	return text
+}
+
+// SwapActivityInfo объем swap и скорость подкачки за интервал замера
+type SwapActivityInfo struct {
+	Total       uint64  `json:"total_bytes"`
+	Used        uint64  `json:"used_bytes"`
+	UsedPercent float64 `json:"used_percent"`
+	// Available false на платформах без счетчиков swap in/out, скорости тогда не заполняются
+	Available          bool          `json:"available"`
+	Interval           time.Duration `json:"interval"`
+	SwapInPagesPerSec  float64       `json:"swap_in_pages_per_sec"`
+	SwapOutPagesPerSec float64       `json:"swap_out_pages_per_sec"`
+	SwapInBytesPerSec  float64       `json:"swap_in_bytes_per_sec"`
+	SwapOutBytesPerSec float64       `json:"swap_out_bytes_per_sec"`
+}
+
+// FormatText formats swap activity as human-readable text
+func (s *SwapActivityInfo) FormatText() string {
+	text := fmt.Sprintf("Swap Activity:\n\n- Swap used: %s / %s (%.1f%%)",
+		FormatBytes(int64(s.Used)), FormatBytes(int64(s.Total)), s.UsedPercent)
+
+	if !s.Available {
+		return text + "\n\nSwap in/out counters are not available on this platform"
+	}
+
+	text += fmt.Sprintf("\n\nOver %v:\n- Swap in: %.1f pages/s (%s/s)\n- Swap out: %.1f pages/s (%s/s)",
+		s.Interval,
+		s.SwapInPagesPerSec, FormatBytes(int64(s.SwapInBytesPerSec)),
+		s.SwapOutPagesPerSec, FormatBytes(int64(s.SwapOutBytesPerSec)))
+
+	if s.SwapInPagesPerSec > 0 || s.SwapOutPagesPerSec > 0 {
+		text += "\n\nNote: active swapping indicates the system is under memory pressure"
+	}
+
+	return text
+}
+
+// HealthComponent вклад одного компонента в оценку здоровья
+type HealthComponent struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	// Penalty штраф 0-100, 0 - компонент в норме
+	Penalty float64 `json:"penalty"`
+	Weight  float64 `json:"weight"`
+	Detail  string  `json:"detail"`
+}
+
+// HealthScore сводная оценка здоровья системы 0-100 с разбивкой по компонентам
+type HealthScore struct {
+	Score         float64           `json:"score"`
+	BiggestFactor string            `json:"biggest_factor,omitempty"`
+	Explanation   string            `json:"explanation"`
+	Components    []HealthComponent `json:"components"`
+}
+
+// FormatText formats the health score as human-readable text
+func (h *HealthScore) FormatText() string {
+	text := fmt.Sprintf("System Health Score: %.0f/100\n\n%s\n\nBreakdown:", h.Score, h.Explanation)
+	for _, c := range h.Components {
+		text += fmt.Sprintf("\n- %s: penalty %.0f/100 (weight %g) - %s", c.Name, c.Penalty, c.Weight, c.Detail)
+	}
+	return text
+}
+
+// FDHog процесс и число его открытых дескрипторов
+type FDHog struct {
+	PID     int32  `json:"pid"`
+	Name    string `json:"name"`
+	OpenFDs int    `json:"open_fds"`
+}
+
+// FDHogsInfo процессы с наибольшим числом открытых дескрипторов
+type FDHogsInfo struct {
+	Platform  string  `json:"platform"`
+	Processes []FDHog `json:"processes"`
+	// Scanned процессы с прочитанным счетчиком, Denied - недоступные из-за прав, Skipped - завершившиеся или нечитаемые
+	Scanned int `json:"scanned"`
+	Denied  int `json:"permission_denied"`
+	Skipped int `json:"skipped"`
+}
+
+// FormatText formats the top processes by open descriptors as human-readable text
+func (f *FDHogsInfo) FormatText() string {
+	unit := "file descriptors"
+	if f.Platform == "windows" {
+		unit = "handles"
+	}
+
+	if len(f.Processes) == 0 {
+		return fmt.Sprintf("Top Processes by Open %s:\n\nNo readable processes (%d %s, %d skipped)", unit, f.Denied, PermissionDenied, f.Skipped)
+	}
+
+	text := fmt.Sprintf("Top Processes by Open %s (%d of %d readable):\n", unit, len(f.Processes), f.Scanned)
+	for _, p := range f.Processes {
+		text += fmt.Sprintf("\n- %d (%s): %d", p.PID, p.Name, p.OpenFDs)
+	}
+
+	if f.Denied > 0 {
+		text += fmt.Sprintf("\n\nNote: %d process(es) %s", f.Denied, PermissionDenied)
+	}
+	if f.Skipped > 0 {
+		text += fmt.Sprintf("\n\nNote: %d process(es) skipped because their count could not be read (exited)", f.Skipped)
+	}
+	switch f.Platform {
+	case "linux":
+		text += "\n\nCounts are entries in /proc/<pid>/fd"
+	case "windows":
+		text += "\n\nOn Windows the count is the process handle count (files, registry keys, events, threads...)"
+	}
+
+	return text
+}
+
+// ProcessSample показатели одного процесса в момент замера
+type ProcessSample struct {
+	PID int32 `json:"pid"`
+	// CPUPercent загрузка CPU с предыдущего замера, может превышать 100 на нескольких ядрах
+	CPUPercent    float64 `json:"cpu_percent"`
+	RSS           uint64  `json:"rss"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+// NamedProcessSample показатели процесса вместе с его именем
+type NamedProcessSample struct {
+	Name string `json:"name"`
+	ProcessSample
+}
+
+// ProcessGroupInfo суммарные показатели процессов, найденных по имени
+type ProcessGroupInfo struct {
+	Query string `json:"query"`
+	Exact bool   `json:"exact"`
+	// Matches число прочитанных совпадений, Processes только самые загруженные из них
+	Matches       int                  `json:"matches"`
+	CPUPercent    float64              `json:"cpu_percent"`
+	RSS           uint64               `json:"rss"`
+	MemoryPercent float64              `json:"memory_percent"`
+	Processes     []NamedProcessSample `json:"processes"`
+	Denied        int                  `json:"permission_denied"`
+}
+
+// FormatText formats aggregated usage of processes matched by name as human-readable text
+func (g *ProcessGroupInfo) FormatText() string {
+	match := "containing"
+	if g.Exact {
+		match = "named"
+	}
+
+	if g.Matches == 0 {
+		text := fmt.Sprintf("Processes %s %q:\n\nNo matching process", match, g.Query)
+		if g.Denied > 0 {
+			text += fmt.Sprintf(" (%d matching process(es) %s)", g.Denied, PermissionDenied)
+		}
+		return text
+	}
+
+	text := fmt.Sprintf("Processes %s %q:\n\nTotal (%d processes):\n- CPU: %.2f%%\n- Memory: %.2f MB (%.2f%%)",
+		match, g.Query, g.Matches, g.CPUPercent, float64(g.RSS)/(1024*1024), g.MemoryPercent)
+
+	if len(g.Processes) < g.Matches {
+		text += "\n\nBusiest by CPU:"
+	} else {
+		text += "\n\nBy PID:"
+	}
+	for _, p := range g.Processes {
+		text += fmt.Sprintf("\n- %d (%s): CPU %.2f%%, RSS %.2f MB (%.2f%%)",
+			p.PID, p.Name, p.CPUPercent, float64(p.RSS)/(1024*1024), p.MemoryPercent)
+	}
+
+	if g.Denied > 0 {
+		text += fmt.Sprintf("\n\nNote: %d matching process(es) %s", g.Denied, PermissionDenied)
+	}
+
+	return text
+}
+
+// Capabilities подсистемы, метрики которых доступны на этом хосте
+type Capabilities struct {
+	Platform    string `json:"platform"`
+	CPU         bool   `json:"cpu"`
+	Memory      bool   `json:"memory"`
+	Swap        bool   `json:"swap"`
+	Disk        bool   `json:"disk"`
+	Network     bool   `json:"network"`
+	Temperature bool   `json:"temperature"`
+	GPU         bool   `json:"gpu"`
+	Load        bool   `json:"load"`
+	// Permissions источники данных, которые без повышенных прав читаются частично
+	Permissions []PermissionProbe `json:"permissions_report"`
+}
+
+// PermissionProbe результат проверки доступа: PermissionOK, PermissionDenied или PermissionUnavailable
+type PermissionProbe struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// FormatText formats host capabilities as "name: true|false" lines
+func (c *Capabilities) FormatText() string {
+	text := fmt.Sprintf("Host Capabilities (%s):\n", c.Platform)
+	text += fmt.Sprintf("\ncpu: %t", c.CPU)
+	text += fmt.Sprintf("\nmemory: %t", c.Memory)
+	text += fmt.Sprintf("\nswap: %t", c.Swap)
+	text += fmt.Sprintf("\ndisk: %t", c.Disk)
+	text += fmt.Sprintf("\nnetwork: %t", c.Network)
+	text += fmt.Sprintf("\ntemperature: %t", c.Temperature)
+	text += fmt.Sprintf("\ngpu: %t", c.GPU)
+	text += fmt.Sprintf("\nload: %t", c.Load)
+
+	text += "\n\nPermissions Report:\n"
+	for _, probe := range c.Permissions {
+		text += fmt.Sprintf("\n%s: %s", probe.Name, probe.Status)
+	}
+	return text
+}
+
+// NUMANode память и процессоры одного узла NUMA
+type NUMANode struct {
+	ID    int    `json:"id"`
+	Total uint64 `json:"total"`
+	Free  uint64 `json:"free"`
+	Used  uint64 `json:"used"`
+	// CPUs список процессоров узла в формате cpulist ("0-3,8")
+	CPUs     string `json:"cpus"`
+	CPUCount int    `json:"cpu_count"`
+}
+
+// NUMAInfo распределение памяти и процессоров по узлам NUMA, Supported=false вне Linux
+type NUMAInfo struct {
+	Supported bool       `json:"supported"`
+	Nodes     []NUMANode `json:"nodes"`
+}
+
+// FormatText formats per-node NUMA memory as human-readable text
+func (n *NUMAInfo) FormatText() string {
+	if !n.Supported {
+		return "NUMA Information:\n\nNUMA topology is only available on Linux"
+	}
+	if len(n.Nodes) == 0 {
+		return "NUMA Information:\n\nNon-NUMA system: no NUMA nodes exposed by the kernel"
+	}
+
+	text := fmt.Sprintf("NUMA Information (%d nodes):\n", len(n.Nodes))
+	if len(n.Nodes) == 1 {
+		text = "NUMA Information (non-NUMA system, single node):\n"
+	}
+
+	for _, node := range n.Nodes {
+		usedPercent := 0.0
+		if node.Total > 0 {
+			usedPercent = float64(node.Used) / float64(node.Total) * 100
+		}
+		text += fmt.Sprintf("\n- Node %d: %s used / %s total (%.1f%%), %s free, CPUs: %s (%d)",
+			node.ID, FormatBytes(int64(node.Used)), FormatBytes(int64(node.Total)), usedPercent,
+			FormatBytes(int64(node.Free)), node.CPUs, node.CPUCount)
+	}
+
+	return text
+}
+
+// ZombieProcess завершившийся процесс, статус которого не забрал родитель
+type ZombieProcess struct {
+	PID        int32  `json:"pid"`
+	PPID       int32  `json:"ppid"`
+	Name       string `json:"name"`
+	ParentName string `json:"parent_name"`
+}
+
+// ZombiesInfo процессы в состоянии zombie (defunct)
+type ZombiesInfo struct {
+	Zombies []ZombieProcess `json:"zombies"`
+	// Total число найденных зомби, Zombies может содержать только их часть
+	Total int `json:"total"`
+	// Scanned процессы с прочитанным статусом, Unreadable - без него (права, завершились)
+	Scanned    int `json:"scanned"`
+	Unreadable int `json:"unreadable"`
+}
+
+// FormatText formats zombie processes grouped by parent as human-readable text
+func (z *ZombiesInfo) FormatText() string {
+	text := fmt.Sprintf("Zombie Processes: %d (of %d scanned)\n", z.Total, z.Scanned)
+
+	if z.Total == 0 {
+		text += "\nNo zombie processes found"
+	} else {
+		// Список отсортирован по PPID, родитель с зомби выводится один раз
+		lastParent := int32(-1)
+		for _, zombie := range z.Zombies {
+			if zombie.PPID != lastParent {
+				lastParent = zombie.PPID
+				text += fmt.Sprintf("\n- Parent %d (%s) is not reaping:", zombie.PPID, zombie.ParentName)
+			}
+			text += fmt.Sprintf("\n  - %d (%s) <defunct>", zombie.PID, zombie.Name)
+		}
+	}
+
+	if z.Unreadable > 0 {
+		text += fmt.Sprintf("\n\nNote: status of %d process(es) could not be read (insufficient permissions or exited)", z.Unreadable)
+	}
+
+	return text
+}
+
+// connLimitHighPercent порог заполненности conntrack и эфемерных портов для предупреждения
+const connLimitHighPercent = 80
+
+// ConntrackInfo заполненность таблицы conntrack и эфемерных портов, Supported=false вне Linux
+type ConntrackInfo struct {
+	Supported bool `json:"supported"`
+
+	ConntrackAvailable   bool    `json:"conntrack_available"`
+	ConntrackCount       uint64  `json:"conntrack_count"`
+	ConntrackMax         uint64  `json:"conntrack_max"`
+	ConntrackUsedPercent float64 `json:"conntrack_used_percent"`
+
+	PortRangeAvailable bool   `json:"port_range_available"`
+	PortRangeLow       uint32 `json:"port_range_low"`
+	PortRangeHigh      uint32 `json:"port_range_high"`
+	// EphemeralPortsInUse различные локальные порты из диапазона у не слушающих сокетов (оценка)
+	EphemeralPortsInUse       int     `json:"ephemeral_ports_in_use"`
+	EphemeralPortsUsedPercent float64 `json:"ephemeral_ports_used_percent"`
+}
+
+// FormatText formats conntrack and ephemeral port usage as human-readable text
+func (c *ConntrackInfo) FormatText() string {
+	if !c.Supported {
+		return "Connection Limits:\n\nConntrack and ephemeral port information is only available on Linux"
+	}
+
+	text := "Connection Limits:\n\nConntrack:"
+	if c.ConntrackAvailable {
+		text += fmt.Sprintf("\n- Entries: %d / %d (%.1f%%)", c.ConntrackCount, c.ConntrackMax, c.ConntrackUsedPercent)
+	} else {
+		text += "\n- unavailable (nf_conntrack module not loaded)"
+	}
+
+	text += "\n\nEphemeral Ports:"
+	if c.PortRangeAvailable {
+		text += fmt.Sprintf("\n- Range: %d-%d (%d ports)\n- In use (estimate): %d (%.1f%%)",
+			c.PortRangeLow, c.PortRangeHigh, c.PortRangeHigh-c.PortRangeLow+1,
+			c.EphemeralPortsInUse, c.EphemeralPortsUsedPercent)
+	} else {
+		text += "\n- unavailable"
+	}
+
+	if c.ConntrackAvailable && c.ConntrackUsedPercent >= connLimitHighPercent {
+		text += "\n\nWarning: conntrack table is nearly full, new connections may be dropped"
+	}
+	if c.PortRangeAvailable && c.EphemeralPortsUsedPercent >= connLimitHighPercent {
+		text += "\n\nWarning: ephemeral ports are nearly exhausted, outgoing connections may fail"
+	}
+
+	return text
+}
+
+// FSStatus состояние точки монтирования с причинами, по которым она считается проблемной
+type FSStatus struct {
+	Mountpoint string   `json:"mountpoint"`
+	Device     string   `json:"device"`
+	Fstype     string   `json:"fstype"`
+	ReadOnly   bool     `json:"readonly"`
+	Degraded   bool     `json:"degraded"`
+	Reasons    []string `json:"reasons,omitempty"`
+}
+
+// FSStatusInfo проблемные файловые системы среди проверенных физических разделов
+type FSStatusInfo struct {
+	Checked  int        `json:"checked"`
+	Problems []FSStatus `json:"problems"`
+}
+
+// FormatText formats problematic filesystems as human-readable text
+func (f *FSStatusInfo) FormatText() string {
+	if len(f.Problems) == 0 {
+		return fmt.Sprintf("Filesystem Status:\n\nAll %d filesystems are writable, no errors detected", f.Checked)
+	}
+
+	text := fmt.Sprintf("Filesystem Status (%d of %d filesystems need attention):\n", len(f.Problems), f.Checked)
+	for _, status := range f.Problems {
+		text += fmt.Sprintf("\n- %s (%s, %s): readonly=%t degraded=%t",
+			status.Mountpoint, status.Device, status.Fstype, status.ReadOnly, status.Degraded)
+		for _, reason := range status.Reasons {
+			text += "\n  " + reason
+		}
+	}
+
+	return text
+}
+
+// CPUTimesBreakdown доля времени CPU по состояниям в процентах, поля без поддержки платформы не заполняются
+type CPUTimesBreakdown struct {
+	CPU     string   `json:"cpu"`
+	User    float64  `json:"user"`
+	System  float64  `json:"system"`
+	Idle    float64  `json:"idle"`
+	Nice    *float64 `json:"nice,omitempty"`
+	Iowait  *float64 `json:"iowait,omitempty"`
+	Irq     *float64 `json:"irq,omitempty"`
+	Softirq *float64 `json:"softirq,omitempty"`
+	Steal   *float64 `json:"steal,omitempty"`
+}
+
+// formatLine форматирует распределение в одну строку, пропуская недоступные поля
+func (b CPUTimesBreakdown) formatLine() string {
+	line := fmt.Sprintf("user %.1f%%, system %.1f%%, idle %.1f%%", b.User, b.System, b.Idle)
+
+	optional := []struct {
+		name  string
+		value *float64
+	}{
+		{"nice", b.Nice}, {"iowait", b.Iowait}, {"irq", b.Irq}, {"softirq", b.Softirq}, {"steal", b.Steal},
+	}
+	for _, field := range optional {
+		if field.value != nil {
+			line += fmt.Sprintf(", %s %.1f%%", field.name, *field.value)
+		}
+	}
+
+	return line
+}
+
+// CPUTimesInfo распределение времени CPU за интервал замера
+type CPUTimesInfo struct {
+	Interval time.Duration       `json:"interval"`
+	Total    CPUTimesBreakdown   `json:"total"`
+	PerCore  []CPUTimesBreakdown `json:"per_core,omitempty"`
+}
+
+// FormatText formats the CPU times breakdown as human-readable text
+func (c *CPUTimesInfo) FormatText() string {
+	text := fmt.Sprintf("CPU Times (over %v):\n\n- Total: %s", c.Interval, c.Total.formatLine())
+
+	if len(c.PerCore) > 0 {
+		text += "\n\nPer Core:"
+		for _, core := range c.PerCore {
+			text += fmt.Sprintf("\n- %s: %s", core.CPU, core.formatLine())
+		}
+	}
+
+	return text
+}
+
+// Battery состояние одной батареи
+type Battery struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+	// Status состояние из sysfs: Charging, Discharging, Full, Not charging, Unknown
+	Status string `json:"status"`
+	// TimeRemainingSeconds оценка времени до разряда, только при разрядке
+	TimeRemainingSeconds *int64 `json:"time_remaining_seconds,omitempty"`
+	// TimeToFullSeconds оценка времени до полного заряда, только при зарядке
+	TimeToFullSeconds *int64 `json:"time_to_full_seconds,omitempty"`
+}
+
+// BatteryAggregate суммарный заряд нескольких батарей
+type BatteryAggregate struct {
+	Percent              float64 `json:"percent"`
+	TimeRemainingSeconds *int64  `json:"time_remaining_seconds,omitempty"`
+}
+
+// PowerInfo состояние питания хоста, Supported=false вне Linux
+type PowerInfo struct {
+	Supported bool `json:"supported"`
+	// ACOnline подключено ли сетевое питание, nil если адаптер не виден системе
+	ACOnline  *bool     `json:"ac_online,omitempty"`
+	OnBattery bool      `json:"on_battery"`
+	Batteries []Battery `json:"batteries"`
+	// Aggregate заполняется при нескольких батареях
+	Aggregate *BatteryAggregate `json:"aggregate,omitempty"`
+}
+
+// FormatText formats power and battery status as human-readable text
+func (p *PowerInfo) FormatText() string {
+	if !p.Supported {
+		return "Power Status:\n\nBattery status is only available on Linux"
+	}
+	if len(p.Batteries) == 0 {
+		return "Power Status:\n\nOn AC power / no battery"
+	}
+
+	text := "Power Status:\n\n"
+	switch {
+	case p.OnBattery:
+		text += "- Power source: battery"
+	case p.ACOnline != nil && *p.ACOnline:
+		text += "- Power source: AC"
+	default:
+		text += "- Power source: unknown"
+	}
+
+	for _, battery := range p.Batteries {
+		text += fmt.Sprintf("\n- %s: %.0f%%, %s", battery.Name, battery.Percent, battery.Status)
+		if battery.TimeRemainingSeconds != nil {
+			text += fmt.Sprintf(", %v remaining", time.Duration(*battery.TimeRemainingSeconds)*time.Second)
+		}
+		if battery.TimeToFullSeconds != nil {
+			text += fmt.Sprintf(", %v until full", time.Duration(*battery.TimeToFullSeconds)*time.Second)
+		}
+	}
+
+	if p.Aggregate != nil {
+		text += fmt.Sprintf("\n- Total: %.0f%%", p.Aggregate.Percent)
+		if p.Aggregate.TimeRemainingSeconds != nil {
+			text += fmt.Sprintf(", %v remaining", time.Duration(*p.Aggregate.TimeRemainingSeconds)*time.Second)
+		}
+	}
+
+	if p.OnBattery {
+		text += "\n\nWarning: host is running on battery"
+	}
+
+	return text
+}
+
+// EntropyInfo доступная энтропия ядра, Available=false если платформа ее не сообщает
+type EntropyInfo struct {
+	Available    bool   `json:"available"`
+	EntropyBits  uint64 `json:"entropy_bits"`
+	PoolSizeBits uint64 `json:"pool_size_bits,omitempty"`
+	LowThreshold uint64 `json:"low_threshold"`
+	Low          bool   `json:"low"`
+}
+
+// FormatText formats kernel entropy availability as human-readable text
+func (e *EntropyInfo) FormatText() string {
+	if !e.Available {
+		return "Entropy:\n\nunavailable (/proc/sys/kernel/random is only exposed on Linux)"
+	}
+
+	text := fmt.Sprintf("Entropy:\n\n- Available: %d bits", e.EntropyBits)
+	if e.PoolSizeBits > 0 {
+		text += fmt.Sprintf(" / %d pool (%.1f%%)", e.PoolSizeBits, float64(e.EntropyBits)/float64(e.PoolSizeBits)*100)
+	}
+	text += fmt.Sprintf("\n- Low threshold: %d bits", e.LowThreshold)
+
+	if e.Low {
+		text += "\n\nWarning: entropy is low, blocking reads from /dev/random (TLS handshakes, key generation) may stall"
+	}
+
+	return text
+}
+
+// KernelLogEntry запись журнала ядра. Timestamp - секунды с загрузки для kmsg или RFC3339 для journalctl
+type KernelLogEntry struct {
+	Timestamp string `json:"timestamp"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+
+	// at время записи journalctl, sinceBoot - смещение записи kmsg от загрузки
+	at        time.Time
+	sinceBoot time.Duration
+}
+
+// Time возвращает время записи, для kmsg - отсчитанное от времени загрузки boot
+func (e KernelLogEntry) Time(boot time.Time) time.Time {
+	if !e.at.IsZero() {
+		return e.at
+	}
+	return boot.Add(e.sinceBoot)
+}
+
+// KernelWarnings записи журнала ядра не ниже порога важности.
+// Available=false если ни один источник не читается, причина в Reason
+type KernelWarnings struct {
+	Supported bool   `json:"supported"`
+	Available bool   `json:"available"`
+	Source    string `json:"source,omitempty"`
+	Severity  string `json:"severity"`
+	Reason    string `json:"reason,omitempty"`
+	// Total число подходящих записей в источнике, Entries только последние из них
+	Total   int              `json:"total"`
+	Counts  map[string]int   `json:"counts,omitempty"`
+	Entries []KernelLogEntry `json:"entries"`
+}
+
+// FormatText formats kernel log warnings as human-readable text
+func (k *KernelWarnings) FormatText() string {
+	if !k.Supported {
+		return "Kernel Warnings:\n\nKernel log is only available on Linux"
+	}
+	if !k.Available {
+		return fmt.Sprintf("Kernel Warnings:\n\nunavailable: %s", k.Reason)
+	}
+
+	text := fmt.Sprintf("Kernel Warnings (%s and above, source: %s):\n\n- Total: %d", k.Severity, k.Source, k.Total)
+	for _, severity := range KernelLogSeverities {
+		if count := k.Counts[severity]; count > 0 {
+			text += fmt.Sprintf("\n- %s: %d", severity, count)
+		}
+	}
+
+	if len(k.Entries) == 0 {
+		return text + "\n\nNo matching messages"
+	}
+
+	text += fmt.Sprintf("\n\nMost recent %d:", len(k.Entries))
+	for _, entry := range k.Entries {
+		text += fmt.Sprintf("\n[%s] %s: %s", entry.Timestamp, entry.Severity, entry.Message)
+	}
+
+	return text
+}
+
+// ClockSync статус синхронизации часов. Смещение и погрешность nil, если источник их не сообщает
+type ClockSync struct {
+	Status          string   `json:"status"`
+	Source          string   `json:"source,omitempty"`
+	Server          string   `json:"server,omitempty"`
+	Stratum         int      `json:"stratum,omitempty"`
+	OffsetSeconds   *float64 `json:"offset_seconds,omitempty"`
+	MaxErrorSeconds *float64 `json:"max_error_seconds,omitempty"`
+}
+
+// TimeInfo текущее время хоста, часовой пояс и статус синхронизации часов
+type TimeInfo struct {
+	Now       string    `json:"now"`
+	Timezone  string    `json:"timezone"`
+	ZoneAbbr  string    `json:"zone_abbr"`
+	UTCOffset string    `json:"utc_offset"`
+	Sync      ClockSync `json:"sync"`
+
+	// WallElapsedSeconds и MonotonicElapsedSeconds время с запуска сервера по настенным и монотонным часам,
+	// ClockStepSeconds их разница: на сколько настенные часы были переведены с запуска
+	WallElapsedSeconds      float64 `json:"wall_elapsed_seconds"`
+	MonotonicElapsedSeconds float64 `json:"monotonic_elapsed_seconds"`
+	ClockStepSeconds        float64 `json:"clock_step_seconds"`
+}
+
+// FormatText formats host time and clock synchronization as human-readable text
+func (t *TimeInfo) FormatText() string {
+	text := fmt.Sprintf("Time Information:\n\n- Now: %s\n- Timezone: %s (%s, UTC%s)",
+		t.Now, t.Timezone, t.ZoneAbbr, t.UTCOffset)
+
+	text += "\n\nClock Sync:"
+	switch t.Sync.Status {
+	case ClockSyncUnknown:
+		text += "\n- Status: sync status unknown (no NTP tooling or kernel clock state available)"
+	default:
+		text += fmt.Sprintf("\n- Status: %s (source: %s)", t.Sync.Status, t.Sync.Source)
+	}
+	if t.Sync.Server != "" {
+		text += fmt.Sprintf("\n- Server: %s (stratum %d)", t.Sync.Server, t.Sync.Stratum)
+	}
+	if t.Sync.OffsetSeconds != nil {
+		text += fmt.Sprintf("\n- Offset: %+.6f s", *t.Sync.OffsetSeconds)
+	}
+	if t.Sync.MaxErrorSeconds != nil {
+		text += fmt.Sprintf("\n- Max error: %.6f s", *t.Sync.MaxErrorSeconds)
+	}
+
+	text += fmt.Sprintf("\n\nServer Clocks Since Start:\n- Wall: %.3f s\n- Monotonic: %.3f s\n- Wall clock step: %+.3f s",
+		t.WallElapsedSeconds, t.MonotonicElapsedSeconds, t.ClockStepSeconds)
+
+	if t.Sync.Status == ClockUnsynchronized {
+		text += "\n\nWarning: clock is not synchronized, timestamps may drift"
+	}
+	if math.Abs(t.ClockStepSeconds) >= 1 {
+		text += "\n\nWarning: wall clock was stepped since server start, compare timestamps with care"
+	}
+
+	return text
+}
+
+// BlockPartition раздел диска, StartBytes - смещение от начала диска
+type BlockPartition struct {
+	Name        string   `json:"name"`
+	SizeBytes   uint64   `json:"size_bytes"`
+	StartBytes  uint64   `json:"start_bytes"`
+	Mountpoints []string `json:"mountpoints,omitempty"`
+}
+
+// BlockDevice физический диск (или device mapper, md) с разделами
+type BlockDevice struct {
+	Name       string `json:"name"`
+	SizeBytes  uint64 `json:"size_bytes"`
+	Model      string `json:"model,omitempty"`
+	Vendor     string `json:"vendor,omitempty"`
+	State      string `json:"state,omitempty"`
+	Rotational bool   `json:"rotational"`
+	Removable  bool   `json:"removable"`
+	ReadOnly   bool   `json:"readonly"`
+	// Mountpoints точки монтирования самого диска без таблицы разделов
+	Mountpoints []string         `json:"mountpoints,omitempty"`
+	Partitions  []BlockPartition `json:"partitions,omitempty"`
+}
+
+// BlockDevicesInfo блочные устройства хоста, Supported=false вне Linux
+type BlockDevicesInfo struct {
+	Supported bool          `json:"supported"`
+	Devices   []BlockDevice `json:"devices"`
+}
+
+// FormatText formats block devices and their partition layout as human-readable text
+func (b *BlockDevicesInfo) FormatText() string {
+	if !b.Supported {
+		return "Block Devices:\n\nBlock device information is only available on Linux"
+	}
+	if len(b.Devices) == 0 {
+		return "Block Devices:\n\nNo physical block devices found"
+	}
+
+	text := fmt.Sprintf("Block Devices (%d):", len(b.Devices))
+	var warnings []string
+	for _, d := range b.Devices {
+		kind := "SSD"
+		if d.Rotational {
+			kind = "HDD"
+		}
+		text += fmt.Sprintf("\n\n%s: %s, %s", d.Name, FormatBytes(int64(d.SizeBytes)), kind)
+		if model := strings.TrimSpace(d.Vendor + " " + d.Model); model != "" {
+			text += fmt.Sprintf(", %s", model)
+		}
+		if d.Removable {
+			text += ", removable"
+		}
+		if d.ReadOnly {
+			text += ", read-only"
+		}
+		if len(d.Mountpoints) > 0 {
+			text += fmt.Sprintf("\n- mounted at %s", strings.Join(d.Mountpoints, ", "))
+		}
+		for _, p := range d.Partitions {
+			text += fmt.Sprintf("\n- %s: %s at offset %s", p.Name, FormatBytes(int64(p.SizeBytes)), FormatBytes(int64(p.StartBytes)))
+			if len(p.Mountpoints) > 0 {
+				text += fmt.Sprintf(", mounted at %s", strings.Join(p.Mountpoints, ", "))
+			}
+		}
+
+		if d.SizeBytes == 0 && !d.Removable {
+			warnings = append(warnings, fmt.Sprintf("%s reports zero size (no medium or failing disk)", d.Name))
+		}
+		if d.State != "" && d.State != "running" && d.State != "live" {
+			warnings = append(warnings, fmt.Sprintf("%s is in state %q", d.Name, d.State))
+		}
+	}
+
+	for _, warning := range warnings {
+		text += "\n\nWarning: " + warning
+	}
+
+	return text
+}
+
+// Классификация тренда памяти
+const (
+	TrendStable  = "stable"
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+)
+
+// MemoryTrend наклон линейной регрессии используемой памяти за окно
+type MemoryTrend struct {
+	Window  time.Duration `json:"window_ns"`
+	Span    time.Duration `json:"span_ns"`
+	Samples int           `json:"samples"`
+	// SlopeMBPerMin изменение используемой памяти в MB в минуту по регрессии
+	SlopeMBPerMin float64 `json:"slope_mb_per_min"`
+	// RSquared доля изменений, объясняемая линейным трендом: близко к 1 - устойчивый рост или падение
+	RSquared       float64 `json:"r_squared"`
+	Classification string  `json:"classification"`
+	// Threshold наклон в MB/мин, до которого тренд считается стабильным
+	Threshold float64 `json:"threshold_mb_per_min"`
+	FirstUsed uint64  `json:"first_used"`
+	LastUsed  uint64  `json:"last_used"`
+}
+
+// FormatText formats the memory trend as human-readable text
+func (m *MemoryTrend) FormatText() string {
+	text := fmt.Sprintf("Memory Trend (last %v):\n\n- Trend: %s\n- Slope: %+.2f MB/min (%+.1f MB/hour)\n- Fit (R²): %.2f\n- Used: %s -> %s\n- Samples: %d over %v\n- Stable threshold: ±%.2f MB/min",
+		m.Window, m.Classification, m.SlopeMBPerMin, m.SlopeMBPerMin*60, m.RSquared,
+		FormatBytes(int64(m.FirstUsed)), FormatBytes(int64(m.LastUsed)), m.Samples, m.Span.Round(time.Second), m.Threshold)
+
+	if m.Classification == TrendRising && m.RSquared >= 0.8 {
+		text += "\n\nWarning: memory usage is rising steadily, a possible leak"
+	}
+
+	return text
+}
+
+// UsagePercentiles распределение загрузки CPU или используемой памяти (%) за окно
+type UsagePercentiles struct {
+	Metric  string        `json:"metric"`
+	Window  time.Duration `json:"window_ns"`
+	Samples int           `json:"samples"`
+	Min     float64       `json:"min"`
+	Max     float64       `json:"max"`
+	P50     float64       `json:"p50"`
+	P90     float64       `json:"p90"`
+	P99     float64       `json:"p99"`
+}
+
+// FormatText formats usage percentiles as human-readable text
+func (u *UsagePercentiles) FormatText() string {
+	name := "CPU Usage"
+	if u.Metric == PercentileMetricMemory {
+		name = "Memory Used"
+	}
+
+	return fmt.Sprintf("%s Percentiles (last %v, %d samples):\n\n- p50: %.2f%%\n- p90: %.2f%%\n- p99: %.2f%%\n- Min: %.2f%%\n- Max: %.2f%%",
+		name, u.Window, u.Samples, u.P50, u.P90, u.P99, u.Min, u.Max)
+}
+
+// CPUFeatures флаги возможностей и кеши процессора
+type CPUFeatures struct {
+	Platform  string            `json:"platform"`
+	Arch      string            `json:"arch"`
+	VendorID  string            `json:"vendor_id"`
+	ModelName string            `json:"model_name"`
+	Flags     []string          `json:"flags"`
+	Caches    []CPUCache        `json:"caches"`
+	Key       []CPUFeatureCheck `json:"key_features"`
+}
+
+// CPUCache один кеш процессора. Level=0, если уровень неизвестен
+type CPUCache struct {
+	Level     int    `json:"level"`
+	Type      string `json:"type"`
+	Size      uint64 `json:"size_bytes"`
+	SharedCPU string `json:"shared_cpu_list,omitempty"`
+}
+
+// CPUFeatureCheck наличие возможности, востребованной рабочими нагрузками
+type CPUFeatureCheck struct {
+	Name      string   `json:"name"`
+	Flags     []string `json:"flags"`
+	Supported bool     `json:"supported"`
+}
+
+// FormatText formats CPU caches and feature flags as human-readable text
+func (f *CPUFeatures) FormatText() string {
+	text := fmt.Sprintf("CPU Features (%s/%s):\n", f.Platform, f.Arch)
+	if f.ModelName != "" {
+		text += fmt.Sprintf("\n- Model: %s", TruncateModelName(f.ModelName))
+	}
+	if f.VendorID != "" {
+		text += fmt.Sprintf("\n- Vendor: %s", f.VendorID)
+	}
+
+	text += "\n\nCaches:"
+	if len(f.Caches) == 0 {
+		text += "\n- Not exposed on this platform"
+	}
+	for _, c := range f.Caches {
+		name := "Cache"
+		if c.Level > 0 {
+			name = fmt.Sprintf("L%d", c.Level)
+		}
+		if c.Type != "" && c.Type != "Unified" {
+			name += " " + strings.ToLower(c.Type)
+		}
+		text += fmt.Sprintf("\n- %s: %s", name, FormatBytes(int64(c.Size)))
+		if c.SharedCPU != "" {
+			text += fmt.Sprintf(" (shared by CPUs %s)", c.SharedCPU)
+		}
+	}
+
+	if len(f.Flags) == 0 {
+		text += fmt.Sprintf("\n\nFeature flags: not exposed on this platform (%s/%s)", f.Platform, f.Arch)
+		return text
+	}
+
+	if len(f.Key) > 0 {
+		text += "\n\nKey features:"
+		for _, check := range f.Key {
+			supported := "no"
+			if check.Supported {
+				supported = "yes"
+			}
+			text += fmt.Sprintf("\n- %s: %s", check.Name, supported)
+		}
+	}
+	text += fmt.Sprintf("\n\nFlags (%d):\n%s", len(f.Flags), strings.Join(f.Flags, " "))
+
+	return text
+}
+
+// OOMEvent процесс, убитый OOM killer
+type OOMEvent struct {
+	// KilledAt нулевое, если время загрузки неизвестно и метку kmsg не удалось привязать ко времени
+	KilledAt time.Time `json:"killed_at,omitempty"`
+	// Timestamp метка журнала ядра: секунды с загрузки для kmsg или RFC3339 для journalctl
+	Timestamp string `json:"timestamp"`
+	PID       int32  `json:"pid"`
+	Process   string `json:"process"`
+	// Scope system - закончилась память хоста, cgroup - превышен лимит cgroup (контейнера)
+	Scope   string `json:"scope"`
+	AnonRSS uint64 `json:"anon_rss_bytes,omitempty"`
+}
+
+// OOMEvents убийства OOM killer из журнала ядра за окно Lookback.
+// Available=false если ни один источник журнала не читается, причина в Reason
+type OOMEvents struct {
+	Supported bool          `json:"supported"`
+	Available bool          `json:"available"`
+	Source    string        `json:"source,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Lookback  time.Duration `json:"lookback"`
+	// WindowNotApplied время загрузки неизвестно, поэтому перечислены все убийства текущей загрузки
+	WindowNotApplied bool `json:"window_not_applied,omitempty"`
+	// Total число убийств в окне, Events только последние из них, Victims - число убийств по имени процесса
+	Total   int            `json:"total"`
+	Victims map[string]int `json:"victims,omitempty"`
+	Events  []OOMEvent     `json:"events"`
+}
+
+// FormatText formats OOM killer events as human-readable text
+func (o *OOMEvents) FormatText() string {
+	if !o.Supported {
+		return "OOM Killer Events:\n\nOOM killer events are only available on Linux"
+	}
+	if !o.Available {
+		return fmt.Sprintf("OOM Killer Events:\n\nunavailable: %s", o.Reason)
+	}
+
+	text := fmt.Sprintf("OOM Killer Events (last %s, source: %s):\n\n- Total: %d", formatStaleAge(o.Lookback), o.Source, o.Total)
+	if o.Total == 0 {
+		return text + "\n\nNo processes were OOM-killed in this window"
+	}
+
+	victims := make([]string, 0, len(o.Victims))
+	for name := range o.Victims {
+		victims = append(victims, name)
+	}
+	sort.Slice(victims, func(i, j int) bool {
+		if o.Victims[victims[i]] != o.Victims[victims[j]] {
+			return o.Victims[victims[i]] > o.Victims[victims[j]]
+		}
+		return victims[i] < victims[j]
+	})
+	for _, name := range victims {
+		text += fmt.Sprintf("\n- %s: %d", name, o.Victims[name])
+	}
+
+	text += fmt.Sprintf("\n\nMost recent %d:", len(o.Events))
+	for _, event := range o.Events {
+		at := event.Timestamp + "s since boot"
+		if !event.KilledAt.IsZero() {
+			at = event.KilledAt.Format(time.RFC3339)
+		}
+		text += fmt.Sprintf("\n[%s] %s (pid %d), %s", at, event.Process, event.PID, event.Scope)
+		if event.AnonRSS > 0 {
+			text += ", anon-rss " + FormatBytes(int64(event.AnonRSS))
+		}
+	}
+
+	if o.WindowNotApplied {
+		text += "\n\nWarning: boot time is unknown, so kernel timestamps could not be converted and the lookback window was not applied"
+	}
+	// Оба источника читают только текущую загрузку, kmsg к тому же ограничен размером буфера
+	text += "\n\nNote: only the current boot is covered"
+	if o.Source == "kmsg" {
+		text += ", and the kernel ring buffer may have dropped older messages"
+	}
+
+	return text
+}
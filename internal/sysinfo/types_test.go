@@ -0,0 +1,102 @@
+package sysinfo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// largeSnapshot возвращает заполненный снимок для бенчмарков форматирования
+func largeSnapshot() *SystemInfo {
+	return &SystemInfo{
+		CPU: CPUInfo{
+			Count:        256,
+			ModelName:    strings.Repeat("Virtual CPU Model ", 16),
+			UsagePercent: 87.65,
+		},
+		Memory: MemoryInfo{
+			Total:       2 << 40,
+			Available:   1 << 40,
+			Used:        1 << 40,
+			UsedPercent: 50,
+		},
+	}
+}
+
+func BenchmarkFormatText(b *testing.B) {
+	info := largeSnapshot()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = info.FormatText()
+	}
+}
+
+func BenchmarkFDInfoFormatText(b *testing.B) {
+	info := &FDInfo{
+		Platform:        "linux",
+		Supported:       true,
+		OpenFDs:         65000,
+		SoftLimit:       65536,
+		HardLimit:       1048576,
+		SystemAvailable: true,
+		SystemAllocated: 1 << 20,
+		SystemMax:       1 << 24,
+	}
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = info.FormatText()
+	}
+}
+
+func TestMountUsageFormatTextFlagsHighInodes(t *testing.T) {
+	info := &MountUsageInfo{Mounts: []MountUsage{
+		{Mountpoint: "/", Total: 100, Used: 10, UsedPercent: 10, Inodes: &InodeUsage{Total: 1000, Used: 950, Free: 50, UsedPercent: 95, High: true}},
+		{Mountpoint: "/boot", Total: 100, Used: 50, UsedPercent: 50, Inodes: &InodeUsage{Total: 1000, Used: 10, Free: 990, UsedPercent: 1}},
+	}}
+
+	text := info.FormatText()
+	if !strings.Contains(text, "inodes nearly exhausted on / (95.0% inodes, 10.0% bytes)") {
+		t.Fatalf("missing inode warning for /:\n%s", text)
+	}
+	if strings.Contains(text, "/boot (1.0% inodes") {
+		t.Fatalf("unexpected inode warning for /boot:\n%s", text)
+	}
+}
+
+func TestFormatTextTruncatesLongModelName(t *testing.T) {
+	info := largeSnapshot()
+	model := info.CPU.ModelName
+
+	text := info.FormatText()
+	want := "- Model: " + model[:modelNameMaxLength-3] + "...\n"
+	if !strings.Contains(text, want) {
+		t.Fatalf("FormatText() has no truncated model %q:\n%s", want, text)
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), model) {
+		t.Fatal("JSON output must keep the full model name")
+	}
+}
+
+func TestTruncateModelName(t *testing.T) {
+	defer SetModelNameMaxLength(modelNameMaxLength)
+	SetModelNameMaxLength(8)
+
+	tests := map[string]string{
+		"Short":          "Short",
+		"ExactlyE":       "ExactlyE",
+		"Much longer":    "Much ...",
+		"Процессор Xeon": "Проце...",
+	}
+	for name, want := range tests {
+		if got := TruncateModelName(name); got != want {
+			t.Errorf("TruncateModelName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package sysinfo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// GetZombies возвращает процессы в состоянии zombie (defunct) с их родителями.
+// Процессы, статус которых не читается (права, завершились), пропускаются и учитываются в Unreadable
+func GetZombies(ctx context.Context) (*ZombiesInfo, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		logger.SysInfo.Error().
+			Err(err).
+			Msg("Failed to list processes")
+		return nil, fmt.Errorf("failed to list processes: %v", err)
+	}
+
+	info := &ZombiesInfo{Zombies: []ZombieProcess{}}
+	names := make(map[int32]string)
+	for _, p := range procs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		status, err := p.StatusWithContext(ctx)
+		if err != nil {
+			info.Unreadable++
+			continue
+		}
+		info.Scanned++
+		if !slices.Contains(status, process.Zombie) {
+			continue
+		}
+
+		zombie := ZombieProcess{PID: p.Pid}
+		zombie.Name, _ = p.NameWithContext(ctx)
+		if ppid, err := p.PpidWithContext(ctx); err == nil {
+			zombie.PPID = ppid
+			zombie.ParentName = processName(ctx, names, ppid)
+		}
+		info.Zombies = append(info.Zombies, zombie)
+	}
+
+	sort.Slice(info.Zombies, func(i, j int) bool {
+		if info.Zombies[i].PPID != info.Zombies[j].PPID {
+			return info.Zombies[i].PPID < info.Zombies[j].PPID
+		}
+		return info.Zombies[i].PID < info.Zombies[j].PID
+	})
+
+	info.Total = len(info.Zombies)
+
+	logger.SysInfo.Debug().
+		Int("zombies", len(info.Zombies)).
+		Int("scanned", info.Scanned).
+		Int("unreadable", info.Unreadable).
+		Msg("Got zombie processes")
+
+	return info, nil
+}
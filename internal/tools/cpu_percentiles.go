@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultPercentilesMinutes окно cpu_percentiles по умолчанию
+const defaultPercentilesMinutes = 15
+
+// CPUPercentilesTool описание инструмента cpu_percentiles
+func CPUPercentilesTool() mcp.Tool {
+	return mcp.NewTool("cpu_percentiles",
+		mcp.WithDescription("Returns p50/p90/p99, min and max of CPU usage (or memory used) over the last N minutes from the server's background metric history. Characterizes sustained load for capacity planning better than a single reading. Fails until the history covers the requested window"),
+		mcp.WithNumber("minutes",
+			mcp.Description(fmt.Sprintf("Window in minutes, at most the server's METRIC_HISTORY_WINDOW (default: %d)", defaultPercentilesMinutes)),
+		),
+		mcp.WithString("metric",
+			mcp.Description("Metric to summarize: cpu (usage %) or memory (used %) (default: cpu)"),
+			mcp.Enum(sysinfo.PercentileMetricCPU, sysinfo.PercentileMetricMemory),
+		),
+	)
+}
+
+// CPUPercentilesHandler возвращает перцентили загрузки по фоновой истории
+func CPUPercentilesHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	minutes := request.GetFloat("minutes", defaultPercentilesMinutes)
+	if minutes <= 0 {
+		return mcp.NewToolResultError("minutes must be positive"), nil
+	}
+	metric := request.GetString("metric", sysinfo.PercentileMetricCPU)
+	if metric != sysinfo.PercentileMetricCPU && metric != sysinfo.PercentileMetricMemory {
+		return mcp.NewToolResultError(fmt.Sprintf("metric must be %s or %s", sysinfo.PercentileMetricCPU, sysinfo.PercentileMetricMemory)), nil
+	}
+	window := time.Duration(minutes * float64(time.Minute))
+
+	logger.Tools.Debug().
+		Str("metric", metric).
+		Dur("window", window).
+		Msg("Getting usage percentiles")
+
+	percentiles, err := sysinfo.GetUsagePercentiles(metric, window)
+	if err != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Msg("Usage percentiles unavailable")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(percentiles.FormatText()), nil
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxCustomToolOutput ограничивает объем вывода команды, хранимого в памяти и возвращаемого клиенту
+const maxCustomToolOutput = 64 * 1024
+
+// customToolWaitDelay сколько ждать закрытия вывода после завершения команды по таймауту: потомок,
+// унаследовавший stdout, иначе держал бы вызов до своего завершения
+const customToolWaitDelay = time.Second
+
+// customToolEnv окружение команд пользовательских инструментов: окружение сервера (с API ключом) не передается
+var customToolEnv = []string{"PATH=/usr/sbin:/usr/bin:/sbin:/bin", "LC_ALL=C"}
+
+// CustomToolDefinition описание пользовательского инструмента из конфигурации
+func CustomToolDefinition(spec config.CustomTool) mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(spec.Description),
+	}
+	for _, param := range spec.Params {
+		paramOptions := []mcp.PropertyOption{
+			mcp.Description(param.Description),
+			mcp.Pattern(config.AnchoredPattern(param.Pattern)),
+		}
+		if param.Required {
+			paramOptions = append(paramOptions, mcp.Required())
+		}
+		options = append(options, mcp.WithString(param.Name, paramOptions...))
+	}
+
+	return mcp.NewTool(spec.Name, options...)
+}
+
+// NewCustomToolHandler создает обработчик, запускающий команду spec напрямую (без shell) с ограничением timeout.
+// Значения параметров проверяются по их паттернам и подставляются только внутрь фиксированных шаблонов аргументов
+func NewCustomToolHandler(spec config.CustomTool, timeout time.Duration) server.ToolHandlerFunc {
+	patterns := make(map[string]*regexp.Regexp, len(spec.Params))
+	for _, param := range spec.Params {
+		patterns[param.Name] = regexp.MustCompile(config.AnchoredPattern(param.Pattern))
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		values := make(map[string]string, len(spec.Params))
+		for _, param := range spec.Params {
+			value := request.GetString(param.Name, "")
+			if value == "" {
+				if param.Required {
+					return mcp.NewToolResultError(fmt.Sprintf("Missing required parameter %q", param.Name)), nil
+				}
+				continue
+			}
+			// Значение, начинающееся с '-', было бы принято командой за флаг
+			if strings.HasPrefix(value, "-") || !patterns[param.Name].MatchString(value) {
+				logger.Tools.Warn().
+					Str("tool", spec.Name).
+					Str("param", param.Name).
+					Msg("Rejected custom tool parameter value")
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid value for parameter %q: must match %s and must not start with '-'", param.Name, param.Pattern)), nil
+			}
+			values[param.Name] = value
+		}
+
+		args := buildCustomToolArgs(spec.Args, values)
+
+		runCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(runCtx, spec.Command, args...)
+		cmd.Env = customToolEnv
+		cmd.WaitDelay = customToolWaitDelay
+		killProcessGroupOnCancel(cmd)
+		output := &cappedBuffer{limit: maxCustomToolOutput}
+		cmd.Stdout = output
+		cmd.Stderr = output
+
+		start := time.Now()
+		err := cmd.Run()
+		duration := time.Since(start)
+
+		text := output.buf.String()
+		if output.total > maxCustomToolOutput {
+			text += "\n... (output truncated)"
+		}
+
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			logger.Tools.Error().
+				Str("tool", spec.Name).
+				Dur("timeout", timeout).
+				Msg("Custom tool command timed out")
+			return mcp.NewToolResultError(fmt.Sprintf("Command timed out after %v", timeout)), nil
+		}
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Str("tool", spec.Name).
+				Str("command", spec.Command).
+				Dur("duration", duration).
+				Msg("Custom tool command failed")
+			return mcp.NewToolResultError(fmt.Sprintf("Command failed: %v\n%s", err, text)), nil
+		}
+
+		logger.Tools.Info().
+			Str("tool", spec.Name).
+			Str("command", spec.Command).
+			Dur("duration", duration).
+			Int64("output_bytes", output.total).
+			Msg("Custom tool command completed")
+
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+// cappedBuffer хранит не больше limit байт вывода, остальное только подсчитывается.
+// Запись всегда успешна, чтобы команда не завершилась с ошибкой записи из-за лимита
+type cappedBuffer struct {
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	limit int
+	total int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total += int64(len(p))
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+// buildCustomToolArgs подставляет значения параметров в шаблоны аргументов.
+// Аргумент, ссылающийся на незаданный необязательный параметр, пропускается целиком
+func buildCustomToolArgs(templates []string, values map[string]string) []string {
+	args := make([]string, 0, len(templates))
+	for _, template := range templates {
+		complete := true
+		arg := config.CustomArgPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+			value, ok := values[placeholder[1:len(placeholder)-1]]
+			complete = complete && ok
+			return value
+		})
+		if complete {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
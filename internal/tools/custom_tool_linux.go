@@ -0,0 +1,17 @@
+//go:build linux
+
+package tools
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroupOnCancel запускает команду в отдельной группе процессов и по таймауту убивает
+// всю группу, а не только саму команду: иначе порожденные ею процессы продолжают работать
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
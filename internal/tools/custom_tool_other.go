@@ -0,0 +1,9 @@
+//go:build !linux
+
+package tools
+
+import "os/exec"
+
+// killProcessGroupOnCancel группы процессов используются только на Linux, на других платформах
+// по таймауту завершается сама команда, а WaitDelay ограничивает ожидание ее вывода
+func killProcessGroupOnCancel(_ *exec.Cmd) {}
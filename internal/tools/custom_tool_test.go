@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-system-info/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCustomToolPatternMatchesWholeValue(t *testing.T) {
+	spec := config.CustomTool{
+		Name:    "echo_unit",
+		Command: "/bin/echo",
+		Args:    []string{"{unit}"},
+		Params:  []config.CustomToolParam{{Name: "unit", Required: true, Pattern: "[a-z]+"}},
+	}
+	handler := NewCustomToolHandler(spec, time.Second)
+
+	call := func(value string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: spec.Name, Arguments: map[string]interface{}{"unit": value}}}
+		result, err := handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("handler(%q): %v", value, err)
+		}
+		return result
+	}
+
+	for _, value := range []string{"abc;rm -rf x", "nginx.service", "ABC"} {
+		if result := call(value); !result.IsError {
+			t.Errorf("value %q accepted by pattern [a-z]+", value)
+		}
+	}
+	if result := call("nginx"); result.IsError {
+		t.Errorf("value %q rejected: %+v", "nginx", result.Content)
+	}
+}
+
+func TestCustomToolTimeoutWithBackgroundChild(t *testing.T) {
+	// Фоновый sleep наследует stdout и держал бы вызов до своего завершения
+	spec := config.CustomTool{Name: "hang", Command: "/bin/sh", Args: []string{"-c", "sleep 30 & sleep 30"}}
+	handler := NewCustomToolHandler(spec, 200*time.Millisecond)
+
+	start := time.Now()
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("handler returned after %v, want about timeout plus wait delay", elapsed)
+	}
+	if !result.IsError {
+		t.Errorf("result = %+v, want timeout error", result.Content)
+	}
+}
+
+func TestCustomToolOutputIsCapped(t *testing.T) {
+	spec := config.CustomTool{Name: "chatty", Command: "/bin/sh", Args: []string{"-c", "head -c 1000000 /dev/zero"}}
+	result, err := NewCustomToolHandler(spec, 5*time.Second)(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if len(text) > maxCustomToolOutput+100 || !strings.HasSuffix(text, "(output truncated)") {
+		t.Errorf("output length = %d, want capped at %d with truncation note", len(text), maxCustomToolOutput)
+	}
+}
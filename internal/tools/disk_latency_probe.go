@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DiskLatencyProbeTool описание инструмента disk_latency_probe
+func DiskLatencyProbeTool() mcp.Tool {
+	return mcp.NewTool("disk_latency_probe",
+		mcp.WithDescription("Measures disk write (with fsync) and read latency by writing and reading back a small temporary file in the server's configured probe directory, then deleting it"),
+	)
+}
+
+// NewDiskLatencyProbeHandler создает обработчик, пишущий пробный файл размером size байт только в dir
+func NewDiskLatencyProbeHandler(dir string, size int) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.Tools.Info().
+			Str("dir", dir).
+			Int("size_bytes", size).
+			Msg("Starting disk latency probe")
+
+		probe, err := sysinfo.ProbeDiskLatency(ctx, dir, size)
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Str("dir", dir).
+				Msg("Disk latency probe failed")
+			return mcp.NewToolResultError(fmt.Sprintf("Error probing disk latency: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(probe.FormatText()), nil
+	}
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultDiskScanLimit количество записей в результате по умолчанию
+	defaultDiskScanLimit = 10
+	// maxDiskScanLimit верхняя граница аргумента limit
+	maxDiskScanLimit = 100
+)
+
+// DiskUsageScanTool описание инструмента disk_usage_scan
+func DiskUsageScanTool() mcp.Tool {
+	return mcp.NewTool("disk_usage_scan",
+		mcp.WithDescription("Reports the largest immediate subdirectories/files under a path (like sorted `du -sh *`). Only paths under the server's configured DISK_SCAN_ROOTS can be scanned. "+paginationContract),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description("Directory to scan; must be inside one of the allowed roots"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of entries per page, largest first (default: %d, max: %d)", defaultDiskScanLimit, maxDiskScanLimit)),
+		),
+		withOffset(),
+	)
+}
+
+// NewDiskUsageScanHandler создает обработчик, сканирующий только внутри roots с ограничением глубины и времени
+func NewDiskUsageScanHandler(roots []string, maxDepth int, timeout time.Duration) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		page, err := ParsePage(request, defaultDiskScanLimit, maxDiskScanLimit)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		resolved, err := resolveScanPath(roots, path)
+		if err != nil {
+			logger.Tools.Warn().
+				Err(err).
+				Str("path", path).
+				Msg("Disk usage scan rejected")
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		logger.Tools.Info().
+			Str("path", resolved).
+			Int("offset", page.Offset).
+			Int("limit", page.Limit).
+			Int("max_depth", maxDepth).
+			Dur("timeout", timeout).
+			Msg("Starting disk usage scan")
+
+		scanCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		usage, err := sysinfo.ScanDiskUsage(scanCtx, resolved, sysinfo.DiskScanOptions{Limit: page.End(), MaxDepth: maxDepth})
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Str("path", resolved).
+				Msg("Failed to scan disk usage")
+			return mcp.NewToolResultError(fmt.Sprintf("Error scanning %s: %v", path, err)), nil
+		}
+
+		usage.Entries = PageItems(usage.Entries, page)
+		return mcp.NewToolResultText(usage.FormatText() + page.Footer(usage.Scanned)), nil
+	}
+}
+
+// resolveScanPath приводит путь к абсолютному без символических ссылок и проверяет, что он внутри одного из roots
+func resolveScanPath(roots []string, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path %q must be absolute", path)
+	}
+
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("cannot access path %q: %v", path, err)
+	}
+
+	for _, root := range roots {
+		resolvedRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			continue
+		}
+
+		rel, err := filepath.Rel(resolvedRoot, resolved)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+
+	return "", fmt.Errorf("path %s is outside the allowed scan roots: %s", path, strings.Join(roots, ", "))
+}
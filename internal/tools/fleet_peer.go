@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxFleetResponseBytes ограничивает размер ответа соседнего сервера
+const maxFleetResponseBytes = 1 << 20
+
+// fleetPeerClient минимальный клиент MCP Streamable HTTP для опроса соседних серверов
+type fleetPeerClient struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+// rpcResponse JSON-RPC ответ соседнего сервера
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// fetchSystemInfo открывает сессию на соседнем сервере, вызывает get_system_info и закрывает сессию
+func (c *fleetPeerClient) fetchSystemInfo(ctx context.Context, peerURL string) (string, error) {
+	_, sessionID, err := c.post(ctx, peerURL, "", map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]interface{}{
+			"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+			"capabilities":    map[string]interface{}{},
+			"clientInfo":      map[string]interface{}{"name": "mcp-system-info-fleet", "version": "1.0.0"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("initialize: %w", err)
+	}
+	if sessionID == "" {
+		return "", errors.New("initialize: peer did not return Mcp-Session-Id")
+	}
+	defer c.deleteSession(peerURL, sessionID)
+
+	// Сосед может требовать notifications/initialized перед tools/call (REQUIRE_INITIALIZED)
+	if _, _, err := c.post(ctx, peerURL, sessionID, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "notifications/initialized",
+	}); err != nil {
+		return "", fmt.Errorf("initialized: %w", err)
+	}
+
+	response, _, err := c.post(ctx, peerURL, sessionID, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      2,
+		"method":  "tools/call",
+		"params": map[string]interface{}{
+			"name":      "get_system_info",
+			"arguments": map[string]interface{}{"random_string": "fleet"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("tools/call: %w", err)
+	}
+
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return "", fmt.Errorf("tools/call: invalid result: %w", err)
+	}
+	var text string
+	for _, content := range result.Content {
+		if content.Type == "text" {
+			text += content.Text
+		}
+	}
+	if result.IsError {
+		return "", fmt.Errorf("get_system_info failed: %s", text)
+	}
+
+	return text, nil
+}
+
+// post отправляет JSON-RPC сообщение и возвращает ответ (nil для 202/204) и Mcp-Session-Id из заголовков
+func (c *fleetPeerClient) post(ctx context.Context, peerURL, sessionID string, message map[string]interface{}) (*rpcResponse, string, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peerURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusAccepted {
+		return nil, resp.Header.Get("Mcp-Session-Id"), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var response rpcResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxFleetResponseBytes)).Decode(&response); err != nil {
+		return nil, "", fmt.Errorf("invalid JSON-RPC response: %w", err)
+	}
+	if response.Error != nil {
+		return nil, "", fmt.Errorf("JSON-RPC error %d: %s", response.Error.Code, response.Error.Message)
+	}
+
+	return &response, resp.Header.Get("Mcp-Session-Id"), nil
+}
+
+// deleteSession закрывает сессию на соседнем сервере, чтобы опрос не оставлял сессии до их таймаута.
+// Ошибка не важна для результата опроса
+func (c *fleetPeerClient) deleteSession(peerURL, sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, peerURL, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("Mcp-Session-Id", sessionID)
+
+	if resp, err := c.httpClient.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetBlockDevicesTool описание инструмента get_block_devices
+func GetBlockDevicesTool() mcp.Tool {
+	return mcp.NewTool("get_block_devices",
+		mcp.WithDescription("Lists physical block devices on Linux (from /sys/block): name, size, model, HDD vs SSD, removable/read-only flags and partition layout, with filesystem mountpoints mapped onto their backing devices. Useful for inventory and spotting a missing, unexpectedly small or offline disk"),
+	)
+}
+
+// GetBlockDevicesHandler возвращает блочные устройства и их разделы
+func GetBlockDevicesHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting block devices")
+
+	info, err := sysinfo.GetBlockDevices(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get block devices")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting block devices: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(info.FormatText()), nil
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetCapabilitiesTool описание инструмента get_capabilities
+func GetCapabilitiesTool() mcp.Tool {
+	return mcp.NewTool("get_capabilities",
+		mcp.WithDescription("Reports which subsystems (cpu, memory, swap, disk, network, temperature, gpu, load) can be collected on this host, as booleans. Probed once and cached; use it to skip tools that would return empty results"),
+	)
+}
+
+// GetCapabilitiesHandler возвращает доступность подсистем хоста
+func GetCapabilitiesHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting host capabilities")
+
+	return mcp.NewToolResultText(sysinfo.GetCapabilities(ctx).FormatText()), nil
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetConnectionStatsTool описание инструмента get_connection_stats
+func GetConnectionStatsTool() mcp.Tool {
+	return mcp.NewTool("get_connection_stats",
+		mcp.WithDescription("Gets TCP connection counts grouped by state (ESTABLISHED, TIME_WAIT, CLOSE_WAIT, LISTEN...). High TIME_WAIT or CLOSE_WAIT counts help diagnose connection leaks"),
+		mcp.WithBoolean("include_ipv4",
+			mcp.Description("Include IPv4 connections (default: true)"),
+		),
+		mcp.WithBoolean("include_ipv6",
+			mcp.Description("Include IPv6 connections (default: true)"),
+		),
+		mcp.WithBoolean("split_by_family",
+			mcp.Description("Additionally report state counts separately for IPv4 and IPv6 (default: false)"),
+		),
+	)
+}
+
+// GetConnectionStatsHandler возвращает количество TCP соединений по состояниям
+func GetConnectionStatsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := sysinfo.ConnectionStatsOptions{
+		IncludeIPv4:   request.GetBool("include_ipv4", true),
+		IncludeIPv6:   request.GetBool("include_ipv6", true),
+		SplitByFamily: request.GetBool("split_by_family", false),
+	}
+	if !opts.IncludeIPv4 && !opts.IncludeIPv6 {
+		return mcp.NewToolResultError("At least one of include_ipv4 or include_ipv6 must be true"), nil
+	}
+
+	logger.Tools.Debug().
+		Bool("include_ipv4", opts.IncludeIPv4).
+		Bool("include_ipv6", opts.IncludeIPv6).
+		Bool("split_by_family", opts.SplitByFamily).
+		Msg("Getting TCP connection stats")
+
+	stats, err := sysinfo.GetConnectionStats(ctx, opts)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get TCP connection stats")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting TCP connection stats: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(stats.FormatText()), nil
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetConntrackInfoTool описание инструмента get_conntrack_info
+func GetConntrackInfoTool() mcp.Tool {
+	return mcp.NewTool("get_conntrack_info",
+		mcp.WithDescription("Gets system-wide connection limits on Linux: nf_conntrack entries vs nf_conntrack_max and the ephemeral port range with an estimate of ports in use. Sections are reported as unavailable when the kernel doesn't expose them"),
+	)
+}
+
+// GetConntrackInfoHandler возвращает заполненность conntrack и эфемерных портов
+func GetConntrackInfoHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting conntrack information")
+
+	info, err := sysinfo.GetConntrackInfo(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get conntrack information")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting conntrack information: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(info.FormatText()), nil
+}
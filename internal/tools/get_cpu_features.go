@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetCPUFeaturesTool описание инструмента get_cpu_features
+func GetCPUFeaturesTool() mcp.Tool {
+	return mcp.NewTool("get_cpu_features",
+		mcp.WithDescription("Gets static CPU capabilities for compatibility checks: L1/L2/L3 cache sizes and the feature flags supported by every CPU (e.g. avx2, aes), with a yes/no summary of commonly required features such as AVX2 and AES-NI. Does not sample CPU usage. Platforms that do not expose flags or cache topology return what is available with a note"),
+	)
+}
+
+// GetCPUFeaturesHandler возвращает кеши и флаги возможностей процессора
+func GetCPUFeaturesHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting CPU features")
+
+	features, err := sysinfo.GetCPUFeatures(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get CPU features")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting CPU features: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(features.FormatText()), nil
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetCPUInfoTool описание инструмента get_cpu_info
+func GetCPUInfoTool() mcp.Tool {
+	return mcp.NewTool("get_cpu_info",
+		mcp.WithDescription("Gets static CPU facts (model, physical/logical cores, base MHz, cache size, per-socket details) without sampling CPU usage. Fast and cached"),
+	)
+}
+
+// GetCPUInfoHandler возвращает статическую информацию о процессоре
+func GetCPUInfoHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting static CPU information")
+
+	cpuInfo, err := sysinfo.GetStaticCPUInfo(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get CPU information")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting CPU information: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(cpuInfo.FormatText()), nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCPUTimesInterval ограничивает время блокировки вызова замером
+const maxCPUTimesInterval = 10 * time.Second
+
+// GetCPUTimesTool описание инструмента get_cpu_times
+func GetCPUTimesTool() mcp.Tool {
+	return mcp.NewTool("get_cpu_times",
+		mcp.WithDescription("Gets the CPU time breakdown in percent (user, system, idle and, where the platform reports them, nice, iowait, irq, softirq, steal) over a short sampling interval. High iowait points to storage, high user to application load. The call blocks for the sampling interval"),
+		mcp.WithString("interval",
+			mcp.Description("Sampling interval between the two counter reads (e.g., '1s'), max 10s"),
+		),
+		mcp.WithBoolean("per_core",
+			mcp.Description("Also report the breakdown for each core (default: false)"),
+		),
+	)
+}
+
+// GetCPUTimesHandler возвращает распределение времени CPU по состояниям
+func GetCPUTimesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	intervalStr := request.GetString("interval", "1s")
+	perCore := request.GetBool("per_core", false)
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval <= 0 || interval > maxCPUTimesInterval {
+		logger.Tools.Error().
+			Err(err).
+			Str("interval", intervalStr).
+			Msg("Invalid CPU times sampling interval")
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid interval %q: must be a positive duration up to %v", intervalStr, maxCPUTimesInterval)), nil
+	}
+
+	logger.Tools.Debug().
+		Dur("interval", interval).
+		Bool("per_core", perCore).
+		Msg("Getting CPU times")
+
+	info, err := sysinfo.GetCPUTimes(ctx, interval, perCore)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get CPU times")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting CPU times: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(info.FormatText()), nil
+}
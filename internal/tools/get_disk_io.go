@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxDiskIOInterval ограничивает время блокировки вызова замером
+const maxDiskIOInterval = 10 * time.Second
+
+// GetDiskIOTool описание инструмента get_disk_io
+func GetDiskIOTool() mcp.Tool {
+	return mcp.NewTool("get_disk_io",
+		mcp.WithDescription("Gets iostat-like per-device disk metrics (IOPS, throughput, average I/O wait, utilization) computed from two samples over a short interval"),
+		mcp.WithString("interval",
+			mcp.Description("Sampling interval between the two counter reads (e.g., '1s'), max 10s"),
+		),
+	)
+}
+
+// GetDiskIOHandler возвращает метрики дискового ввода-вывода
+func GetDiskIOHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	intervalStr := request.GetString("interval", "1s")
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval <= 0 || interval > maxDiskIOInterval {
+		logger.Tools.Error().
+			Err(err).
+			Str("interval", intervalStr).
+			Msg("Invalid disk I/O sampling interval")
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid interval %q: must be a positive duration up to %v", intervalStr, maxDiskIOInterval)), nil
+	}
+
+	logger.Tools.Debug().
+		Dur("interval", interval).
+		Msg("Getting disk I/O statistics")
+
+	diskIO, err := sysinfo.GetDiskIO(ctx, interval)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get disk I/O statistics")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting disk I/O statistics: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(diskIO.FormatText()), nil
+}
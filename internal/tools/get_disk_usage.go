@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetDiskUsageTool описание инструмента get_disk_usage
+func GetDiskUsageTool() mcp.Tool {
+	return mcp.NewTool("get_disk_usage",
+		mcp.WithDescription("Gets filesystem usage (total, used, free) per mount point, plus inode usage on Unix with a warning when inodes are nearly exhausted. Defaults to all physical mounts, or the mounts configured by the server"),
+		mcp.WithString("mounts",
+			mcp.Description("Optional comma-separated list of mount points to report (e.g., '/,/var'). Each must be an existing mount point"),
+		),
+		mcp.WithBoolean("fresh",
+			mcp.Description("Re-read filesystem usage instead of serving a result cached by the server for a few seconds (default: false)"),
+		),
+	)
+}
+
+// NewGetDiskUsageHandler создает обработчик get_disk_usage. Непустой allowed ограничивает набор
+// точек монтирования: он используется по умолчанию, а аргумент mounts может только сузить его
+func NewGetDiskUsageHandler(allowed []string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		mounts := allowed
+
+		if value := request.GetString("mounts", ""); value != "" {
+			mounts = nil
+			for _, mount := range strings.Split(value, ",") {
+				mount = strings.TrimSpace(mount)
+				if mount == "" {
+					continue
+				}
+				if !filepath.IsAbs(mount) {
+					return mcp.NewToolResultError(fmt.Sprintf("Mount %q must be an absolute path", mount)), nil
+				}
+				mount = filepath.Clean(mount)
+				if len(allowed) > 0 && !slices.Contains(allowed, mount) {
+					return mcp.NewToolResultError(fmt.Sprintf("Mount %q is not in the configured DISK_MOUNTS (%s)", mount, strings.Join(allowed, ", "))), nil
+				}
+				mounts = append(mounts, mount)
+			}
+		}
+
+		fresh := request.GetBool("fresh", false)
+
+		logger.Tools.Debug().
+			Strs("mounts", mounts).
+			Bool("fresh", fresh).
+			Msg("Getting disk usage")
+
+		usage, err := sysinfo.GetCachedMountUsage(ctx, mounts, fresh)
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Strs("mounts", mounts).
+				Msg("Failed to get disk usage")
+			if errors.Is(err, sysinfo.ErrUnknownMount) {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid mounts: %v", err)), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Error getting disk usage: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(usage.FormatText()), nil
+	}
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetEntropyInfoTool описание инструмента get_entropy_info
+func GetEntropyInfoTool() mcp.Tool {
+	return mcp.NewTool("get_entropy_info",
+		mcp.WithDescription("Gets available kernel entropy on Linux (entropy_avail vs poolsize) with a low-entropy warning. Useful when diagnosing stalls in TLS handshakes or key generation. Reported as unavailable on other platforms"),
+	)
+}
+
+// GetEntropyInfoHandler возвращает доступную энтропию ядра
+func GetEntropyInfoHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting entropy information")
+
+	return mcp.NewToolResultText(sysinfo.GetEntropyInfo().FormatText()), nil
+}
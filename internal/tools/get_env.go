@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// redactedValue подставляется вместо скрытых значений
+const redactedValue = "[REDACTED]"
+
+// apiKeyEnvVar переменная с API ключом сервера, скрывается всегда
+const apiKeyEnvVar = "MCP_API_KEY"
+
+// GetEnvTool описание инструмента get_env
+func GetEnvTool() mcp.Tool {
+	return mcp.NewTool("get_env",
+		mcp.WithDescription("Gets the server process environment variables. Values of variables whose names look sensitive (KEY, TOKEN, SECRET, PASSWORD...) are redacted"),
+		mcp.WithString("prefix",
+			mcp.Description("Optional variable name prefix filter (e.g., 'SESSION_')"),
+		),
+	)
+}
+
+// NewGetEnvHandler создает обработчик get_env, скрывающий значения переменных по redactPattern
+// и любые значения, совпадающие с apiKey
+func NewGetEnvHandler(redactPattern *regexp.Regexp, apiKey string) server.ToolHandlerFunc {
+	return func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		prefix := request.GetString("prefix", "")
+
+		var lines []string
+		redacted := 0
+		for _, entry := range os.Environ() {
+			name, value, _ := strings.Cut(entry, "=")
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+
+			if name == apiKeyEnvVar || redactPattern.MatchString(name) || (apiKey != "" && value == apiKey) {
+				value = redactedValue
+				redacted++
+			}
+			lines = append(lines, name+"="+value)
+		}
+		sort.Strings(lines)
+
+		logger.Tools.Info().
+			Str("prefix", prefix).
+			Int("variables", len(lines)).
+			Int("redacted", redacted).
+			Msg("Environment listed")
+
+		text := fmt.Sprintf("Environment (%d variables, %d redacted):\n\n%s", len(lines), redacted, strings.Join(lines, "\n"))
+		return mcp.NewToolResultText(text), nil
+	}
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetFDInfoTool описание инструмента get_fd_info
+func GetFDInfoTool() mcp.Tool {
+	return mcp.NewTool("get_fd_info",
+		mcp.WithDescription("Gets open file descriptor count and RLIMIT_NOFILE limits of the server process, plus system-wide usage when available"),
+	)
+}
+
+// GetFDInfoHandler возвращает информацию о файловых дескрипторах
+func GetFDInfoHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting file descriptor information")
+
+	fdInfo, err := sysinfo.GetFDInfo()
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get file descriptor information")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting file descriptor information: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fdInfo.FormatText()), nil
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetFleetInfoTool описание инструмента get_fleet_info
+func GetFleetInfoTool() mcp.Tool {
+	return mcp.NewTool("get_fleet_info",
+		mcp.WithDescription("Gets a fleet overview: system information (CPU and memory) of this host and of every configured peer MCP server, keyed by host. Peers that are down or time out are marked unreachable instead of failing the whole call"),
+	)
+}
+
+// fleetHostReport результат опроса одного хоста флота
+type fleetHostReport struct {
+	Host string
+	Text string
+	Err  error
+}
+
+// NewGetFleetInfoHandler создает обработчик get_fleet_info, опрашивающий peers не более чем concurrency
+// запросами одновременно и не дольше timeout каждый
+func NewGetFleetInfoHandler(peers []string, apiKey string, concurrency int, timeout time.Duration) server.ToolHandlerFunc {
+	client := &fleetPeerClient{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			// Редиректы не выполняются: иначе X-API-Key ушел бы на хост, указанный соседом
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		apiKey: apiKey,
+	}
+
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.Tools.Debug().
+			Int("peers", len(peers)).
+			Msg("Getting fleet information")
+
+		reports := make([]fleetHostReport, len(peers))
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, peer := range peers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				peerCtx, cancel := context.WithTimeout(ctx, timeout)
+				defer cancel()
+
+				text, err := client.fetchSystemInfo(peerCtx, peer)
+				if err != nil {
+					logger.Tools.Warn().
+						Err(err).
+						Str("peer", fleetHostKey(peer)).
+						Msg("Fleet peer is unreachable")
+				}
+				reports[i] = fleetHostReport{Host: fleetHostKey(peer), Text: text, Err: err}
+			}()
+		}
+
+		local := localFleetReport()
+		wg.Wait()
+
+		return mcp.NewToolResultText(formatFleetReport(local, reports)), nil
+	}
+}
+
+// localFleetReport собирает информацию о самом агрегаторе
+func localFleetReport() fleetHostReport {
+	hostname := sysinfo.Hostname()
+	if hostname == "" {
+		hostname = "local"
+	}
+
+	info, err := sysinfo.Get()
+	if err != nil {
+		return fleetHostReport{Host: hostname + " (local)", Err: err}
+	}
+	return fleetHostReport{Host: hostname + " (local)", Text: info.FormatText()}
+}
+
+// fleetHostKey возвращает host:port соседа как ключ отчета и логов, при REDACT_HOSTNAME имя хоста
+// заменяется хешем
+func fleetHostKey(peer string) string {
+	parsed, err := url.Parse(peer)
+	if err != nil || parsed.Host == "" {
+		return sysinfo.RedactHostname(peer)
+	}
+
+	host := sysinfo.RedactHostname(parsed.Hostname())
+	if port := parsed.Port(); port != "" {
+		return net.JoinHostPort(host, port)
+	}
+	return host
+}
+
+// formatFleetReport объединяет отчеты хостов, недоступные хосты помечаются unreachable
+func formatFleetReport(local fleetHostReport, peers []fleetHostReport) string {
+	unreachable := 0
+	for _, report := range peers {
+		if report.Err != nil {
+			unreachable++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Fleet Information: %d hosts, %d unreachable\n", len(peers)+1, unreachable)
+	for _, report := range append([]fleetHostReport{local}, peers...) {
+		fmt.Fprintf(&b, "\n=== %s ===\n", report.Host)
+		if report.Err != nil {
+			fmt.Fprintf(&b, "unreachable: %v\n", report.Err)
+			continue
+		}
+		b.WriteString(strings.TrimSpace(report.Text))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
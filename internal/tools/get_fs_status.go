@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetFSStatusTool описание инструмента get_fs_status
+func GetFSStatusTool() mcp.Tool {
+	return mcp.NewTool("get_fs_status",
+		mcp.WithDescription("Reports only problematic filesystems: mounted read-only (e.g. remounted by the kernel after disk errors) or with recorded filesystem errors. Returns an all-clear message when every physical filesystem is writable"),
+	)
+}
+
+// GetFSStatusHandler возвращает проблемные файловые системы
+func GetFSStatusHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting filesystem status")
+
+	info, err := sysinfo.GetFSStatus(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get filesystem status")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting filesystem status: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(info.FormatText()), nil
+}
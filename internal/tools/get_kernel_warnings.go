@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultKernelWarningsLimit число последних строк журнала ядра по умолчанию
+const defaultKernelWarningsLimit = 20
+
+// GetKernelWarningsTool описание инструмента get_kernel_warnings
+func GetKernelWarningsTool() mcp.Tool {
+	return mcp.NewTool("get_kernel_warnings",
+		mcp.WithDescription("Counts kernel log (dmesg) messages at or above a severity and returns the most recent ones on Linux. Surfaces hardware problems such as ECC or I/O errors that metrics don't show. Reads /dev/kmsg or journalctl; reported as unavailable with a reason when neither is readable"),
+		mcp.WithString("severity",
+			mcp.Description("Minimum severity: "+strings.Join(sysinfo.KernelLogSeverities, ", ")+" (default: warning)"),
+			mcp.Enum(sysinfo.KernelLogSeverities...),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of most recent messages to return (default: %d, max: %d)", defaultKernelWarningsLimit, sysinfo.KernelLogMaxLines)),
+		),
+	)
+}
+
+// NewGetKernelWarningsHandler создает обработчик get_kernel_warnings, читающий журнал из source
+func NewGetKernelWarningsHandler(source string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		severity := request.GetString("severity", "warning")
+		maxLevel, err := sysinfo.KernelSeverityLevel(severity)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		limit := request.GetInt("limit", defaultKernelWarningsLimit)
+		if limit <= 0 || limit > sysinfo.KernelLogMaxLines {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d, got %d", sysinfo.KernelLogMaxLines, limit)), nil
+		}
+
+		logger.Tools.Debug().
+			Str("source", source).
+			Str("severity", severity).
+			Int("limit", limit).
+			Msg("Getting kernel warnings")
+
+		return mcp.NewToolResultText(sysinfo.GetKernelWarnings(ctx, source, maxLevel, limit).FormatText()), nil
+	}
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultListeningPortsLimit размер страницы get_listening_ports по умолчанию
+	defaultListeningPortsLimit = 100
+	// maxListeningPortsLimit верхняя граница аргумента limit
+	maxListeningPortsLimit = 1000
+)
+
+// GetListeningPortsTool описание инструмента get_listening_ports
+func GetListeningPortsTool() mcp.Tool {
+	return mcp.NewTool("get_listening_ports",
+		mcp.WithDescription("Gets TCP/UDP ports the host is listening on with the owning PID and process name. Sockets whose owner can't be read due to permissions are shown without a PID. "+paginationContract),
+		mcp.WithBoolean("include_ipv4",
+			mcp.Description("Include IPv4 sockets (default: true)"),
+		),
+		mcp.WithBoolean("include_ipv6",
+			mcp.Description("Include IPv6 sockets (default: true)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of sockets per page (default: %d, max: %d)", defaultListeningPortsLimit, maxListeningPortsLimit)),
+		),
+		withOffset(),
+	)
+}
+
+// GetListeningPortsHandler возвращает список слушающих портов
+func GetListeningPortsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	opts := sysinfo.PortsOptions{
+		IncludeIPv4: request.GetBool("include_ipv4", true),
+		IncludeIPv6: request.GetBool("include_ipv6", true),
+	}
+	if !opts.IncludeIPv4 && !opts.IncludeIPv6 {
+		return mcp.NewToolResultError("At least one of include_ipv4 or include_ipv6 must be true"), nil
+	}
+	page, err := ParsePage(request, defaultListeningPortsLimit, maxListeningPortsLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logger.Tools.Debug().
+		Bool("include_ipv4", opts.IncludeIPv4).
+		Bool("include_ipv6", opts.IncludeIPv6).
+		Int("offset", page.Offset).
+		Int("limit", page.Limit).
+		Msg("Getting listening ports")
+
+	ports, err := sysinfo.GetListeningPorts(ctx, opts)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get listening ports")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting listening ports: %v", err)), nil
+	}
+
+	ports.Ports = PageItems(ports.Ports, page)
+	return mcp.NewToolResultText(ports.FormatText() + page.Footer(ports.Total)), nil
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetNUMAInfoTool описание инструмента get_numa_info
+func GetNUMAInfoTool() mcp.Tool {
+	return mcp.NewTool("get_numa_info",
+		mcp.WithDescription("Gets per-NUMA-node memory usage (total/free/used) and the CPUs of each node to spot memory imbalance on multi-socket hosts (Linux only). Single-node hosts are reported as non-NUMA"),
+	)
+}
+
+// GetNUMAInfoHandler возвращает распределение памяти по узлам NUMA
+func GetNUMAInfoHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting NUMA information")
+
+	numaInfo, err := sysinfo.GetNUMAInfo(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get NUMA information")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting NUMA information: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(numaInfo.FormatText()), nil
+}
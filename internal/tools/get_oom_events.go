@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultOOMLookback окно поиска событий OOM killer по умолчанию
+	defaultOOMLookback = 24 * time.Hour
+	// defaultOOMEventsLimit число последних событий OOM killer по умолчанию
+	defaultOOMEventsLimit = 20
+)
+
+// GetOOMEventsTool описание инструмента get_oom_events
+func GetOOMEventsTool() mcp.Tool {
+	return mcp.NewTool("get_oom_events",
+		mcp.WithDescription("Finds OOM-killer activity in the kernel log on Linux: how many processes were killed for lack of memory over a lookback window, per-process victim counts and the most recent kills (process, pid, killed-at time, whole-host vs cgroup limit). Answers \"did something get OOM-killed?\" when a process died unexpectedly. Reads /dev/kmsg or journalctl for the current boot; reported as unavailable with a reason when neither is readable"),
+		mcp.WithString("lookback",
+			mcp.Description(fmt.Sprintf("How far back to search, e.g. 1h or 72h (default: %.0fh, max: %.0fh)", defaultOOMLookback.Hours(), sysinfo.OOMMaxLookback.Hours())),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of most recent kills to return (default: %d, max: %d)", defaultOOMEventsLimit, sysinfo.KernelLogMaxLines)),
+		),
+	)
+}
+
+// NewGetOOMEventsHandler создает обработчик get_oom_events, читающий журнал из source
+func NewGetOOMEventsHandler(source string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lookback := defaultOOMLookback
+		if value := request.GetString("lookback", ""); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed <= 0 || parsed > sysinfo.OOMMaxLookback {
+				return mcp.NewToolResultError(fmt.Sprintf("lookback must be a duration between 1s and %v, got %q", sysinfo.OOMMaxLookback, value)), nil
+			}
+			lookback = parsed
+		}
+
+		limit := request.GetInt("limit", defaultOOMEventsLimit)
+		if limit <= 0 || limit > sysinfo.KernelLogMaxLines {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d, got %d", sysinfo.KernelLogMaxLines, limit)), nil
+		}
+
+		logger.Tools.Debug().
+			Str("source", source).
+			Dur("lookback", lookback).
+			Int("limit", limit).
+			Msg("Getting OOM events")
+
+		return mcp.NewToolResultText(sysinfo.GetOOMEvents(ctx, source, lookback, limit).FormatText()), nil
+	}
+}
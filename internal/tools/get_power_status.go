@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetPowerStatusTool описание инструмента get_power_status
+func GetPowerStatusTool() mcp.Tool {
+	return mcp.NewTool("get_power_status",
+		mcp.WithDescription("Gets power status on Linux: whether the host runs on AC or battery, and each battery's charge percent, charging state and estimated time remaining, plus a total for multiple batteries. Hosts without a battery report 'On AC power / no battery'"),
+	)
+}
+
+// GetPowerStatusHandler возвращает состояние питания и батарей
+func GetPowerStatusHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting power status")
+
+	info, err := sysinfo.GetPowerStatus(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get power status")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting power status: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(info.FormatText()), nil
+}
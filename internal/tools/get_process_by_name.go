@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxProcessByNameLimit ограничивает разбивку get_process_by_name по PID
+const maxProcessByNameLimit = 100
+
+// GetProcessByNameTool описание инструмента get_process_by_name
+func GetProcessByNameTool() mcp.Tool {
+	return mcp.NewTool("get_process_by_name",
+		mcp.WithDescription("Finds processes by name (case-insensitive substring, or exact name) and returns total CPU and memory across all matches plus a per-PID breakdown. Useful for services that spawn multiple workers (e.g. nginx). CPU usage is measured over 500ms. "+paginationContract),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Process name or part of it, e.g. 'nginx'"),
+		),
+		mcp.WithBoolean("exact",
+			mcp.Description("Match the whole process name instead of a substring, still case-insensitive (default: false)"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of PIDs per page of the breakdown, busiest first; totals always include all matches (default: 20, max: %d)", maxProcessByNameLimit)),
+		),
+		withOffset(),
+	)
+}
+
+// GetProcessByNameHandler возвращает суммарные показатели процессов с подходящим именем
+func GetProcessByNameHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name := request.GetString("name", "")
+	if name == "" {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	exact := request.GetBool("exact", false)
+	page, err := ParsePage(request, 20, maxProcessByNameLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logger.Tools.Debug().
+		Str("name", name).
+		Bool("exact", exact).
+		Int("offset", page.Offset).
+		Int("limit", page.Limit).
+		Msg("Getting processes by name")
+
+	info, err := sysinfo.GetProcessesByName(ctx, name, exact, page.End())
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get processes by name")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting processes by name: %v", err)), nil
+	}
+
+	info.Processes = PageItems(info.Processes, page)
+	return mcp.NewToolResultText(info.FormatText() + page.Footer(info.Matches)), nil
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetRuntimeInfoTool описание инструмента get_runtime_info
+func GetRuntimeInfoTool() mcp.Tool {
+	return mcp.NewTool("get_runtime_info",
+		mcp.WithDescription("Gets Go runtime statistics of the MCP server process itself (not host metrics): Go version, platform, goroutines, heap and GC pauses"),
+	)
+}
+
+// GetRuntimeInfoHandler возвращает статистику Go runtime процесса сервера
+func GetRuntimeInfoHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting Go runtime information")
+
+	return mcp.NewToolResultText(sysinfo.GetRuntimeInfo().FormatText()), nil
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetSelfStatsTool описание инструмента get_self_stats
+func GetSelfStatsTool() mcp.Tool {
+	return mcp.NewTool("get_self_stats",
+		mcp.WithDescription("Gets resource usage of the MCP server process itself (not host metrics): CPU percent, RSS, virtual memory, threads, open file descriptors and uptime. Use it to check whether the server is what consumes resources"),
+	)
+}
+
+// GetSelfStatsHandler возвращает потребление ресурсов процессом сервера
+func GetSelfStatsHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting server process resource usage")
+
+	stats, err := sysinfo.GetSelfStats(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get server process resource usage")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting server process resource usage: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(stats.FormatText()), nil
+}
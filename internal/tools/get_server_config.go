@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetServerConfigTool описание инструмента get_server_config
+func GetServerConfigTool() mcp.Tool {
+	return mcp.NewTool("get_server_config",
+		mcp.WithDescription("Gets the effective server configuration with secrets redacted, the source of each setting (env, default or derived) and the list of enabled tools. Safe to paste into a support ticket"),
+	)
+}
+
+// NewGetServerConfigHandler создает обработчик get_server_config для cfg и списка включенных инструментов
+func NewGetServerConfigHandler(cfg *config.Config, enabledTools []string) server.ToolHandlerFunc {
+	return func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings := cfg.Settings()
+
+		logger.Tools.Debug().
+			Int("settings", len(settings)).
+			Msg("Getting server configuration")
+
+		text := "Server Configuration (secrets redacted):\n"
+		for _, s := range settings {
+			text += fmt.Sprintf("\n- %s: %s [%s]", s.Key, s.Value, s.Source)
+		}
+		text += fmt.Sprintf("\n\nEnabled tools (%d): %s", len(enabledTools), strings.Join(enabledTools, ", "))
+
+		return mcp.NewToolResultText(text), nil
+	}
+}
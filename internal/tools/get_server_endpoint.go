@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetServerEndpointTool описание инструмента get_server_endpoint
+func GetServerEndpointTool() mcp.Tool {
+	return mcp.NewTool("get_server_endpoint",
+		mcp.WithDescription("Gets how this server is reachable: transport (stdio or HTTP), bound address, whether TLS is enabled, base path, MCP endpoint path and protocol version. Helps bridges and proxies discover the HTTP transport. Contains no secrets"),
+	)
+}
+
+// NewGetServerEndpointHandler создает обработчик get_server_endpoint для конфигурации cfg
+func NewGetServerEndpointHandler(cfg *config.Config) server.ToolHandlerFunc {
+	text := formatServerEndpoint(cfg)
+
+	return func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.Tools.Debug().Msg("Getting server endpoint")
+
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+// formatServerEndpoint описывает транспорт сервера. API ключ не раскрывается, сообщается только способ аутентификации
+func formatServerEndpoint(cfg *config.Config) string {
+	if cfg.Port == 0 {
+		return fmt.Sprintf("Server Endpoint:\n\n- Transport: stdio, no HTTP endpoint\n- Protocol version: %s", mcp.LATEST_PROTOCOL_VERSION)
+	}
+
+	basePath := cfg.BasePath
+	if basePath == "" {
+		basePath = "/"
+	}
+
+	// HTTP сервер слушает все интерфейсы без TLS, TLS обычно завершается на прокси перед ним
+	return fmt.Sprintf("Server Endpoint:\n\n- Transport: http\n- Address: :%d (all interfaces)\n- TLS: disabled\n- Base path: %s\n- MCP endpoint: %s/mcp\n- Protocol version: %s\n- Authentication: X-API-Key header",
+		cfg.Port, basePath, cfg.BasePath, mcp.LATEST_PROTOCOL_VERSION)
+}
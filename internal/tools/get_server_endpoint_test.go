@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-system-info/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestFormatServerEndpoint(t *testing.T) {
+	stdio := formatServerEndpoint(&config.Config{APIKey: "secret-key"})
+	if !strings.Contains(stdio, "stdio, no HTTP endpoint") {
+		t.Fatalf("stdio endpoint = %q", stdio)
+	}
+
+	http := formatServerEndpoint(&config.Config{Port: 8080, BasePath: "/sysinfo", APIKey: "secret-key"})
+	for _, want := range []string{"Address: :8080", "TLS: disabled", "Base path: /sysinfo", "MCP endpoint: /sysinfo/mcp", mcp.LATEST_PROTOCOL_VERSION} {
+		if !strings.Contains(http, want) {
+			t.Fatalf("http endpoint does not contain %q:\n%s", want, http)
+		}
+	}
+	if strings.Contains(stdio+http, "secret-key") {
+		t.Fatal("endpoint description leaks the API key")
+	}
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetSummaryTool описание инструмента get_summary
+func GetSummaryTool() mcp.Tool {
+	return mcp.NewTool("get_summary",
+		mcp.WithDescription("Gets a compact single-line system summary (e.g. 'CPU 23% | MEM 61% (9.8/16.0 GB) | LOAD 1.20') for status bars and chat"),
+		mcp.WithString("fields",
+			mcp.Description(fmt.Sprintf("Comma-separated metrics to include, in order: %s (default: %s)",
+				strings.Join(sysinfo.SummaryFields, ", "), strings.Join(sysinfo.DefaultSummaryFields, ","))),
+		),
+	)
+}
+
+// GetSummaryHandler возвращает однострочную сводку о системе
+func GetSummaryHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fields, err := parseSummaryFields(request.GetString("fields", ""))
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Invalid summary fields")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logger.Tools.Debug().
+		Strs("fields", fields).
+		Msg("Getting system summary")
+
+	sysInfo, err := sysinfo.Get()
+	if errors.Is(err, sysinfo.ErrCollectionTimeout) && sysInfo != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Msg("Returning partial system summary after collection timeout")
+		return mcp.NewToolResultText(fmt.Sprintf("%s (partial: %v)", sysInfo.FormatSummary(fields...), err)), nil
+	}
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get system information")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting system information: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(sysInfo.FormatSummary(fields...)), nil
+}
+
+// parseSummaryFields разбирает список полей сводки, пустое значение означает набор по умолчанию
+func parseSummaryFields(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return sysinfo.DefaultSummaryFields, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(sysinfo.SummaryFields, field) {
+			return nil, fmt.Errorf("unknown summary field %q, allowed: %s", field, strings.Join(sysinfo.SummaryFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return sysinfo.DefaultSummaryFields, nil
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxSwapActivityInterval ограничивает время блокировки вызова замером
+const maxSwapActivityInterval = 10 * time.Second
+
+// GetSwapActivityTool описание инструмента get_swap_activity
+func GetSwapActivityTool() mcp.Tool {
+	return mcp.NewTool("get_swap_activity",
+		mcp.WithDescription("Gets swap usage and the active swapping rate (pages swapped in/out per second, Linux only). The call blocks for the sampling interval between two counter reads"),
+		mcp.WithString("interval",
+			mcp.Description("Sampling interval between the two counter reads (e.g., '1s'), max 10s"),
+		),
+	)
+}
+
+// GetSwapActivityHandler возвращает скорость подкачки
+func GetSwapActivityHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	intervalStr := request.GetString("interval", "1s")
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval <= 0 || interval > maxSwapActivityInterval {
+		logger.Tools.Error().
+			Err(err).
+			Str("interval", intervalStr).
+			Msg("Invalid swap activity sampling interval")
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid interval %q: must be a positive duration up to %v", intervalStr, maxSwapActivityInterval)), nil
+	}
+
+	logger.Tools.Debug().
+		Dur("interval", interval).
+		Msg("Getting swap activity")
+
+	activity, err := sysinfo.GetSwapActivity(ctx, interval)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get swap activity")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting swap activity: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(activity.FormatText()), nil
+}
@@ -2,7 +2,9 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"mcp-system-info/internal/logger"
 	"mcp-system-info/internal/sysinfo"
@@ -10,11 +12,58 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// GetSystemInfoTool описание инструмента get_system_info
+func GetSystemInfoTool() mcp.Tool {
+	return mcp.NewTool("get_system_info",
+		mcp.WithDescription("Gets system information: CPU and memory"),
+		mcp.WithString("random_string",
+			mcp.Required(),
+			mcp.Description("Dummy parameter for no-parameter tools"),
+		),
+		mcp.WithString("cpu_sample_interval",
+			mcp.Description("Optional window to measure CPU usage over (e.g., '1s', max 10s). More accurate than the instantaneous default, but the call blocks for the whole interval"),
+		),
+	)
+}
+
+// maxCPUSampleInterval ограничивает время блокировки вызова замером CPU
+const maxCPUSampleInterval = 10 * time.Second
+
+// ParseCPUSampleInterval разбирает аргумент cpu_sample_interval, пустое значение означает мгновенный замер
+func ParseCPUSampleInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 || interval > maxCPUSampleInterval {
+		return 0, fmt.Errorf("invalid cpu_sample_interval %q: must be a positive duration up to %v", value, maxCPUSampleInterval)
+	}
+
+	return interval, nil
+}
+
 // GetSystemInfoHandler возвращает текущую информацию о системе
-func GetSystemInfoHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	logger.Tools.Debug().Msg("Getting system information")
+func GetSystemInfoHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cpuInterval, err := ParseCPUSampleInterval(request.GetString("cpu_sample_interval", ""))
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Invalid CPU sample interval")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	sysInfo, err := sysinfo.Get()
+	logger.Tools.Debug().
+		Dur("cpu_sample_interval", cpuInterval).
+		Msg("Getting system information")
+
+	sysInfo, err := sysinfo.GetWithOptions(sysinfo.Options{CPUSampleInterval: cpuInterval})
+	if errors.Is(err, sysinfo.ErrCollectionTimeout) && sysInfo != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Msg("Returning partial system information after collection timeout")
+		return mcp.NewToolResultText(fmt.Sprintf("%s\n\nWarning: %v", sysInfo.FormatText(), err)), nil
+	}
 	if err != nil {
 		logger.Tools.Error().
 			Err(err).
@@ -24,7 +73,7 @@ func GetSystemInfoHandler(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallTo
 
 	logger.Tools.Debug().
 		Int("cpu_count", sysInfo.CPU.Count).
-		Str("cpu_model", sysInfo.CPU.ModelName).
+		Str("cpu_model", sysinfo.TruncateModelName(sysInfo.CPU.ModelName)).
 		Float64("cpu_usage", sysInfo.CPU.UsagePercent).
 		Uint64("memory_total", sysInfo.Memory.Total).
 		Uint64("memory_available", sysInfo.Memory.Available).
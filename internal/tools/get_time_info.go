@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetTimeInfoTool описание инструмента get_time_info
+func GetTimeInfoTool() mcp.Tool {
+	return mcp.NewTool("get_time_info",
+		mcp.WithDescription("Gets the host's current time, timezone and clock synchronization status (chronyc or the kernel NTP state on Linux) with the offset when available, plus the server's wall vs monotonic clock since start to detect clock steps. Reports 'sync status unknown' when no NTP information is available"),
+	)
+}
+
+// GetTimeInfoHandler возвращает время хоста и статус синхронизации часов
+func GetTimeInfoHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting time information")
+
+	return mcp.NewToolResultText(sysinfo.GetTimeInfo(ctx).FormatText()), nil
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// HealthScoreTool описание инструмента health_score
+func HealthScoreTool() mcp.Tool {
+	return mcp.NewTool("health_score",
+		mcp.WithDescription("Gets a 0-100 system health score combining CPU usage, memory pressure, swap activity, disk fullness and load average, with the component breakdown and the biggest contributing factor. Blocks for about 0.5s to sample CPU and swap"),
+	)
+}
+
+// NewHealthScoreHandler создает обработчик health_score с весами компонентов и точками монтирования для оценки диска
+func NewHealthScoreHandler(weights map[string]float64, mounts []string) server.ToolHandlerFunc {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		logger.Tools.Debug().
+			Interface("weights", weights).
+			Msg("Computing health score")
+
+		health, err := sysinfo.GetHealthScore(ctx, weights, mounts)
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Msg("Failed to compute health score")
+			return mcp.NewToolResultError(fmt.Sprintf("Error computing health score: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(health.FormatText()), nil
+	}
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrServerBusy возвращается когда все слоты для тяжелых инструментов заняты
+var ErrServerBusy = errors.New("server busy: too many concurrent heavy tool executions")
+
+// Limiter ограничивает число одновременных выполнений тяжелых инструментов
+type Limiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+// NewLimiter создает ограничитель на maxConcurrent слотов.
+// queueTimeout задает сколько ждать свободный слот, 0 - отказывать сразу
+func NewLimiter(maxConcurrent int, queueTimeout time.Duration) *Limiter {
+	return &Limiter{
+		slots:        make(chan struct{}, maxConcurrent),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Acquire занимает слот и возвращает функцию его освобождения. Nil ограничитель не ограничивает
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() { <-l.slots }
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if l.queueTimeout <= 0 {
+		return nil, ErrServerBusy
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, ErrServerBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WithLimit оборачивает обработчик инструмента ограничителем
+func WithLimit(limiter *Limiter, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		release, err := limiter.Acquire(ctx)
+		if err != nil {
+			limiter.logRejected(request.Params.Name, err)
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, request)
+	}
+}
+
+// AcquireTool занимает слот для инструмента tool и логгирует отказ так же, как WithLimit.
+// Используется streaming вызовами, которые выполняются вне обработчика инструмента
+func (l *Limiter) AcquireTool(ctx context.Context, tool string) (func(), error) {
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		l.logRejected(tool, err)
+	}
+	return release, err
+}
+
+// logRejected логгирует отказ в выполнении тяжелого инструмента
+func (l *Limiter) logRejected(tool string, err error) {
+	logger.Tools.Warn().
+		Err(err).
+		Str("tool", tool).
+		Int("max_concurrent", cap(l.slots)).
+		Msg("Rejected heavy tool execution")
+}
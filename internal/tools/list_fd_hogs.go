@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxFDHogsLimit ограничивает размер списка list_fd_hogs
+const maxFDHogsLimit = 100
+
+// ListFDHogsTool описание инструмента list_fd_hogs
+func ListFDHogsTool() mcp.Tool {
+	return mcp.NewTool("list_fd_hogs",
+		mcp.WithDescription("Lists processes with the most open file descriptors (handles on Windows) to find fd leaks. Processes whose count can't be read are skipped. "+paginationContract),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of processes per page (default: 10, max: 100)"),
+		),
+		withOffset(),
+	)
+}
+
+// ListFDHogsHandler возвращает процессы с наибольшим числом открытых дескрипторов
+func ListFDHogsHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	page, err := ParsePage(request, 10, maxFDHogsLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logger.Tools.Debug().
+		Int("offset", page.Offset).
+		Int("limit", page.Limit).
+		Msg("Listing processes by open file descriptors")
+
+	hogs, err := sysinfo.GetFDHogs(ctx, page.End())
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to list processes by open file descriptors")
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing processes by open file descriptors: %v", err)), nil
+	}
+
+	hogs.Processes = PageItems(hogs.Processes, page)
+	return mcp.NewToolResultText(hogs.FormatText() + page.Footer(hogs.Scanned)), nil
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultZombiesLimit размер страницы list_zombies по умолчанию
+	defaultZombiesLimit = 100
+	// maxZombiesLimit верхняя граница аргумента limit
+	maxZombiesLimit = 1000
+)
+
+// ListZombiesTool описание инструмента list_zombies
+func ListZombiesTool() mcp.Tool {
+	return mcp.NewTool("list_zombies",
+		mcp.WithDescription("Lists zombie (defunct) processes with PID, parent PID and names, grouped by the parent that is not reaping them. Always returns the count, even when zero. "+paginationContract),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of zombie processes per page (default: %d, max: %d)", defaultZombiesLimit, maxZombiesLimit)),
+		),
+		withOffset(),
+	)
+}
+
+// ListZombiesHandler возвращает процессы в состоянии zombie
+func ListZombiesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	page, err := ParsePage(request, defaultZombiesLimit, maxZombiesLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	logger.Tools.Debug().
+		Int("offset", page.Offset).
+		Int("limit", page.Limit).
+		Msg("Listing zombie processes")
+
+	zombies, err := sysinfo.GetZombies(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to list zombie processes")
+		return mcp.NewToolResultError(fmt.Sprintf("Error listing zombie processes: %v", err)), nil
+	}
+
+	zombies.Zombies = PageItems(zombies.Zombies, page)
+	return mcp.NewToolResultText(zombies.FormatText() + page.Footer(zombies.Total)), nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// LogDiskUsageTool описание инструмента log_disk_usage
+func LogDiskUsageTool() mcp.Tool {
+	return mcp.NewTool("log_disk_usage",
+		mcp.WithDescription("Reports total size of the server's configured log directories (LOG_SCAN_ROOTS, e.g. /var/log), the largest log files and directories, and stale log files not modified recently, for disk cleanup triage"),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of largest files, directories and stale files to return (default: %d, max: %d)", defaultDiskScanLimit, maxDiskScanLimit)),
+		),
+		mcp.WithNumber("stale_days",
+			mcp.Description("Files not modified for this many days are reported as stale (default: server's LOG_STALE_AGE)"),
+		),
+	)
+}
+
+// NewLogDiskUsageHandler создает обработчик, сканирующий только настроенные корни логов с ограничением глубины и времени
+func NewLogDiskUsageHandler(roots []string, maxDepth int, timeout, staleAfter time.Duration) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		limit := request.GetInt("limit", defaultDiskScanLimit)
+		if limit <= 0 || limit > maxDiskScanLimit {
+			return mcp.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxDiskScanLimit)), nil
+		}
+
+		if days, ok := request.GetArguments()["stale_days"]; ok {
+			value, ok := days.(float64)
+			if !ok || value <= 0 {
+				return mcp.NewToolResultError("stale_days must be a positive number"), nil
+			}
+			staleAfter = time.Duration(value * float64(24*time.Hour))
+		}
+
+		logger.Tools.Info().
+			Strs("roots", roots).
+			Int("limit", limit).
+			Int("max_depth", maxDepth).
+			Dur("timeout", timeout).
+			Dur("stale_after", staleAfter).
+			Msg("Starting log directory scan")
+
+		scanCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		usage := sysinfo.ScanLogDirs(scanCtx, roots, sysinfo.LogScanOptions{Limit: limit, MaxDepth: maxDepth, StaleAfter: staleAfter})
+		return mcp.NewToolResultText(usage.FormatText()), nil
+	}
+}
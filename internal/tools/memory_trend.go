@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultMemoryTrendMinutes окно memory_trend по умолчанию
+	defaultMemoryTrendMinutes = 15
+	// defaultMemoryTrendThreshold наклон в MB/мин, до которого тренд считается стабильным
+	defaultMemoryTrendThreshold = 1.0
+)
+
+// MemoryTrendTool описание инструмента memory_trend
+func MemoryTrendTool() mcp.Tool {
+	return mcp.NewTool("memory_trend",
+		mcp.WithDescription("Returns the linear regression slope of used memory (MB/min) over the last N minutes from the server's background metric history, classified as stable, rising or falling. A sustained rising slope with a good fit (R² close to 1) signals a memory leak. Fails with a clear error until enough history has been collected"),
+		mcp.WithNumber("minutes",
+			mcp.Description(fmt.Sprintf("Window in minutes, at most the server's METRIC_HISTORY_WINDOW (default: %d)", defaultMemoryTrendMinutes)),
+		),
+		mcp.WithNumber("threshold_mb_per_min",
+			mcp.Description(fmt.Sprintf("Slope below which memory is considered stable, in MB/min (default: %g)", defaultMemoryTrendThreshold)),
+		),
+	)
+}
+
+// MemoryTrendHandler возвращает тренд используемой памяти по фоновой истории
+func MemoryTrendHandler(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	minutes := request.GetFloat("minutes", defaultMemoryTrendMinutes)
+	if minutes <= 0 {
+		return mcp.NewToolResultError("minutes must be positive"), nil
+	}
+	threshold := request.GetFloat("threshold_mb_per_min", defaultMemoryTrendThreshold)
+	if threshold < 0 {
+		return mcp.NewToolResultError("threshold_mb_per_min must not be negative"), nil
+	}
+	window := time.Duration(minutes * float64(time.Minute))
+
+	logger.Tools.Debug().
+		Dur("window", window).
+		Float64("threshold_mb_per_min", threshold).
+		Msg("Getting memory trend")
+
+	trend, err := sysinfo.GetMemoryTrend(window, threshold)
+	if err != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Msg("Memory trend unavailable")
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(trend.FormatText()), nil
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// paginationContract описание постраничного вывода, добавляемое к описанию списочных инструментов
+const paginationContract = "Results are paginated: when more entries exist, the result ends with `next_offset: N`; call again with offset=N to get the next page"
+
+// Page окно списка, запрошенное аргументами offset и limit
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// End индекс после последней записи страницы: столько записей нужно собрать, чтобы вырезать страницу
+func (p Page) End() int {
+	return p.Offset + p.Limit
+}
+
+// withOffset аргумент offset списочного инструмента
+func withOffset() mcp.ToolOption {
+	return mcp.WithNumber("offset",
+		mcp.Description("Number of entries to skip, use next_offset from the previous page (default: 0)"),
+	)
+}
+
+// ParsePage читает offset и limit вызова и проверяет их границы
+func ParsePage(request mcp.CallToolRequest, defaultLimit, maxLimit int) (Page, error) {
+	page := Page{
+		Offset: request.GetInt("offset", 0),
+		Limit:  request.GetInt("limit", defaultLimit),
+	}
+	if page.Limit <= 0 || page.Limit > maxLimit {
+		return Page{}, fmt.Errorf("Invalid limit %d: must be between 1 and %d", page.Limit, maxLimit)
+	}
+	if page.Offset < 0 {
+		return Page{}, fmt.Errorf("Invalid offset %d: must not be negative", page.Offset)
+	}
+	return page, nil
+}
+
+// PageItems вырезает страницу из полного упорядоченного списка, offset за концом дает пустую страницу
+func PageItems[T any](items []T, page Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+	return items[page.Offset:min(len(items), page.End())]
+}
+
+// Footer строка о положении страницы в списке из total записей. Пусто, если весь список уместился
+// в первую страницу, поэтому короткие ответы не меняются
+func (p Page) Footer(total int) string {
+	if p.Offset == 0 && total <= p.Limit {
+		return ""
+	}
+	if p.Offset >= total {
+		return fmt.Sprintf("\n\nNo entries at offset %d (total: %d)", p.Offset, total)
+	}
+
+	end := min(total, p.End())
+	text := fmt.Sprintf("\n\nShowing entries %d-%d of %d", p.Offset+1, end, total)
+	if end < total {
+		text += fmt.Sprintf("\nnext_offset: %d", end)
+	}
+	return text
+}
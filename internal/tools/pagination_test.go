@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func pageRequest(arguments map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}}
+}
+
+func TestParsePage(t *testing.T) {
+	page, err := ParsePage(pageRequest(nil), 10, 100)
+	if err != nil || page != (Page{Offset: 0, Limit: 10}) {
+		t.Fatalf("defaults: page = %+v, err = %v", page, err)
+	}
+
+	page, err = ParsePage(pageRequest(map[string]interface{}{"offset": float64(20), "limit": float64(5)}), 10, 100)
+	if err != nil || page != (Page{Offset: 20, Limit: 5}) || page.End() != 25 {
+		t.Fatalf("page = %+v, err = %v", page, err)
+	}
+
+	for _, arguments := range []map[string]interface{}{
+		{"limit": float64(0)},
+		{"limit": float64(101)},
+		{"offset": float64(-1)},
+	} {
+		if _, err := ParsePage(pageRequest(arguments), 10, 100); err == nil {
+			t.Errorf("ParsePage(%v) succeeded, want error", arguments)
+		}
+	}
+}
+
+func TestPageItemsAndFooter(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		page       Page
+		want       []int
+		wantFooter string
+	}{
+		{Page{Offset: 0, Limit: 10}, []int{1, 2, 3, 4, 5}, ""},
+		{Page{Offset: 0, Limit: 2}, []int{1, 2}, "next_offset: 2"},
+		{Page{Offset: 2, Limit: 2}, []int{3, 4}, "next_offset: 4"},
+		{Page{Offset: 4, Limit: 2}, []int{5}, "Showing entries 5-5 of 5"},
+		{Page{Offset: 9, Limit: 2}, []int{}, "No entries at offset 9"},
+	}
+	for _, tt := range tests {
+		if got := PageItems(items, tt.page); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PageItems(%+v) = %v, want %v", tt.page, got, tt.want)
+		}
+
+		footer := tt.page.Footer(len(items))
+		if tt.wantFooter == "" && footer != "" {
+			t.Errorf("Footer(%+v) = %q, want empty", tt.page, footer)
+		}
+		if !strings.Contains(footer, tt.wantFooter) {
+			t.Errorf("Footer(%+v) = %q, want it to contain %q", tt.page, footer, tt.wantFooter)
+		}
+		if tt.page.End() >= len(items) && strings.Contains(footer, "next_offset") {
+			t.Errorf("Footer(%+v) = %q has next_offset on the last page", tt.page, footer)
+		}
+	}
+}
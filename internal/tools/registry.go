@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"regexp"
+
+	"mcp-system-info/internal/config"
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Definitions возвращает все инструменты сервера вместе с их обработчиками.
+// Используется и для регистрации в MCP сервере (stdio), и в Fiber обработчике (HTTP).
+// Тяжелые инструменты оборачиваются общим ограничителем параллельных выполнений,
+// streaming инструменты - еще и предохранителем от запуска на перегруженном хосте.
+// heavy разделяется с SSE путем Fiber обработчика, чтобы потоки учитывались в том же лимите
+func Definitions(cfg *config.Config, heavy *Limiter) []server.ServerTool {
+	streams := NewStreamGuard(cfg.StreamOverloadCPUPercent, cfg.StreamOverloadAction, cfg.StreamOverloadInterval)
+
+	definitions := []server.ServerTool{
+		{Tool: GetSystemInfoTool(), Handler: GetSystemInfoHandler},
+		{Tool: SystemMonitorStreamTool(), Handler: WithStreamGuard(streams, WithLimit(heavy, NewSystemMonitorStreamHandler(cfg.MonitorOutputDir, cfg.MonitorMaxSamples, cfg.MonitorTimestampFormat)))},
+		{Tool: GetSummaryTool(), Handler: GetSummaryHandler},
+		{Tool: GetCPUInfoTool(), Handler: GetCPUInfoHandler},
+		{Tool: GetCPUFeaturesTool(), Handler: GetCPUFeaturesHandler},
+		{Tool: GetCPUTimesTool(), Handler: WithLimit(heavy, GetCPUTimesHandler)},
+		{Tool: GetNUMAInfoTool(), Handler: GetNUMAInfoHandler},
+		{Tool: GetCapabilitiesTool(), Handler: GetCapabilitiesHandler},
+		{Tool: GetFDInfoTool(), Handler: GetFDInfoHandler},
+		{Tool: ListFDHogsTool(), Handler: WithLimit(heavy, ListFDHogsHandler)},
+		{Tool: ListZombiesTool(), Handler: WithLimit(heavy, ListZombiesHandler)},
+		{Tool: WatchProcessTool(), Handler: WithStreamGuard(streams, WithLimit(heavy, NewWatchProcessHandler(cfg.MonitorMaxSamples, cfg.MonitorTimestampFormat)))},
+		{Tool: GetProcessByNameTool(), Handler: WithLimit(heavy, GetProcessByNameHandler)},
+		{Tool: WaitForConditionTool(), Handler: WithStreamGuard(streams, WithLimit(heavy, NewWaitForConditionHandler(cfg.MonitorMaxSamples, cfg.MonitorTimestampFormat)))},
+		{Tool: GetRuntimeInfoTool(), Handler: GetRuntimeInfoHandler},
+		{Tool: GetSelfStatsTool(), Handler: GetSelfStatsHandler},
+		{Tool: GetServerEndpointTool(), Handler: NewGetServerEndpointHandler(cfg)},
+		{Tool: GetDiskIOTool(), Handler: WithLimit(heavy, GetDiskIOHandler)},
+		{Tool: GetSwapActivityTool(), Handler: WithLimit(heavy, GetSwapActivityHandler)},
+		{Tool: GetDiskUsageTool(), Handler: NewGetDiskUsageHandler(cfg.DiskMounts)},
+		{Tool: GetFSStatusTool(), Handler: GetFSStatusHandler},
+		{Tool: GetBlockDevicesTool(), Handler: GetBlockDevicesHandler},
+		{Tool: GetListeningPortsTool(), Handler: WithLimit(heavy, GetListeningPortsHandler)},
+		{Tool: GetConnectionStatsTool(), Handler: GetConnectionStatsHandler},
+		{Tool: GetConntrackInfoTool(), Handler: WithLimit(heavy, GetConntrackInfoHandler)},
+		{Tool: GetEntropyInfoTool(), Handler: GetEntropyInfoHandler},
+		{Tool: GetKernelWarningsTool(), Handler: WithLimit(heavy, NewGetKernelWarningsHandler(cfg.KernelLogSource))},
+		{Tool: GetOOMEventsTool(), Handler: WithLimit(heavy, NewGetOOMEventsHandler(cfg.KernelLogSource))},
+		{Tool: HealthScoreTool(), Handler: WithLimit(heavy, NewHealthScoreHandler(cfg.HealthWeights, cfg.DiskMounts))},
+		{Tool: ThermalStatusTool(), Handler: ThermalStatusHandler},
+		{Tool: GetPowerStatusTool(), Handler: GetPowerStatusHandler},
+		{Tool: GetTimeInfoTool(), Handler: GetTimeInfoHandler},
+		{Tool: MemoryTrendTool(), Handler: MemoryTrendHandler},
+		{Tool: CPUPercentilesTool(), Handler: CPUPercentilesHandler},
+	}
+
+	// Даже с маскированием окружение может быть чувствительным, поэтому инструмент включается явно
+	if cfg.EnableEnvTool {
+		definitions = append(definitions, server.ServerTool{
+			Tool:    GetEnvTool(),
+			Handler: NewGetEnvHandler(regexp.MustCompile(cfg.EnvRedactPattern), cfg.APIKey),
+		})
+	}
+
+	// Сканирование диска доступно только внутри явно разрешенных каталогов
+	if len(cfg.DiskScanRoots) > 0 {
+		definitions = append(definitions, server.ServerTool{
+			Tool:    DiskUsageScanTool(),
+			Handler: WithLimit(heavy, NewDiskUsageScanHandler(cfg.DiskScanRoots, cfg.DiskScanMaxDepth, cfg.DiskScanTimeout)),
+		})
+	}
+
+	// Сканирование логов ограничено явно заданными корнями, как и disk_usage_scan
+	if len(cfg.LogScanRoots) > 0 {
+		definitions = append(definitions, server.ServerTool{
+			Tool:    LogDiskUsageTool(),
+			Handler: WithLimit(heavy, NewLogDiskUsageHandler(cfg.LogScanRoots, cfg.LogScanMaxDepth, cfg.LogScanTimeout, cfg.LogStaleAge)),
+		})
+	}
+
+	// Проба пишет только во временный файл внутри явно заданного каталога
+	if cfg.DiskProbeDir != "" {
+		definitions = append(definitions, server.ServerTool{
+			Tool:    DiskLatencyProbeTool(),
+			Handler: WithLimit(heavy, NewDiskLatencyProbeHandler(cfg.DiskProbeDir, cfg.DiskProbeSizeKB*1024)),
+		})
+	}
+
+	// Обзор флота опрашивает только явно заданные соседние серверы
+	if len(cfg.FleetPeers) > 0 {
+		definitions = append(definitions, server.ServerTool{
+			Tool:    GetFleetInfoTool(),
+			Handler: WithLimit(heavy, NewGetFleetInfoHandler(cfg.FleetPeers, cfg.FleetAPIKey, cfg.FleetConcurrency, cfg.FleetTimeout)),
+		})
+	}
+
+	// Пользовательские инструменты из конфигурации не могут подменять встроенные, включая
+	// get_server_config, который добавляется последним
+	serverConfigTool := GetServerConfigTool()
+	for _, spec := range cfg.CustomTools {
+		if hasTool(definitions, spec.Name) || spec.Name == serverConfigTool.Name {
+			logger.Tools.Error().
+				Str("tool", spec.Name).
+				Msg("Custom tool name conflicts with a built-in tool, skipping")
+			continue
+		}
+		definitions = append(definitions, server.ServerTool{
+			Tool:    CustomToolDefinition(spec),
+			Handler: WithLimit(heavy, NewCustomToolHandler(spec, cfg.CustomToolTimeout)),
+		})
+	}
+
+	// Список включенных инструментов известен только после сборки всех определений
+	enabledTools := make([]string, 0, len(definitions)+1)
+	for _, definition := range definitions {
+		enabledTools = append(enabledTools, definition.Tool.Name)
+	}
+	enabledTools = append(enabledTools, serverConfigTool.Name)
+	definitions = append(definitions, server.ServerTool{
+		Tool:    serverConfigTool,
+		Handler: NewGetServerConfigHandler(cfg, enabledTools),
+	})
+
+	if cfg.SchemaVersionField {
+		for i := range definitions {
+			definitions[i].Handler = withSchemaVersion(definitions[i].Handler)
+		}
+	}
+
+	return definitions
+}
+
+// hasTool проверяет, есть ли инструмент с таким именем среди definitions
+func hasTool(definitions []server.ServerTool, name string) bool {
+	for _, definition := range definitions {
+		if definition.Tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
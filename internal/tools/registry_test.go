@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"testing"
+
+	"mcp-system-info/internal/config"
+)
+
+func TestDefinitionsRejectCustomToolShadowingBuiltins(t *testing.T) {
+	cfg := &config.Config{
+		CustomTools: []config.CustomTool{
+			{Name: "get_server_config", Command: "/bin/echo"},
+			{Name: "get_cpu_info", Command: "/bin/echo"},
+			{Name: "echo_custom", Command: "/bin/echo"},
+		},
+	}
+
+	counts := make(map[string]int)
+	for _, definition := range Definitions(cfg, nil) {
+		counts[definition.Tool.Name]++
+	}
+	for _, name := range []string{"get_server_config", "get_cpu_info", "echo_custom"} {
+		if counts[name] != 1 {
+			t.Errorf("tool %q registered %d times, want 1", name, counts[name])
+		}
+	}
+}
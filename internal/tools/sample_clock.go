@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ValidateSampleCount проверяет интервал и число сэмплов duration/interval, которое создаст стрим.
+// time.ParseDuration принимает отрицательные значения, без проверки стрим завершился бы сразу без сэмплов.
+// Одно ограничение на число сэмплов защищает и от роста памяти в stdio, и от потока событий в SSE
+func ValidateSampleCount(duration, interval time.Duration, maxSamples int) error {
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %v", duration)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %v", interval)
+	}
+	if interval >= duration {
+		return fmt.Errorf("interval %v must be shorter than duration %v: the stream would end before the first sample", interval, duration)
+	}
+
+	if samples := duration / interval; samples > time.Duration(maxSamples) {
+		return fmt.Errorf("duration %v with interval %v implies %d samples, more than the allowed %d: use a larger interval (at least %v) or a shorter duration (at most %v)",
+			duration, interval, samples, maxSamples, duration/time.Duration(maxSamples), interval*time.Duration(maxSamples))
+	}
+
+	return nil
+}
+
+// SampleClock измеряет фактический интервал между сэмплами мониторинга.
+// time.Ticker сам держит среднюю частоту (тики не смещаются на время сбора, а при медленном сборе
+// пропускаются), поэтому компенсация не нужна, но реальный интервал между сэмплами плавает
+// и клиенту нужен для корректного расчета скоростей
+type SampleClock struct {
+	last time.Time
+}
+
+// NewSampleClock создает часы, первый интервал отсчитывается от start
+func NewSampleClock(start time.Time) *SampleClock {
+	return &SampleClock{last: start}
+}
+
+// Mark фиксирует момент сэмпла и возвращает время, прошедшее с предыдущего
+func (c *SampleClock) Mark(now time.Time) time.Duration {
+	elapsed := now.Sub(c.last)
+	c.last = now
+	return elapsed
+}
+
+// Milliseconds переводит интервал в миллисекунды с дробной частью
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// Форматы времени сэмплов в выводе стримов
+const (
+	// TimestampShort локальное время сервера без даты и зоны ("15:04:05"), удобно читать
+	TimestampShort = "short"
+	// TimestampRFC3339 дата и время с зоной, однозначно сопоставляется между часовыми поясами
+	TimestampRFC3339 = "rfc3339"
+	// TimestampUnixMs миллисекунды Unix epoch
+	TimestampUnixMs = "unix_ms"
+)
+
+// rfc3339Millis RFC 3339 с фиксированной точностью до миллисекунд
+const rfc3339Millis = "2006-01-02T15:04:05.000Z07:00"
+
+// ParseTimestampFormat проверяет аргумент timestamp_format, пустое значение означает defaultFormat
+func ParseTimestampFormat(value, defaultFormat string) (string, error) {
+	switch value {
+	case "":
+		return defaultFormat, nil
+	case TimestampShort, TimestampRFC3339, TimestampUnixMs:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid timestamp_format %q: must be one of %s, %s, %s", value, TimestampShort, TimestampRFC3339, TimestampUnixMs)
+}
+
+// FormatTimestamp форматирует время сэмпла в выбранном формате
+func FormatTimestamp(t time.Time, format string) string {
+	switch format {
+	case TimestampRFC3339:
+		return t.Format(rfc3339Millis)
+	case TimestampUnixMs:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format("15:04:05")
+	}
+}
+
+// AlignDelay возвращает время до ближайшей границы интервала по часам (начала секунды для 1s,
+// минуты для 1m). Границы отсчитываются от нулевого времени в UTC, поэтому для интервалов,
+// не делящих сутки, или зон с дробным смещением они не совпадают с локальными
+func AlignDelay(now time.Time, interval time.Duration) time.Duration {
+	next := now.Truncate(interval).Add(interval)
+	return next.Sub(now)
+}
+
+// WaitAligned ждет ближайшей границы интервала по часам, false если контекст отменен раньше
+func WaitAligned(ctx context.Context, interval time.Duration) bool {
+	timer := time.NewTimer(AlignDelay(time.Now(), interval))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestValidateSampleCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		interval time.Duration
+		wantErr  string
+	}{
+		{name: "valid", duration: 30 * time.Second, interval: 2 * time.Second},
+		{name: "at limit", duration: 100 * time.Second, interval: time.Second},
+		{name: "negative duration", duration: -5 * time.Second, interval: time.Second, wantErr: "duration must be positive"},
+		{name: "zero duration", duration: 0, interval: time.Second, wantErr: "duration must be positive"},
+		{name: "negative interval", duration: 30 * time.Second, interval: -time.Second, wantErr: "interval must be positive"},
+		{name: "zero interval", duration: 30 * time.Second, interval: 0, wantErr: "interval must be positive"},
+		{name: "interval equals duration", duration: time.Second, interval: time.Second, wantErr: "must be shorter than duration"},
+		{name: "interval longer than duration", duration: time.Second, interval: 5 * time.Second, wantErr: "must be shorter than duration"},
+		{name: "too many samples", duration: 101 * time.Second, interval: time.Second, wantErr: "more than the allowed 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSampleCount(tt.duration, tt.interval, 100)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSystemMonitorStreamRejectsInvalidWindow(t *testing.T) {
+	handler := NewSystemMonitorStreamHandler("", 100, TimestampShort)
+
+	tests := []struct {
+		name     string
+		duration string
+		interval string
+	}{
+		{name: "negative duration", duration: "-5s", interval: "1s"},
+		{name: "zero duration", duration: "0s", interval: "1s"},
+		{name: "negative interval", duration: "30s", interval: "-1s"},
+		{name: "zero interval", duration: "30s", interval: "0s"},
+		{name: "too many samples", duration: "1h", interval: "1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request mcp.CallToolRequest
+			request.Params.Arguments = map[string]interface{}{
+				"duration": tt.duration,
+				"interval": tt.interval,
+			}
+
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatalf("expected error result for duration=%s interval=%s", tt.duration, tt.interval)
+			}
+			text, _ := result.Content[0].(mcp.TextContent)
+			if !strings.HasPrefix(text.Text, "Invalid monitoring window") {
+				t.Fatalf("result text = %q", text.Text)
+			}
+		})
+	}
+}
+
+func TestParseTimestampFormat(t *testing.T) {
+	if got, err := ParseTimestampFormat("", TimestampRFC3339); err != nil || got != TimestampRFC3339 {
+		t.Fatalf("empty value = %q, %v, want default %q", got, err, TimestampRFC3339)
+	}
+	if got, err := ParseTimestampFormat(TimestampUnixMs, TimestampShort); err != nil || got != TimestampUnixMs {
+		t.Fatalf("unix_ms = %q, %v", got, err)
+	}
+	if _, err := ParseTimestampFormat("iso", TimestampShort); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2025, 3, 26, 14, 5, 9, 123456789, time.FixedZone("MSK", 3*60*60))
+
+	tests := map[string]string{
+		TimestampShort:   "14:05:09",
+		TimestampRFC3339: "2025-03-26T14:05:09.123+03:00",
+		TimestampUnixMs:  "1742987109123",
+	}
+	for format, want := range tests {
+		if got := FormatTimestamp(ts, format); got != want {
+			t.Fatalf("FormatTimestamp(%s) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestAlignDelay(t *testing.T) {
+	base := time.Date(2025, 3, 26, 14, 5, 9, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		want     time.Duration
+	}{
+		{name: "mid second", now: base.Add(250 * time.Millisecond), interval: time.Second, want: 750 * time.Millisecond},
+		{name: "on boundary waits full interval", now: base, interval: time.Second, want: time.Second},
+		{name: "minute", now: base, interval: time.Minute, want: 51 * time.Second},
+		{name: "five seconds", now: base, interval: 5 * time.Second, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AlignDelay(tt.now, tt.interval); got != tt.want {
+				t.Fatalf("AlignDelay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sampleNotifier отправляет сэмплы мониторинга клиенту уведомлениями MCP по мере их получения.
+// С progressToken от клиента используется notifications/progress, иначе notifications/message,
+// если уровень логов клиента (logging/setLevel) пропускает info
+type sampleNotifier struct {
+	ctx     context.Context
+	server  *server.MCPServer
+	session server.ClientSession
+	token   mcp.ProgressToken
+	total   int
+	tool    string
+}
+
+// logSeverity порядок уровней логов MCP (RFC 5424) от подробного к критичному
+var logSeverity = map[mcp.LoggingLevel]int{
+	mcp.LoggingLevelDebug:     0,
+	mcp.LoggingLevelInfo:      1,
+	mcp.LoggingLevelNotice:    2,
+	mcp.LoggingLevelWarning:   3,
+	mcp.LoggingLevelError:     4,
+	mcp.LoggingLevelCritical:  5,
+	mcp.LoggingLevelAlert:     6,
+	mcp.LoggingLevelEmergency: 7,
+}
+
+// clientAcceptsLog проверяет, что сессия поддерживает логи и ее уровень пропускает level
+func clientAcceptsLog(session server.ClientSession, level mcp.LoggingLevel) bool {
+	logging, ok := session.(server.SessionWithLogging)
+	if !ok {
+		return false
+	}
+	minimum, known := logSeverity[logging.GetLogLevel()]
+	return known && logSeverity[level] >= minimum
+}
+
+// newSampleNotifier возвращает nil, если в контексте нет MCP сервера с инициализированной
+// клиентской сессией (например, вызов через Fiber обработчик, где сэмплы идут SSE потоком)
+func newSampleNotifier(ctx context.Context, request mcp.CallToolRequest, total int) *sampleNotifier {
+	srv := server.ServerFromContext(ctx)
+	session := server.ClientSessionFromContext(ctx)
+	if srv == nil || session == nil || !session.Initialized() {
+		return nil
+	}
+
+	notifier := &sampleNotifier{ctx: ctx, server: srv, session: session, total: total, tool: request.Params.Name}
+	if request.Params.Meta != nil {
+		notifier.token = request.Params.Meta.ProgressToken
+	}
+	return notifier
+}
+
+// send отправляет сэмпл, false означает что уведомление не доставлено и сэмпл нужно оставить в результате
+func (n *sampleNotifier) send(iteration int, text string) bool {
+	if n.token == nil && !clientAcceptsLog(n.session, mcp.LoggingLevelInfo) {
+		return false
+	}
+
+	method := "notifications/message"
+	params := map[string]any{
+		"level":  "info",
+		"logger": n.tool,
+		"data":   strings.TrimSpace(text),
+	}
+	if n.token != nil {
+		method = "notifications/progress"
+		params = map[string]any{
+			"progressToken": n.token,
+			"progress":      iteration,
+			"total":         n.total,
+			"message":       strings.TrimSpace(text),
+		}
+	}
+
+	if err := n.server.SendNotificationToClient(n.ctx, method, params); err != nil {
+		logger.Tools.Debug().
+			Err(err).
+			Int("iteration", iteration).
+			Msg("Failed to send sample notification, keeping sample in result")
+		return false
+	}
+	return true
+}
+
+// sampleSummary агрегаты по сэмплам для итогового результата стрима
+type sampleSummary struct {
+	count          int
+	cpuSum, cpuMax float64
+	memSum, memMax float64
+}
+
+// add учитывает сэмпл в агрегатах
+func (s *sampleSummary) add(info *sysinfo.SystemInfo) {
+	s.count++
+	s.cpuSum += info.CPU.UsagePercent
+	s.cpuMax = max(s.cpuMax, info.CPU.UsagePercent)
+	s.memSum += info.Memory.UsedPercent
+	s.memMax = max(s.memMax, info.Memory.UsedPercent)
+}
+
+// String форматирует сводку по сэмплам
+func (s *sampleSummary) String() string {
+	if s.count == 0 {
+		return "📋 Summary: no samples collected\n"
+	}
+	return fmt.Sprintf("📋 Summary: %d samples, CPU avg %.1f%% max %.1f%%, MEM avg %.1f%% max %.1f%%\n",
+		s.count, s.cpuSum/float64(s.count), s.cpuMax, s.memSum/float64(s.count), s.memMax)
+}
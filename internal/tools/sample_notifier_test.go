@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// loggingSession клиентская сессия с уровнем логов
+type loggingSession struct {
+	level mcp.LoggingLevel
+}
+
+func (s *loggingSession) Initialize()                                         {}
+func (s *loggingSession) Initialized() bool                                   { return true }
+func (s *loggingSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *loggingSession) SessionID() string                                   { return "test" }
+func (s *loggingSession) SetLogLevel(level mcp.LoggingLevel)                  { s.level = level }
+func (s *loggingSession) GetLogLevel() mcp.LoggingLevel                       { return s.level }
+
+func TestClientAcceptsLog(t *testing.T) {
+	cases := []struct {
+		session server.ClientSession
+		want    bool
+	}{
+		{&loggingSession{level: mcp.LoggingLevelDebug}, true},
+		{&loggingSession{level: mcp.LoggingLevelInfo}, true},
+		{&loggingSession{level: mcp.LoggingLevelError}, false},
+		{&loggingSession{}, false},
+	}
+	for _, tc := range cases {
+		if got := clientAcceptsLog(tc.session, mcp.LoggingLevelInfo); got != tc.want {
+			t.Errorf("clientAcceptsLog(level %q, info) = %t, want %t", tc.session.(*loggingSession).level, got, tc.want)
+		}
+	}
+}
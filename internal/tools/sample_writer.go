@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+)
+
+// ErrOutputDisabled возвращается, если директория для файлов мониторинга не настроена
+var ErrOutputDisabled = errors.New("output_file is disabled: MONITOR_OUTPUT_DIR is not configured")
+
+// csvHeader заголовок CSV файла с сэмплами
+var csvHeader = []string{"iteration", "timestamp", "cpu_percent", "memory_used_percent", "memory_used_bytes", "memory_total_bytes", "actual_interval_ms"}
+
+// Sample одна запись мониторинга, сохраняемая в файл
+type Sample struct {
+	Iteration         int       `json:"iteration"`
+	Timestamp         time.Time `json:"timestamp"`
+	CPUPercent        float64   `json:"cpu_percent"`
+	MemoryUsedPercent float64   `json:"memory_used_percent"`
+	MemoryUsed        uint64    `json:"memory_used_bytes"`
+	MemoryTotal       uint64    `json:"memory_total_bytes"`
+	// ActualIntervalMs фактическое время с предыдущего сэмпла (для первого - с начала стрима)
+	ActualIntervalMs float64 `json:"actual_interval_ms"`
+}
+
+// NewSample создает запись из снимка системной информации и фактического интервала с предыдущего сэмпла
+func NewSample(iteration int, info *sysinfo.SystemInfo, actualInterval time.Duration) Sample {
+	return Sample{
+		Iteration:         iteration,
+		Timestamp:         time.Now().UTC(),
+		CPUPercent:        info.CPU.UsagePercent,
+		MemoryUsedPercent: info.Memory.UsedPercent,
+		MemoryUsed:        info.Memory.Used,
+		MemoryTotal:       info.Memory.Total,
+		ActualIntervalMs:  Milliseconds(actualInterval),
+	}
+}
+
+// SampleWriter дописывает сэмплы мониторинга в CSV или JSONL файл
+type SampleWriter struct {
+	path   string
+	file   *os.File
+	buf    *bufio.Writer
+	csv    *csv.Writer
+	count  int
+	closed bool
+}
+
+// ResolveOutputPath проверяет, что относительный путь остается внутри baseDir, в том числе после
+// разрешения символических ссылок в его директориях
+func ResolveOutputPath(baseDir, name string) (string, error) {
+	if baseDir == "" {
+		return "", ErrOutputDisabled
+	}
+	if name == "" {
+		return "", errors.New("output_file must not be empty")
+	}
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, `\`) {
+		return "", fmt.Errorf("output_file %q must be a relative path", name)
+	}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return "", fmt.Errorf("output_file %q must not contain '..'", name)
+		}
+	}
+
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve output directory: %v", err)
+	}
+	path := filepath.Join(base, name)
+
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("output_file %q escapes the output directory", name)
+	}
+	if err := checkOutputLocation(base, path); err != nil {
+		return "", fmt.Errorf("output_file %q %v", name, err)
+	}
+
+	return path, nil
+}
+
+// checkOutputLocation проверяет, что директория файла после разрешения символических ссылок
+// лежит внутри base, а сам файл не является символической ссылкой
+func checkOutputLocation(base, path string) error {
+	resolvedBase, err := evalExistingSymlinks(base)
+	if err != nil {
+		return fmt.Errorf("cannot resolve the output directory: %v", err)
+	}
+	resolvedDir, err := evalExistingSymlinks(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("cannot resolve its directory: %v", err)
+	}
+
+	rel, err := filepath.Rel(resolvedBase, resolvedDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return errors.New("escapes the output directory through a symlink")
+	}
+
+	if stat, err := os.Lstat(path); err == nil && stat.Mode()&os.ModeSymlink != 0 {
+		return errors.New("must not be a symlink")
+	}
+
+	return nil
+}
+
+// evalExistingSymlinks разрешает символические ссылки в самой длинной существующей части пути,
+// несуществующий остаток (директории, которые еще будут созданы) дописывается как есть
+func evalExistingSymlinks(path string) (string, error) {
+	var missing []string
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			for i := len(missing) - 1; i >= 0; i-- {
+				resolved = filepath.Join(resolved, missing[i])
+			}
+			return resolved, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", err
+		}
+		missing = append(missing, filepath.Base(path))
+		path = parent
+	}
+}
+
+// OpenSampleWriter открывает файл внутри baseDir на дозапись, формат определяется расширением (.csv или .jsonl)
+func OpenSampleWriter(baseDir, name string) (*SampleWriter, error) {
+	path, err := ResolveOutputPath(baseDir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".csv" && ext != ".jsonl" {
+		return nil, fmt.Errorf("output_file %q must have .csv or .jsonl extension", name)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %v", err)
+	}
+	// Повторная проверка после создания директорий: их могли подменить ссылкой между проверкой и MkdirAll
+	if _, err := ResolveOutputPath(baseDir, name); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %v", err)
+	}
+
+	writer := &SampleWriter{
+		path: path,
+		file: file,
+		buf:  bufio.NewWriter(file),
+	}
+
+	if ext == ".csv" {
+		writer.csv = csv.NewWriter(writer.buf)
+		// Заголовок пишется только в новый файл, чтобы дозапись не дублировала его
+		if stat, err := file.Stat(); err == nil && stat.Size() == 0 {
+			if err := writer.csv.Write(csvHeader); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("failed to write CSV header: %v", err)
+			}
+		}
+	}
+
+	logger.Tools.Info().
+		Str("path", path).
+		Str("format", strings.TrimPrefix(ext, ".")).
+		Msg("Monitor sample file opened")
+
+	return writer, nil
+}
+
+// Write дописывает один сэмпл
+func (w *SampleWriter) Write(sample Sample) error {
+	if w.csv != nil {
+		return w.writeCSV(sample)
+	}
+
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := w.buf.Write(data); err != nil {
+		return err
+	}
+
+	w.count++
+	return nil
+}
+
+// writeCSV дописывает сэмпл строкой CSV
+func (w *SampleWriter) writeCSV(sample Sample) error {
+	record := []string{
+		strconv.Itoa(sample.Iteration),
+		sample.Timestamp.Format(time.RFC3339Nano),
+		strconv.FormatFloat(sample.CPUPercent, 'f', 2, 64),
+		strconv.FormatFloat(sample.MemoryUsedPercent, 'f', 2, 64),
+		strconv.FormatUint(sample.MemoryUsed, 10),
+		strconv.FormatUint(sample.MemoryTotal, 10),
+		strconv.FormatFloat(sample.ActualIntervalMs, 'f', 3, 64),
+	}
+	if err := w.csv.Write(record); err != nil {
+		return err
+	}
+
+	w.count++
+	return nil
+}
+
+// Close сбрасывает буферы и закрывает файл, повторный вызов безопасен
+func (w *SampleWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	var flushErr error
+	if w.csv != nil {
+		w.csv.Flush()
+		flushErr = w.csv.Error()
+	}
+	flushErr = errors.Join(flushErr, w.buf.Flush())
+	closeErr := w.file.Close()
+
+	logger.Tools.Info().
+		Str("path", w.path).
+		Int("samples_written", w.count).
+		Msg("Monitor sample file closed")
+
+	return errors.Join(flushErr, closeErr)
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveOutputPathRejectsSymlinkEscape(t *testing.T) {
+	base := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(base, "link")); err != nil {
+		t.Skipf("symlinks are not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "target.csv"), filepath.Join(base, "file.csv")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"link/samples.csv", "link/nested/samples.csv", "file.csv"} {
+		if _, err := ResolveOutputPath(base, name); err == nil {
+			t.Errorf("ResolveOutputPath(%q) succeeded, want error", name)
+		}
+	}
+
+	if _, err := ResolveOutputPath(base, "nested/samples.csv"); err != nil {
+		t.Errorf("ResolveOutputPath(nested/samples.csv) = %v, want nil", err)
+	}
+}
+
+func TestResolveOutputPathAllowsDotPrefixedNames(t *testing.T) {
+	base := t.TempDir()
+
+	for _, name := range []string{"..samples.jsonl", "nested/..samples.csv"} {
+		if _, err := ResolveOutputPath(base, name); err != nil {
+			t.Errorf("ResolveOutputPath(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"../samples.csv", "nested/../../samples.csv"} {
+		if _, err := ResolveOutputPath(base, name); err == nil {
+			t.Errorf("ResolveOutputPath(%q) succeeded, want error", name)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-system-info/internal/config"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WithSchemaVersion добавляет в _meta результата версию контракта вывода инструментов.
+// _meta зарезервировано протоколом для дополнительных данных, поэтому поле не ломает строгих клиентов
+func WithSchemaVersion(meta map[string]any) map[string]any {
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	meta["schema_version"] = config.OutputSchemaVersion
+	return meta
+}
+
+// withSchemaVersion оборачивает обработчик инструмента, добавляя версию контракта в результат.
+// Обертка ставится в Definitions и поэтому действует и в stdio, и в HTTP
+func withSchemaVersion(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if result != nil {
+			result.Meta = WithSchemaVersion(result.Meta)
+		}
+		return result, err
+	}
+}
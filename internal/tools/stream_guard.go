@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"mcp-system-info/internal/logger"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+// ErrHostOverloaded возвращается вместо запуска streaming инструмента, когда CPU хоста выше порога
+var ErrHostOverloaded = errors.New("host overloaded, streaming temporarily disabled")
+
+// Реакции на перегрузку хоста (STREAM_OVERLOAD_ACTION)
+const (
+	// OverloadActionReject отклонять новые streaming вызовы
+	OverloadActionReject = "reject"
+	// OverloadActionDegrade запускать streaming вызовы с увеличенным интервалом сэмплов
+	OverloadActionDegrade = "degrade"
+)
+
+// overloadSampleWindow окно замера CPU перед запуском streaming инструмента
+const overloadSampleWindow = 250 * time.Millisecond
+
+// StreamGuard предохранитель streaming инструментов: при загрузке CPU выше порога новые
+// стримы отклоняются или запускаются с редкими сэмплами. Уже запущенные стримы не затрагиваются
+type StreamGuard struct {
+	threshold        float64
+	action           string
+	degradedInterval time.Duration
+	cpuPercent       func(ctx context.Context) (float64, error)
+}
+
+// NewStreamGuard создает предохранитель с порогом CPU threshold в процентах, nil при threshold=0 (выключен)
+func NewStreamGuard(threshold float64, action string, degradedInterval time.Duration) *StreamGuard {
+	if threshold <= 0 {
+		return nil
+	}
+	return &StreamGuard{
+		threshold:        threshold,
+		action:           action,
+		degradedInterval: degradedInterval,
+		cpuPercent:       sampleCPUPercent,
+	}
+}
+
+// sampleCPUPercent замеряет загрузку CPU хоста за overloadSampleWindow. Только CPU, без полного сбора:
+// хост уже может быть перегружен, а общие базы замеров (cpu.Percent(0), steal) не должны сбиваться
+func sampleCPUPercent(ctx context.Context) (float64, error) {
+	percents, err := cpu.PercentWithContext(ctx, overloadSampleWindow, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(percents) == 0 {
+		return 0, fmt.Errorf("no CPU usage reported")
+	}
+	return percents[0], nil
+}
+
+// Check проверяет загрузку хоста перед запуском стрима. Возвращает аргументы вызова, в режиме degrade
+// с интервалом не меньше degradedInterval, или ErrHostOverloaded в режиме reject.
+// Ошибка замера CPU не блокирует стрим: предохранитель не должен отключать мониторинг сам по себе
+func (g *StreamGuard) Check(ctx context.Context, tool string, arguments map[string]interface{}) (map[string]interface{}, error) {
+	if g == nil {
+		return arguments, nil
+	}
+
+	usage, err := g.cpuPercent(ctx)
+	if err != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Str("tool", tool).
+			Msg("Failed to sample CPU for stream overload check, allowing stream")
+		return arguments, nil
+	}
+	if usage < g.threshold {
+		return arguments, nil
+	}
+
+	if g.action != OverloadActionDegrade {
+		logger.Tools.Warn().
+			Str("tool", tool).
+			Float64("cpu_percent", usage).
+			Float64("threshold", g.threshold).
+			Msg("Rejected streaming tool call: host overloaded")
+		return nil, fmt.Errorf("%w (CPU %.1f%% >= %.1f%%)", ErrHostOverloaded, usage, g.threshold)
+	}
+
+	degraded := make(map[string]interface{}, len(arguments)+1)
+	for key, value := range arguments {
+		degraded[key] = value
+	}
+	requested, _ := arguments["interval"].(string)
+	if interval, err := time.ParseDuration(requested); requested == "" || (err == nil && interval < g.degradedInterval) {
+		degraded["interval"] = g.degradedInterval.String()
+	}
+
+	logger.Tools.Warn().
+		Str("tool", tool).
+		Float64("cpu_percent", usage).
+		Float64("threshold", g.threshold).
+		Str("requested_interval", requested).
+		Interface("interval", degraded["interval"]).
+		Msg("Host overloaded, streaming tool call degraded to a longer interval")
+	return degraded, nil
+}
+
+// WithStreamGuard оборачивает обработчик streaming инструмента предохранителем guard
+func WithStreamGuard(guard *StreamGuard, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	if guard == nil {
+		return handler
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, err := guard.Check(ctx, request.Params.Name, request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+		request.Params.Arguments = arguments
+		return handler(ctx, request)
+	}
+}
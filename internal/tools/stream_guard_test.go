@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func fakeStreamGuard(action string, usage float64) *StreamGuard {
+	guard := NewStreamGuard(90, action, 10*time.Second)
+	guard.cpuPercent = func(context.Context) (float64, error) { return usage, nil }
+	return guard
+}
+
+func TestStreamGuardDisabled(t *testing.T) {
+	guard := NewStreamGuard(0, OverloadActionReject, time.Second)
+	if guard != nil {
+		t.Fatalf("NewStreamGuard(0) = %+v, want nil", guard)
+	}
+
+	arguments := map[string]interface{}{"interval": "1s"}
+	got, err := guard.Check(context.Background(), "system_monitor_stream", arguments)
+	if err != nil || got["interval"] != "1s" {
+		t.Fatalf("nil guard Check = %v, %v; want arguments unchanged", got, err)
+	}
+}
+
+func TestStreamGuardReject(t *testing.T) {
+	arguments := map[string]interface{}{"interval": "1s"}
+
+	if _, err := fakeStreamGuard(OverloadActionReject, 50).Check(context.Background(), "system_monitor_stream", arguments); err != nil {
+		t.Fatalf("Check below threshold: %v", err)
+	}
+
+	_, err := fakeStreamGuard(OverloadActionReject, 95).Check(context.Background(), "system_monitor_stream", arguments)
+	if !errors.Is(err, ErrHostOverloaded) {
+		t.Fatalf("Check above threshold error = %v, want ErrHostOverloaded", err)
+	}
+}
+
+func TestStreamGuardDegrade(t *testing.T) {
+	guard := fakeStreamGuard(OverloadActionDegrade, 95)
+
+	tests := []struct {
+		arguments map[string]interface{}
+		want      interface{}
+	}{
+		{map[string]interface{}{"interval": "1s"}, "10s"},
+		{map[string]interface{}{}, "10s"},
+		{map[string]interface{}{"interval": "30s"}, "30s"},
+		{map[string]interface{}{"interval": "bogus"}, "bogus"},
+	}
+	for _, tt := range tests {
+		got, err := guard.Check(context.Background(), "watch_process", tt.arguments)
+		if err != nil {
+			t.Fatalf("Check(%v): %v", tt.arguments, err)
+		}
+		if got["interval"] != tt.want {
+			t.Errorf("Check(%v) interval = %v, want %v", tt.arguments, got["interval"], tt.want)
+		}
+	}
+}
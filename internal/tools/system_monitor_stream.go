@@ -9,17 +9,54 @@ import (
 	"mcp-system-info/internal/sysinfo"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
-// SystemMonitorStreamHandler стримит системную информацию в реальном времени
-func SystemMonitorStreamHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// SystemMonitorStreamTool описание инструмента system_monitor_stream
+func SystemMonitorStreamTool() mcp.Tool {
+	return mcp.NewTool("system_monitor_stream",
+		mcp.WithDescription("Streams real-time system information: CPU and memory monitoring"),
+		mcp.WithString("duration",
+			mcp.Description("Monitoring duration (e.g., '30s', '5m')"),
+		),
+		mcp.WithString("interval",
+			mcp.Description("Update interval (e.g., '1s', '2s')"),
+		),
+		mcp.WithBoolean("verbose_samples",
+			mcp.Description("Repeat static host fields (CPU model, cores, total memory) in every sample instead of only once at start (default: false)"),
+		),
+		mcp.WithBoolean("align",
+			mcp.Description("Align samples to wall-clock interval boundaries (e.g. the top of each second for '1s'): the stream waits for the next boundary before sampling (default: false, relative to stream start)"),
+		),
+		mcp.WithString("timestamp_format",
+			mcp.Description("Sample timestamp format: 'short' (server local time, 15:04:05), 'rfc3339' (date, time and zone) or 'unix_ms' (default: server's MONITOR_TIMESTAMP_FORMAT)"),
+		),
+		mcp.WithString("output_file",
+			mcp.Description("Optional relative path (.csv or .jsonl) inside the server's MONITOR_OUTPUT_DIR to append each sample to"),
+		),
+	)
+}
+
+// NewSystemMonitorStreamHandler создает обработчик, сохраняющий сэмплы в файлы внутри outputDir,
+// ограничивающий число сэмплов одного вызова maxSamples и форматирующий время сэмплов в timestampFormat,
+// если клиент не передал timestamp_format
+func NewSystemMonitorStreamHandler(outputDir string, maxSamples int, timestampFormat string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return systemMonitorStream(ctx, request, outputDir, maxSamples, timestampFormat)
+	}
+}
+
+// systemMonitorStream стримит системную информацию в реальном времени
+func systemMonitorStream(ctx context.Context, request mcp.CallToolRequest, outputDir string, maxSamples int, defaultTimestampFormat string) (*mcp.CallToolResult, error) {
 	logger.Tools.Info().
 		Str("tool", "system_monitor_stream").
 		Msg("Starting real-time system monitoring stream")
 
 	// Получаем параметры из запроса
 	args := request.Params.Arguments
-	var durationStr, intervalStr string
+	var durationStr, intervalStr, outputFile string
+	verboseSamples := request.GetBool("verbose_samples", false)
+	align := request.GetBool("align", false)
 
 	if argsMap, ok := args.(map[string]interface{}); ok {
 		if dur, exists := argsMap["duration"]; exists {
@@ -32,6 +69,11 @@ func SystemMonitorStreamHandler(ctx context.Context, request mcp.CallToolRequest
 				intervalStr = interStr
 			}
 		}
+		if out, exists := argsMap["output_file"]; exists {
+			if outStr, ok := out.(string); ok {
+				outputFile = outStr
+			}
+		}
 	}
 
 	if durationStr == "" {
@@ -59,32 +101,94 @@ func SystemMonitorStreamHandler(ctx context.Context, request mcp.CallToolRequest
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid interval format: %v", err)), nil
 	}
 
+	if err := ValidateSampleCount(duration, interval, maxSamples); err != nil {
+		logger.Tools.Warn().
+			Err(err).
+			Dur("duration", duration).
+			Dur("interval", interval).
+			Msg("Rejected monitor stream window")
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid monitoring window: %v", err)), nil
+	}
+
+	timestampFormat, err := ParseTimestampFormat(request.GetString("timestamp_format", ""), defaultTimestampFormat)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var sampleWriter *SampleWriter
+	if outputFile != "" {
+		sampleWriter, err = OpenSampleWriter(outputDir, outputFile)
+		if err != nil {
+			logger.Tools.Error().
+				Err(err).
+				Str("output_file", outputFile).
+				Msg("Invalid output file")
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid output_file: %v", err)), nil
+		}
+		defer sampleWriter.Close()
+	}
+
 	logger.Tools.Info().
 		Dur("duration", duration).
 		Dur("interval", interval).
+		Str("output_file", outputFile).
+		Bool("align", align).
 		Msg("System monitoring stream configured")
 
+	// С align тикер запускается на границе интервала, и все сэмплы попадают на границы по часам
+	if align && !WaitAligned(ctx, interval) {
+		return mcp.NewToolResultText("❌ Stream cancelled by context\n"), nil
+	}
+
 	// Создаем буфер для накопления результатов
 	var streamResults []string
 	endTime := time.Now().Add(duration)
+	// steal в сэмплах считается от предыдущего сэмпла этого же стрима
+	steal := sysinfo.NewStealBaseline()
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	streamResults = append(streamResults, "🔄 System Monitor Stream Started\n")
 	streamResults = append(streamResults, fmt.Sprintf("⏱️  Duration: %v, Interval: %v\n", duration, interval))
+	if sampleWriter != nil {
+		streamResults = append(streamResults, fmt.Sprintf("📁 Writing samples to %s\n", outputFile))
+	}
+
+	// Статические данные хоста выводятся один раз в заголовке стрима
+	if !verboseSamples {
+		if host, err := sysinfo.GetHostSummary(ctx); err == nil {
+			streamResults = append(streamResults, fmt.Sprintf("🖥️  Host: %s (%d cores), %.1f GB memory\n",
+				sysinfo.TruncateModelName(host.CPUModel), host.CPUCount, float64(host.MemoryTotal)/(1024*1024*1024)))
+		}
+	}
 	streamResults = append(streamResults, "📊 Collecting data...\n\n")
 
+	// Если клиент может принимать уведомления (stdio), сэмплы отправляются по мере получения,
+	// а итоговый результат содержит только сводку
+	notifier := newSampleNotifier(ctx, request, int(duration/interval))
+	if notifier != nil {
+		streamResults = append(streamResults, "📡 Samples are sent as notifications\n")
+	}
+	var summary sampleSummary
+
+	clock := NewSampleClock(time.Now())
 	iteration := 0
 	for {
 		select {
 		case <-ctx.Done():
 			logger.Tools.Info().Msg("Context cancelled, stopping stream")
+			if notifier != nil {
+				streamResults = append(streamResults, summary.String())
+			}
 			streamResults = append(streamResults, "❌ Stream cancelled by context\n")
 			return mcp.NewToolResultText(joinResults(streamResults)), nil
 
 		case <-ticker.C:
 			if time.Now().After(endTime) {
 				logger.Tools.Info().Msg("Duration expired, stopping stream")
+				if notifier != nil {
+					streamResults = append(streamResults, summary.String())
+				}
 				streamResults = append(streamResults, "✅ Stream completed successfully\n")
 				return mcp.NewToolResultText(joinResults(streamResults)), nil
 			}
@@ -92,7 +196,7 @@ func SystemMonitorStreamHandler(ctx context.Context, request mcp.CallToolRequest
 			iteration++
 
 			// Получаем текущую системную информацию
-			sysInfo, err := sysinfo.Get()
+			sysInfo, err := sysinfo.GetWithOptions(sysinfo.Options{Steal: steal})
 			if err != nil {
 				logger.Tools.Error().
 					Err(err).
@@ -101,23 +205,47 @@ func SystemMonitorStreamHandler(ctx context.Context, request mcp.CallToolRequest
 				streamResults = append(streamResults, fmt.Sprintf("❌ Error at iteration %d: %v\n", iteration, err))
 				continue
 			}
+			actualInterval := clock.Mark(time.Now())
 
 			// Форматируем данные для стрима
-			timestamp := time.Now().Format("15:04:05")
-			streamData := fmt.Sprintf("📈 Sample #%d at %s:\n", iteration, timestamp)
-			streamData += fmt.Sprintf("  💻 CPU: %s (%d cores) - %.1f%% usage\n",
-				sysInfo.CPU.ModelName, sysInfo.CPU.Count, sysInfo.CPU.UsagePercent)
-			streamData += fmt.Sprintf("  🧠 Memory: %.1f GB used / %.1f GB total (%.1f%%)\n",
-				float64(sysInfo.Memory.Used)/(1024*1024*1024),
-				float64(sysInfo.Memory.Total)/(1024*1024*1024),
-				sysInfo.Memory.UsedPercent)
-			streamData += fmt.Sprintf("  💾 Available: %.1f GB\n\n",
-				float64(sysInfo.Memory.Available)/(1024*1024*1024))
-
-			streamResults = append(streamResults, streamData)
+			timestamp := FormatTimestamp(time.Now(), timestampFormat)
+			var streamData string
+			if verboseSamples {
+				streamData = fmt.Sprintf("📈 Sample #%d at %s (+%.0f ms):\n", iteration, timestamp, Milliseconds(actualInterval))
+				streamData += fmt.Sprintf("  💻 CPU: %s (%d cores) - %.1f%% usage\n",
+					sysinfo.TruncateModelName(sysInfo.CPU.ModelName), sysInfo.CPU.Count, sysInfo.CPU.UsagePercent)
+				streamData += fmt.Sprintf("  🧠 Memory: %.1f GB used / %.1f GB total (%.1f%%)\n",
+					float64(sysInfo.Memory.Used)/(1024*1024*1024),
+					float64(sysInfo.Memory.Total)/(1024*1024*1024),
+					sysInfo.Memory.UsedPercent)
+				streamData += fmt.Sprintf("  💾 Available: %.1f GB\n\n",
+					float64(sysInfo.Memory.Available)/(1024*1024*1024))
+			} else {
+				streamData = fmt.Sprintf("📈 #%d %s (+%.0f ms)  CPU %.1f%%  MEM %.1f GB (%.1f%%)\n",
+					iteration, timestamp, Milliseconds(actualInterval),
+					sysInfo.CPU.UsagePercent,
+					float64(sysInfo.Memory.Used)/(1024*1024*1024),
+					sysInfo.Memory.UsedPercent)
+			}
+
+			summary.add(sysInfo)
+			// Сэмпл, доставленный уведомлением, в итоговый результат не попадает
+			if notifier == nil || !notifier.send(iteration, streamData) {
+				streamResults = append(streamResults, streamData)
+			}
+
+			if sampleWriter != nil {
+				if err := sampleWriter.Write(NewSample(iteration, sysInfo, actualInterval)); err != nil {
+					logger.Tools.Error().
+						Err(err).
+						Int("iteration", iteration).
+						Msg("Failed to write sample to output file")
+				}
+			}
 
 			logger.Tools.Debug().
 				Int("iteration", iteration).
+				Dur("actual_interval", actualInterval).
 				Float64("cpu_usage", sysInfo.CPU.UsagePercent).
 				Float64("memory_usage", sysInfo.Memory.UsedPercent).
 				Msg("Stream data collected")
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ThermalStatusTool описание инструмента thermal_status
+func ThermalStatusTool() mcp.Tool {
+	return mcp.NewTool("thermal_status",
+		mcp.WithDescription("Reports temperature-based throttling risk: compares each sensor's current temperature to its high/critical thresholds, returns a per-sensor risk level, margin to critical and the overall worst case"),
+	)
+}
+
+// ThermalStatusHandler возвращает риск троттлинга по датчикам температуры
+func ThermalStatusHandler(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	logger.Tools.Debug().Msg("Getting thermal status")
+
+	status, err := sysinfo.GetThermalStatus(ctx)
+	if err != nil {
+		logger.Tools.Error().
+			Err(err).
+			Msg("Failed to get thermal status")
+		return mcp.NewToolResultError(fmt.Sprintf("Error getting thermal status: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(status.FormatText()), nil
+}
@@ -0,0 +1,286 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultConditionSustain сколько условие должно выполняться непрерывно по умолчанию
+	defaultConditionSustain = 30 * time.Second
+	// defaultConditionTimeout общее ограничение ожидания по умолчанию
+	defaultConditionTimeout = 10 * time.Minute
+	// defaultConditionInterval интервал сэмплов wait_for_condition по умолчанию
+	defaultConditionInterval = 2 * time.Second
+)
+
+// ConditionMetrics метрики, доступные в wait_for_condition
+var ConditionMetrics = []string{"cpu", "memory", "load1"}
+
+// ConditionOperators операторы сравнения метрики с порогом
+var ConditionOperators = []string{"above", "below"}
+
+// WaitForConditionTool описание инструмента wait_for_condition
+func WaitForConditionTool() mcp.Tool {
+	return mcp.NewTool("wait_for_condition",
+		mcp.WithDescription("Blocks, streaming progress, until a metric stays above or below a threshold for a sustained window (e.g. cpu above 90 for 30s), then returns the trigger time. Momentary spikes reset the window. Returns a timeout result if the condition is not sustained before timeout"),
+		mcp.WithString("metric",
+			mcp.Required(),
+			mcp.Description("Metric to watch: 'cpu' (usage %), 'memory' (used %) or 'load1' (1-minute load average, not available on Windows)"),
+			mcp.Enum(ConditionMetrics...),
+		),
+		mcp.WithString("operator",
+			mcp.Required(),
+			mcp.Description("'above' (metric > threshold) or 'below' (metric < threshold)"),
+			mcp.Enum(ConditionOperators...),
+		),
+		mcp.WithNumber("threshold",
+			mcp.Required(),
+			mcp.Description("Threshold value in the metric's units"),
+		),
+		mcp.WithString("sustain",
+			mcp.Description(fmt.Sprintf("How long the condition must hold continuously (default: %v)", defaultConditionSustain)),
+		),
+		mcp.WithString("timeout",
+			mcp.Description(fmt.Sprintf("Overall wait limit (default: %v)", defaultConditionTimeout)),
+		),
+		mcp.WithString("interval",
+			mcp.Description(fmt.Sprintf("Sample interval, must be shorter than sustain (default: %v)", defaultConditionInterval)),
+		),
+		mcp.WithString("timestamp_format",
+			mcp.Description("Timestamp format: 'short' (server local time, 15:04:05), 'rfc3339' (date, time and zone) or 'unix_ms' (default: server's MONITOR_TIMESTAMP_FORMAT)"),
+		),
+	)
+}
+
+// WaitForConditionArgs разобранные аргументы wait_for_condition
+type WaitForConditionArgs struct {
+	Metric          string
+	Operator        string
+	Threshold       float64
+	Sustain         time.Duration
+	Timeout         time.Duration
+	Interval        time.Duration
+	TimestampFormat string
+}
+
+// String описывает условие, например "cpu above 90 for 30s"
+func (a *WaitForConditionArgs) String() string {
+	return fmt.Sprintf("%s %s %g for %v", a.Metric, a.Operator, a.Threshold, a.Sustain)
+}
+
+// ParseWaitForConditionArgs разбирает и проверяет аргументы wait_for_condition, общие для stdio и SSE режимов
+func ParseWaitForConditionArgs(arguments map[string]interface{}, maxSamples int, defaultTimestampFormat string) (*WaitForConditionArgs, error) {
+	args := &WaitForConditionArgs{
+		Sustain:  defaultConditionSustain,
+		Timeout:  defaultConditionTimeout,
+		Interval: defaultConditionInterval,
+	}
+
+	args.Metric, _ = arguments["metric"].(string)
+	if !slices.Contains(ConditionMetrics, args.Metric) {
+		return nil, fmt.Errorf("invalid metric %q: must be one of %s", args.Metric, strings.Join(ConditionMetrics, ", "))
+	}
+	args.Operator, _ = arguments["operator"].(string)
+	if !slices.Contains(ConditionOperators, args.Operator) {
+		return nil, fmt.Errorf("invalid operator %q: must be one of %s", args.Operator, strings.Join(ConditionOperators, ", "))
+	}
+	threshold, ok := arguments["threshold"].(float64)
+	if !ok {
+		return nil, errors.New("threshold is required and must be a number")
+	}
+	args.Threshold = threshold
+
+	for _, field := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"sustain", &args.Sustain},
+		{"timeout", &args.Timeout},
+		{"interval", &args.Interval},
+	} {
+		value, _ := arguments[field.name].(string)
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %v", field.name, err)
+		}
+		*field.value = duration
+	}
+
+	if err := ValidateSampleCount(args.Sustain, args.Interval, maxSamples); err != nil {
+		return nil, fmt.Errorf("invalid sustain window: %v", err)
+	}
+	if err := ValidateSampleCount(args.Timeout, args.Interval, maxSamples); err != nil {
+		return nil, fmt.Errorf("invalid timeout: %v", err)
+	}
+	if args.Timeout < args.Sustain {
+		return nil, fmt.Errorf("timeout %v must not be shorter than sustain %v", args.Timeout, args.Sustain)
+	}
+
+	value, _ := arguments["timestamp_format"].(string)
+	timestampFormat, err := ParseTimestampFormat(value, defaultTimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+	args.TimestampFormat = timestampFormat
+
+	return args, nil
+}
+
+// ConditionMetricValue возвращает значение метрики из сэмпла, false если платформа ее не сообщает
+func ConditionMetricValue(info *sysinfo.SystemInfo, metric string) (float64, bool) {
+	switch metric {
+	case "cpu":
+		return info.CPU.UsagePercent, true
+	case "memory":
+		return info.Memory.UsedPercent, true
+	case "load1":
+		return info.Load.Load1, info.Load.Available
+	}
+	return 0, false
+}
+
+// ConditionTracker отслеживает, как долго условие выполняется непрерывно
+type ConditionTracker struct {
+	args  *WaitForConditionArgs
+	since time.Time
+}
+
+// NewConditionTracker создает трекер условия args
+func NewConditionTracker(args *WaitForConditionArgs) *ConditionTracker {
+	return &ConditionTracker{args: args}
+}
+
+// Observe учитывает сэмпл value в момент now. Возвращает, выполнено ли условие в этом сэмпле,
+// сколько оно уже держится и выдержано ли оно весь sustain. Невыполненное условие сбрасывает окно
+func (t *ConditionTracker) Observe(value float64, now time.Time) (holding bool, held time.Duration, triggered bool) {
+	holding = value > t.args.Threshold
+	if t.args.Operator == "below" {
+		holding = value < t.args.Threshold
+	}
+
+	if !holding {
+		t.since = time.Time{}
+		return false, 0, false
+	}
+	if t.since.IsZero() {
+		t.since = now
+	}
+
+	held = now.Sub(t.since)
+	return true, held, held >= t.args.Sustain
+}
+
+// Since возвращает начало текущего окна выполнения условия
+func (t *ConditionTracker) Since() time.Time {
+	return t.since
+}
+
+// NewWaitForConditionHandler создает обработчик wait_for_condition с ограничением числа сэмплов maxSamples
+// и форматом времени timestampFormat по умолчанию
+func NewWaitForConditionHandler(maxSamples int, timestampFormat string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := ParseWaitForConditionArgs(request.GetArguments(), maxSamples, timestampFormat)
+		if err != nil {
+			logger.Tools.Warn().
+				Err(err).
+				Msg("Rejected wait_for_condition arguments")
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return waitForCondition(ctx, request, args)
+	}
+}
+
+// waitForCondition собирает сэмплы до выполнения условия в течение Sustain или истечения Timeout
+func waitForCondition(ctx context.Context, request mcp.CallToolRequest, args *WaitForConditionArgs) (*mcp.CallToolResult, error) {
+	logger.Tools.Info().
+		Str("condition", args.String()).
+		Dur("timeout", args.Timeout).
+		Dur("interval", args.Interval).
+		Msg("Waiting for condition")
+
+	var results []string
+	results = append(results, fmt.Sprintf("⏳ Waiting for %s\n", args))
+	results = append(results, fmt.Sprintf("⏱️  Timeout: %v, Interval: %v\n", args.Timeout, args.Interval))
+
+	notifier := newSampleNotifier(ctx, request, int(args.Timeout/args.Interval))
+	if notifier != nil {
+		results = append(results, "📡 Progress is sent as notifications\n")
+	}
+	results = append(results, "\n")
+
+	tracker := NewConditionTracker(args)
+	endTime := time.Now().Add(args.Timeout)
+	steal := sysinfo.NewStealBaseline()
+	ticker := time.NewTicker(args.Interval)
+	defer ticker.Stop()
+
+	iteration := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Tools.Info().
+				Str("condition", args.String()).
+				Msg("Context cancelled, stopping condition wait")
+			results = append(results, "❌ Wait cancelled by context\n")
+			return mcp.NewToolResultText(joinResults(results)), nil
+
+		case <-ticker.C:
+			if time.Now().After(endTime) {
+				logger.Tools.Info().
+					Str("condition", args.String()).
+					Int("total_samples", iteration).
+					Msg("Condition wait timed out")
+				results = append(results, fmt.Sprintf("⌛ Timeout: %s was not sustained within %v (%d samples)\n", args, args.Timeout, iteration))
+				return mcp.NewToolResultText(joinResults(results)), nil
+			}
+
+			iteration++
+			info, err := sysinfo.GetWithOptions(sysinfo.Options{Steal: steal})
+			if err != nil {
+				results = append(results, fmt.Sprintf("❌ Error at iteration %d: %v\n", iteration, err))
+				continue
+			}
+			value, ok := ConditionMetricValue(info, args.Metric)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("Metric %s is not available on this platform", args.Metric)), nil
+			}
+
+			now := time.Now()
+			holding, held, triggered := tracker.Observe(value, now)
+			if triggered {
+				logger.Tools.Info().
+					Str("condition", args.String()).
+					Time("since", tracker.Since()).
+					Int("total_samples", iteration).
+					Msg("Condition sustained")
+				results = append(results, fmt.Sprintf("🚨 Condition met: %s since %s (triggered at %s, %d samples)\n",
+					args, FormatTimestamp(tracker.Since(), args.TimestampFormat), FormatTimestamp(now, args.TimestampFormat), iteration))
+				return mcp.NewToolResultText(joinResults(results)), nil
+			}
+
+			state := "not met"
+			if holding {
+				state = fmt.Sprintf("holding for %v / %v", held.Round(time.Second), args.Sustain)
+			}
+			line := fmt.Sprintf("📈 #%d %s  %s %.2f (%s)\n", iteration, FormatTimestamp(now, args.TimestampFormat), args.Metric, value, state)
+			if notifier == nil || !notifier.send(iteration, line) {
+				results = append(results, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWaitForConditionArgs(t *testing.T) {
+	base := func(extra map[string]interface{}) map[string]interface{} {
+		arguments := map[string]interface{}{"metric": "cpu", "operator": "above", "threshold": float64(90)}
+		for k, v := range extra {
+			arguments[k] = v
+		}
+		return arguments
+	}
+
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		wantErr   string
+	}{
+		{name: "defaults", arguments: base(nil)},
+		{name: "unknown metric", arguments: base(map[string]interface{}{"metric": "disk"}), wantErr: "invalid metric"},
+		{name: "unknown operator", arguments: base(map[string]interface{}{"operator": "equals"}), wantErr: "invalid operator"},
+		{name: "missing threshold", arguments: map[string]interface{}{"metric": "cpu", "operator": "above"}, wantErr: "threshold is required"},
+		{name: "bad sustain", arguments: base(map[string]interface{}{"sustain": "soon"}), wantErr: "invalid sustain format"},
+		{name: "interval not shorter than sustain", arguments: base(map[string]interface{}{"sustain": "2s", "interval": "2s"}), wantErr: "must be shorter than duration"},
+		{name: "timeout shorter than sustain", arguments: base(map[string]interface{}{"sustain": "1m", "timeout": "30s"}), wantErr: "must not be shorter than sustain"},
+		{name: "too many samples", arguments: base(map[string]interface{}{"timeout": "1h", "interval": "1s"}), wantErr: "more than the allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := ParseWaitForConditionArgs(tt.arguments, 1000, TimestampShort)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if args.Sustain != defaultConditionSustain || args.Timeout != defaultConditionTimeout {
+					t.Fatalf("defaults = %v/%v, want %v/%v", args.Sustain, args.Timeout, defaultConditionSustain, defaultConditionTimeout)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConditionTrackerResetsOnBreak(t *testing.T) {
+	args := &WaitForConditionArgs{Metric: "cpu", Operator: "above", Threshold: 90, Sustain: 10 * time.Second}
+	tracker := NewConditionTracker(args)
+	start := time.Date(2025, 3, 26, 14, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		offset        time.Duration
+		value         float64
+		wantHolding   bool
+		wantTriggered bool
+	}{
+		{0, 95, true, false},
+		{5 * time.Second, 96, true, false},
+		// кратковременный провал сбрасывает окно
+		{8 * time.Second, 50, false, false},
+		{10 * time.Second, 91, true, false},
+		{15 * time.Second, 90, false, false},
+		{16 * time.Second, 99, true, false},
+		{26 * time.Second, 99, true, true},
+	}
+
+	for i, step := range steps {
+		holding, _, triggered := tracker.Observe(step.value, start.Add(step.offset))
+		if holding != step.wantHolding || triggered != step.wantTriggered {
+			t.Fatalf("step %d: holding=%v triggered=%v, want %v/%v", i, holding, triggered, step.wantHolding, step.wantTriggered)
+		}
+	}
+	if got := tracker.Since(); !got.Equal(start.Add(16 * time.Second)) {
+		t.Fatalf("since = %v, want %v", got, start.Add(16*time.Second))
+	}
+}
+
+func TestConditionTrackerBelow(t *testing.T) {
+	args := &WaitForConditionArgs{Metric: "memory", Operator: "below", Threshold: 20, Sustain: time.Second}
+	tracker := NewConditionTracker(args)
+	now := time.Now()
+
+	if holding, _, _ := tracker.Observe(25, now); holding {
+		t.Fatal("25 below 20 must not hold")
+	}
+	tracker.Observe(10, now)
+	if _, held, triggered := tracker.Observe(15, now.Add(time.Second)); !triggered || held != time.Second {
+		t.Fatalf("triggered=%v held=%v, want true/1s", triggered, held)
+	}
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"mcp-system-info/internal/logger"
+	"mcp-system-info/internal/sysinfo"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultWatchMaxDuration ограничение наблюдения по умолчанию, если процесс не завершается
+	defaultWatchMaxDuration = 5 * time.Minute
+	// defaultWatchInterval интервал сэмплов watch_process по умолчанию
+	defaultWatchInterval = 2 * time.Second
+)
+
+// WatchProcessTool описание инструмента watch_process
+func WatchProcessTool() mcp.Tool {
+	return mcp.NewTool("watch_process",
+		mcp.WithDescription("Streams CPU and memory samples of one process until it exits or max_duration passes, then reports whether it exited"),
+		mcp.WithNumber("pid",
+			mcp.Required(),
+			mcp.Description("PID of the process to watch"),
+		),
+		mcp.WithString("max_duration",
+			mcp.Description(fmt.Sprintf("Maximum watch duration if the process keeps running (default: %v)", defaultWatchMaxDuration)),
+		),
+		mcp.WithString("interval",
+			mcp.Description(fmt.Sprintf("Sample interval (default: %v)", defaultWatchInterval)),
+		),
+		mcp.WithString("timestamp_format",
+			mcp.Description("Sample timestamp format: 'short' (server local time, 15:04:05), 'rfc3339' (date, time and zone) or 'unix_ms' (default: server's MONITOR_TIMESTAMP_FORMAT)"),
+		),
+	)
+}
+
+// WatchProcessArgs разобранные аргументы watch_process
+type WatchProcessArgs struct {
+	PID             int32
+	MaxDuration     time.Duration
+	Interval        time.Duration
+	TimestampFormat string
+}
+
+// ParseWatchProcessArgs разбирает и проверяет аргументы watch_process, общие для stdio и SSE режимов
+func ParseWatchProcessArgs(arguments map[string]interface{}, maxSamples int, defaultTimestampFormat string) (*WatchProcessArgs, error) {
+	rawPID, ok := arguments["pid"].(float64)
+	if !ok {
+		return nil, errors.New("pid is required and must be a number")
+	}
+	if rawPID <= 0 || rawPID > math.MaxInt32 || rawPID != math.Trunc(rawPID) {
+		return nil, fmt.Errorf("invalid pid %v: must be a positive integer", rawPID)
+	}
+
+	args := &WatchProcessArgs{
+		PID:         int32(rawPID),
+		MaxDuration: defaultWatchMaxDuration,
+		Interval:    defaultWatchInterval,
+	}
+
+	if value, _ := arguments["max_duration"].(string); value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_duration format: %v", err)
+		}
+		args.MaxDuration = duration
+	}
+	if value, _ := arguments["interval"].(string); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval format: %v", err)
+		}
+		args.Interval = interval
+	}
+
+	if err := ValidateSampleCount(args.MaxDuration, args.Interval, maxSamples); err != nil {
+		return nil, fmt.Errorf("invalid watch window: %v", err)
+	}
+
+	value, _ := arguments["timestamp_format"].(string)
+	timestampFormat, err := ParseTimestampFormat(value, defaultTimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+	args.TimestampFormat = timestampFormat
+
+	return args, nil
+}
+
+// NewWatchProcessHandler создает обработчик watch_process с ограничением числа сэмплов maxSamples
+// и форматом времени сэмплов timestampFormat по умолчанию
+func NewWatchProcessHandler(maxSamples int, timestampFormat string) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, err := ParseWatchProcessArgs(request.GetArguments(), maxSamples, timestampFormat)
+		if err != nil {
+			logger.Tools.Warn().
+				Err(err).
+				Msg("Rejected watch_process arguments")
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return watchProcess(ctx, request, args)
+	}
+}
+
+// watchProcess собирает сэмплы процесса до его завершения или истечения MaxDuration
+func watchProcess(ctx context.Context, request mcp.CallToolRequest, args *WatchProcessArgs) (*mcp.CallToolResult, error) {
+	watcher, err := sysinfo.NewProcessWatcher(ctx, args.PID)
+	if errors.Is(err, sysinfo.ErrProcessExited) {
+		return mcp.NewToolResultText(fmt.Sprintf("🏁 Process %d has already exited\n", args.PID)), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Error watching process %d: %v", args.PID, err)), nil
+	}
+
+	logger.Tools.Info().
+		Int32("pid", args.PID).
+		Str("name", watcher.Name()).
+		Dur("max_duration", args.MaxDuration).
+		Dur("interval", args.Interval).
+		Msg("Starting process watch")
+
+	var results []string
+	results = append(results, fmt.Sprintf("👀 Watching process %d (%s)\n", args.PID, watcher.Name()))
+	results = append(results, fmt.Sprintf("⏱️  Max duration: %v, Interval: %v\n", args.MaxDuration, args.Interval))
+
+	notifier := newSampleNotifier(ctx, request, int(args.MaxDuration/args.Interval))
+	if notifier != nil {
+		results = append(results, "📡 Samples are sent as notifications\n")
+	}
+	results = append(results, "\n")
+
+	endTime := time.Now().Add(args.MaxDuration)
+	ticker := time.NewTicker(args.Interval)
+	defer ticker.Stop()
+
+	iteration := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Tools.Info().
+				Int32("pid", args.PID).
+				Msg("Context cancelled, stopping process watch")
+			results = append(results, "❌ Watch cancelled by context\n")
+			return mcp.NewToolResultText(joinResults(results)), nil
+
+		case <-ticker.C:
+			if time.Now().After(endTime) {
+				results = append(results, fmt.Sprintf("⏱️  Max duration reached after %d samples, process %d is still running\n", iteration, args.PID))
+				return mcp.NewToolResultText(joinResults(results)), nil
+			}
+
+			sample, err := watcher.Sample(ctx)
+			if errors.Is(err, sysinfo.ErrProcessExited) {
+				logger.Tools.Info().
+					Int32("pid", args.PID).
+					Int("total_samples", iteration).
+					Msg("Watched process exited")
+				results = append(results, fmt.Sprintf("🏁 Process %d exited after %d samples\n", args.PID, iteration))
+				return mcp.NewToolResultText(joinResults(results)), nil
+			}
+
+			iteration++
+			if err != nil {
+				logger.Tools.Error().
+					Err(err).
+					Int32("pid", args.PID).
+					Int("iteration", iteration).
+					Msg("Failed to sample watched process")
+				results = append(results, fmt.Sprintf("❌ Error at iteration %d: %v\n", iteration, err))
+				continue
+			}
+
+			line := fmt.Sprintf("📈 #%d %s  CPU %.1f%%  RSS %s (%.1f%%)\n",
+				iteration, FormatTimestamp(time.Now(), args.TimestampFormat),
+				sample.CPUPercent, sysinfo.FormatBytes(int64(sample.RSS)), sample.MemoryPercent)
+			if notifier == nil || !notifier.send(iteration, line) {
+				results = append(results, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseWatchProcessArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		wantErr   string
+	}{
+		{name: "defaults", arguments: map[string]interface{}{"pid": float64(1)}},
+		{name: "missing pid", arguments: map[string]interface{}{}, wantErr: "pid is required"},
+		{name: "negative pid", arguments: map[string]interface{}{"pid": float64(-1)}, wantErr: "invalid pid"},
+		{name: "fractional pid", arguments: map[string]interface{}{"pid": 1.5}, wantErr: "invalid pid"},
+		{name: "negative max_duration", arguments: map[string]interface{}{"pid": float64(1), "max_duration": "-5s"}, wantErr: "duration must be positive"},
+		{name: "zero interval", arguments: map[string]interface{}{"pid": float64(1), "interval": "0s"}, wantErr: "interval must be positive"},
+		{name: "too many samples", arguments: map[string]interface{}{"pid": float64(1), "max_duration": "1h", "interval": "1s"}, wantErr: "more than the allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWatchProcessArgs(tt.arguments, 1000, TimestampShort)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// runWatch вызывает watch_process для pid и возвращает текст результата
+func runWatch(t *testing.T, pid int, maxDuration, interval string) string {
+	t.Helper()
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]interface{}{
+		"pid":          float64(pid),
+		"max_duration": maxDuration,
+		"interval":     interval,
+	}
+
+	result, err := NewWatchProcessHandler(1000, TimestampShort)(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text, _ := result.Content[0].(mcp.TextContent)
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", text.Text)
+	}
+	return text.Text
+}
+
+func TestWatchProcessAlreadyExited(t *testing.T) {
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot run helper process: %v", err)
+	}
+
+	if text := runWatch(t, cmd.Process.Pid, "5s", "100ms"); !strings.Contains(text, "already exited") {
+		t.Fatalf("result = %q, want already exited note", text)
+	}
+}
+
+func TestWatchProcessUntilExit(t *testing.T) {
+	cmd := exec.Command("sleep", "0.5")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot start helper process: %v", err)
+	}
+	// Wait забирает статус завершения, чтобы процесс не остался zombie
+	go cmd.Wait()
+
+	text := runWatch(t, cmd.Process.Pid, "5s", "100ms")
+	if !strings.Contains(text, "exited after") {
+		t.Fatalf("result = %q, want exit note", text)
+	}
+	if !strings.Contains(text, "📈 #1") {
+		t.Fatalf("result = %q, want at least one sample", text)
+	}
+}
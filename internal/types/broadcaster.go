@@ -0,0 +1,151 @@
+package types
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mcp-system-info/internal/logger"
+)
+
+// SampleFunc собирает одно сообщение для рассылки подписчикам
+type SampleFunc func() (interface{}, error)
+
+// subscription подписка сессии, одна сессия может подписаться из нескольких потоков
+type subscription struct {
+	session *Session
+	streams int
+}
+
+// Broadcaster общий фоновый сэмплер: один сбор метрик за интервал рассылается всем подписанным
+// сессиям через их буфер уведомлений. Сэмплер работает, только пока есть хотя бы один подписчик
+type Broadcaster struct {
+	interval time.Duration
+	sample   SampleFunc
+
+	mu            sync.Mutex
+	subscriptions map[string]*subscription
+	cancel        context.CancelFunc
+
+	collections atomic.Uint64
+}
+
+// NewBroadcaster создает рассылку сэмплов sample с периодом interval
+func NewBroadcaster(interval time.Duration, sample SampleFunc) *Broadcaster {
+	return &Broadcaster{
+		interval:      interval,
+		sample:        sample,
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+// Subscribe подписывает сессию на рассылку, первый подписчик запускает сэмплер
+func (b *Broadcaster) Subscribe(session *Session) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sub, exists := b.subscriptions[session.ID]; exists {
+		sub.streams++
+		return
+	}
+	b.subscriptions[session.ID] = &subscription{session: session, streams: 1}
+
+	logger.Session.Debug().
+		Str("session_id", session.ID).
+		Int("subscribers", len(b.subscriptions)).
+		Msg("Session subscribed to metrics broadcast")
+
+	if b.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		b.cancel = cancel
+		go b.run(ctx)
+	}
+}
+
+// Unsubscribe отменяет подписку потока сессии, последний отписавшийся останавливает сэмплер
+func (b *Broadcaster) Unsubscribe(session *Session) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, exists := b.subscriptions[session.ID]
+	if !exists {
+		return
+	}
+	if sub.streams--; sub.streams > 0 {
+		return
+	}
+	delete(b.subscriptions, session.ID)
+
+	logger.Session.Debug().
+		Str("session_id", session.ID).
+		Int("subscribers", len(b.subscriptions)).
+		Msg("Session unsubscribed from metrics broadcast")
+
+	if len(b.subscriptions) == 0 && b.cancel != nil {
+		b.cancel()
+		b.cancel = nil
+	}
+}
+
+// Subscribers возвращает число подписанных сессий
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscriptions)
+}
+
+// Collections возвращает число выполненных сборов метрик
+func (b *Broadcaster) Collections() uint64 {
+	return b.collections.Load()
+}
+
+// run собирает сэмпл раз в интервал и кладет его в буфер каждой подписанной сессии
+func (b *Broadcaster) run(ctx context.Context) {
+	logger.Session.Info().
+		Dur("interval", b.interval).
+		Msg("Metrics broadcaster started")
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Session.Info().Msg("Metrics broadcaster stopped: no subscribers")
+			return
+
+		case <-ticker.C:
+			sessions := b.snapshot()
+			if len(sessions) == 0 {
+				continue
+			}
+
+			message, err := b.sample()
+			b.collections.Add(1)
+			if err != nil {
+				logger.Session.Error().
+					Err(err).
+					Int("subscribers", len(sessions)).
+					Msg("Failed to collect broadcast sample")
+				continue
+			}
+
+			for _, session := range sessions {
+				session.Notify(message)
+			}
+		}
+	}
+}
+
+// snapshot возвращает подписанные сессии, чтобы рассылка шла без удержания блокировки
+func (b *Broadcaster) snapshot() []*Session {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sessions := make([]*Session, 0, len(b.subscriptions))
+	for _, sub := range b.subscriptions {
+		sessions = append(sessions, sub.session)
+	}
+	return sessions
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBroadcasterFansOutOneCollection(t *testing.T) {
+	var calls atomic.Int64
+	b := NewBroadcaster(10*time.Millisecond, func() (interface{}, error) {
+		return calls.Add(1), nil
+	})
+
+	first, second := NewSession("first"), NewSession("second")
+	b.Subscribe(first)
+	b.Subscribe(second)
+
+	// Оба подписчика получают результат одного и того же, первого сбора
+	for _, session := range []*Session{first, second} {
+		select {
+		case message := <-session.Notifications():
+			if message != int64(1) {
+				t.Fatalf("session %s received sample %v, want the first collection", session.ID, message)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("session %s received no broadcast sample", session.ID)
+		}
+	}
+
+	b.Unsubscribe(first)
+	b.Unsubscribe(second)
+	if b.Subscribers() != 0 {
+		t.Fatalf("subscribers = %d after unsubscribe, want 0", b.Subscribers())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	stopped := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	if calls.Load() != stopped {
+		t.Fatal("sampler kept collecting without subscribers")
+	}
+}
+
+func TestBroadcasterCountsStreamsPerSession(t *testing.T) {
+	b := NewBroadcaster(time.Hour, func() (interface{}, error) { return nil, nil })
+	session := NewSession("session")
+
+	b.Subscribe(session)
+	b.Subscribe(session)
+	b.Unsubscribe(session)
+	if b.Subscribers() != 1 {
+		t.Fatalf("subscribers = %d with one stream still open, want 1", b.Subscribers())
+	}
+
+	b.Unsubscribe(session)
+	if b.Subscribers() != 0 {
+		t.Fatalf("subscribers = %d after last stream closed, want 0", b.Subscribers())
+	}
+}
@@ -1,33 +1,324 @@
 package types
 
 import (
+	"context"
 	"crypto/rand"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"mcp-system-info/internal/logger"
 )
 
+// OverflowPolicy определяет поведение при заполненном буфере уведомлений сессии
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest вытесняет самое старое сообщение из буфера
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowDropNewest отбрасывает новое сообщение
+	OverflowDropNewest OverflowPolicy = "drop-newest"
+	// OverflowBlock ждет освобождения места не дольше BlockTimeout
+	OverflowBlock OverflowPolicy = "block"
+)
+
+// SessionConfig конфигурация буфера уведомлений сессий
+type SessionConfig struct {
+	// BufferSize размер канала уведомлений сессии
+	BufferSize int
+	// OverflowPolicy поведение при заполненном канале
+	OverflowPolicy OverflowPolicy
+	// BlockTimeout максимальное ожидание для политики block
+	BlockTimeout time.Duration
+	// EventMaxAge максимальный возраст событий, хранимых для возобновления потока, 0 - без ограничения
+	EventMaxAge time.Duration
+}
+
+// DefaultSessionConfig возвращает конфигурацию сессий по умолчанию
+func DefaultSessionConfig() SessionConfig {
+	return SessionConfig{
+		BufferSize:     100,
+		OverflowPolicy: OverflowDropOldest,
+		BlockTimeout:   time.Second,
+		EventMaxAge:    time.Minute,
+	}
+}
+
+// NotificationStats снимок счетчиков уведомлений
+type NotificationStats struct {
+	Enqueued  uint64 `json:"enqueued"`
+	Delivered uint64 `json:"delivered"`
+	Dropped   uint64 `json:"dropped"`
+}
+
+// notificationCounters атомарные счетчики уведомлений
+type notificationCounters struct {
+	enqueued  atomic.Uint64
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+// snapshot возвращает текущие значения счетчиков
+func (c *notificationCounters) snapshot() NotificationStats {
+	return NotificationStats{
+		Enqueued:  c.enqueued.Load(),
+		Delivered: c.delivered.Load(),
+		Dropped:   c.dropped.Load(),
+	}
+}
+
+// maxTimelineEntries ограничивает длину хронологии событий сессии
+const maxTimelineEntries = 32
+
+// TimelineEntry заметное событие в хронологии сессии
+type TimelineEntry struct {
+	At     time.Time
+	Event  string
+	Detail string
+}
+
 // Session представляет сессию MCP
 type Session struct {
 	ID           string
 	CreatedAt    time.Time
 	LastActivity time.Time
-	Initialized  bool // Флаг что клиент отправил notifications/initialized
+	Initialized  bool   // Флаг что клиент отправил notifications/initialized
+	ClientType   string // Тип клиента (cursor, n8n, curl...), определенный при initialize
 	mu           sync.RWMutex
+
+	notifications  chan interface{}
+	overflowPolicy OverflowPolicy
+	blockTimeout   time.Duration
+	counters       notificationCounters
+	totals         *notificationCounters // Глобальные счетчики менеджера, может быть nil
+	activeStreams  atomic.Int64
+
+	timeline        []TimelineEntry
+	timelineDropped int
+
+	// history последние отправленные события для возобновления потока по Last-Event-Id,
+	// ID событий идут подряд, последнее имеет lastEventID
+	history       []SessionEvent
+	historySize   int
+	historyMaxAge time.Duration
+	lastEventID   uint64
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-// NewSession создает новую сессию
+// NewSession создает новую сессию с конфигурацией по умолчанию
 func NewSession(id string) *Session {
+	return NewSessionWithConfig(id, DefaultSessionConfig())
+}
+
+// NewSessionWithConfig создает новую сессию с настраиваемым буфером уведомлений
+func NewSessionWithConfig(id string, config SessionConfig) *Session {
 	logger.Session.Debug().
 		Str("session_id", id).
+		Int("buffer_size", config.BufferSize).
+		Str("overflow_policy", string(config.OverflowPolicy)).
 		Msg("Creating new session")
 
 	return &Session{
-		ID:           id,
-		CreatedAt:    time.Now(),
-		LastActivity: time.Now(),
+		ID:             id,
+		CreatedAt:      time.Now(),
+		LastActivity:   time.Now(),
+		notifications:  make(chan interface{}, config.BufferSize),
+		overflowPolicy: config.OverflowPolicy,
+		blockTimeout:   config.BlockTimeout,
+		historySize:    config.BufferSize,
+		historyMaxAge:  config.EventMaxAge,
+		done:           make(chan struct{}),
+	}
+}
+
+// Notify ставит уведомление в очередь сессии согласно политике переполнения.
+// Возвращает false если новое сообщение было отброшено
+func (s *Session) Notify(message interface{}) bool {
+	select {
+	case s.notifications <- message:
+		s.recordEnqueue()
+		return true
+	default:
 	}
+
+	switch s.overflowPolicy {
+	case OverflowDropOldest:
+		// Вытесняем самое старое сообщение и повторяем попытку
+		select {
+		case <-s.notifications:
+			s.recordDrop()
+		default:
+		}
+		select {
+		case s.notifications <- message:
+			s.recordEnqueue()
+			return true
+		default:
+			s.recordDrop()
+			return false
+		}
+
+	case OverflowBlock:
+		timer := time.NewTimer(s.blockTimeout)
+		defer timer.Stop()
+		select {
+		case s.notifications <- message:
+			s.recordEnqueue()
+			return true
+		case <-timer.C:
+			s.recordDrop()
+			return false
+		}
+
+	default:
+		s.recordDrop()
+		return false
+	}
+}
+
+// Notifications возвращает канал уведомлений для доставки клиенту
+func (s *Session) Notifications() <-chan interface{} {
+	return s.notifications
+}
+
+// MarkDelivered учитывает уведомление, успешно отправленное клиенту
+func (s *Session) MarkDelivered() {
+	s.counters.delivered.Add(1)
+	if s.totals != nil {
+		s.totals.delivered.Add(1)
+	}
+}
+
+// SessionEvent отправленное клиенту событие с его SSE id
+type SessionEvent struct {
+	ID   uint64
+	At   time.Time
+	Data []byte
+}
+
+// RetainEvent присваивает событию следующий SSE id и сохраняет его для возобновления потока.
+// Хранятся последние BufferSize событий не старше EventMaxAge
+func (s *Session) RetainEvent(data []byte) uint64 {
+	return s.retainEvent(data, time.Now())
+}
+
+func (s *Session) retainEvent(data []byte, now time.Time) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastEventID++
+	if s.historySize > 0 {
+		if len(s.history) >= s.historySize {
+			s.history = s.history[1:]
+		}
+		s.history = append(s.history, SessionEvent{ID: s.lastEventID, At: now, Data: data})
+	}
+	s.history = s.history[s.expiredEvents(now):]
+
+	return s.lastEventID
+}
+
+// expiredEvents возвращает число устаревших событий в начале истории. Вызывается под s.mu
+func (s *Session) expiredEvents(now time.Time) int {
+	if s.historyMaxAge <= 0 {
+		return 0
+	}
+	cutoff := now.Add(-s.historyMaxAge)
+	expired := 0
+	for expired < len(s.history) && s.history[expired].At.Before(cutoff) {
+		expired++
+	}
+	return expired
+}
+
+// EventsAfter возвращает сохраненные события после lastID. false означает разрыв: часть событий
+// после lastID уже вытеснена из истории по размеру или возрасту (или lastID этой сессии неизвестен),
+// и клиенту нужен полный снимок
+func (s *Session) EventsAfter(lastID uint64) ([]SessionEvent, bool) {
+	return s.eventsAfter(lastID, time.Now())
+}
+
+func (s *Session) eventsAfter(lastID uint64, now time.Time) ([]SessionEvent, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if lastID > s.lastEventID {
+		return nil, false
+	}
+	if lastID == s.lastEventID {
+		return nil, true
+	}
+
+	// Устаревшие события удаляются только при сохранении нового, поэтому при чтении они пропускаются
+	history := s.history[s.expiredEvents(now):]
+	oldest := s.lastEventID - uint64(len(history)) + 1
+	if lastID+1 < oldest {
+		return nil, false
+	}
+
+	return slices.Clone(history[lastID+1-oldest:]), true
+}
+
+// OldestEventID возвращает id самого старого сохраненного события, 0 если история пуста
+func (s *Session) OldestEventID() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.history[s.expiredEvents(time.Now()):]
+	if len(history) == 0 {
+		return 0
+	}
+	return history[0].ID
+}
+
+// NotificationStats возвращает счетчики уведомлений сессии
+func (s *Session) NotificationStats() NotificationStats {
+	return s.counters.snapshot()
+}
+
+// StreamStarted учитывает открытый поток сессии (и его горутину)
+func (s *Session) StreamStarted() {
+	s.activeStreams.Add(1)
+}
+
+// StreamFinished учитывает закрытие потока сессии
+func (s *Session) StreamFinished() {
+	s.activeStreams.Add(-1)
+}
+
+// ActiveStreams возвращает количество открытых потоков сессии
+func (s *Session) ActiveStreams() int64 {
+	return s.activeStreams.Load()
+}
+
+// recordEnqueue учитывает уведомление, поставленное в очередь
+func (s *Session) recordEnqueue() {
+	s.counters.enqueued.Add(1)
+	if s.totals != nil {
+		s.totals.enqueued.Add(1)
+	}
+}
+
+// recordDrop учитывает отброшенное уведомление
+func (s *Session) recordDrop() {
+	dropped := s.counters.dropped.Add(1)
+	if s.totals != nil {
+		s.totals.dropped.Add(1)
+	}
+
+	logger.Session.Warn().
+		Str("session_id", s.ID).
+		Str("client_type", s.GetClientType()).
+		Str("overflow_policy", string(s.overflowPolicy)).
+		Int("buffer_size", cap(s.notifications)).
+		Uint64("dropped_total", dropped).
+		Msg("Session notification buffer overflow, message dropped")
 }
 
 // UpdateActivity обновляет время последней активности
@@ -60,30 +351,117 @@ func (s *Session) IsInitialized() bool {
 	return s.Initialized
 }
 
-// Close закрывает сессию
+// SetClientType сохраняет тип клиента, открывшего сессию
+func (s *Session) SetClientType(clientType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ClientType = clientType
+}
+
+// GetClientType возвращает тип клиента сессии или "unknown", если он не определен
+func (s *Session) GetClientType() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ClientType == "" {
+		return "unknown"
+	}
+	return s.ClientType
+}
+
+// RecordEvent добавляет событие в хронологию сессии. При переполнении отбрасываются самые старые
+// события, кроме первого, чтобы в итоге оставались начало сессии и ее последние действия
+func (s *Session) RecordEvent(event, detail string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.timeline) >= maxTimelineEntries {
+		s.timeline = append(s.timeline[:1], s.timeline[2:]...)
+		s.timelineDropped++
+	}
+	s.timeline = append(s.timeline, TimelineEntry{At: time.Now(), Event: event, Detail: detail})
+}
+
+// timelineSummary возвращает компактную хронологию: смещение от создания сессии, событие и детали
+func (s *Session) timelineSummary() ([]string, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	summary := make([]string, 0, len(s.timeline))
+	for _, entry := range s.timeline {
+		line := fmt.Sprintf("+%v %s", entry.At.Sub(s.CreatedAt).Round(time.Millisecond), entry.Event)
+		if entry.Detail != "" {
+			line += " " + entry.Detail
+		}
+		summary = append(summary, line)
+	}
+	return summary, s.timelineDropped
+}
+
+// Done закрывается при завершении сессии, открытые потоки сессии должны завершиться
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close закрывает сессию, завершает ее потоки и логирует хронологию ее событий
 func (s *Session) Close() {
+	s.closeOnce.Do(func() { close(s.done) })
+
+	timeline, dropped := s.timelineSummary()
+
 	logger.Session.Info().
 		Str("session_id", s.ID).
+		Str("client_type", s.GetClientType()).
 		Time("created_at", s.CreatedAt).
 		Time("last_activity", s.LastActivity).
 		Bool("was_initialized", s.Initialized).
+		Interface("notifications", s.NotificationStats()).
 		Dur("session_duration", time.Since(s.CreatedAt)).
+		Strs("timeline", timeline).
+		Int("timeline_dropped", dropped).
 		Msg("Closing session")
 }
 
 // SessionManager управляет сессиями
 type SessionManager struct {
 	sessions map[string]*Session
-	mu       sync.RWMutex
+	config   SessionConfig
+	totals   notificationCounters
+	// generateID выдает ID новых сессий. По умолчанию generateSessionID, тесты подменяют
+	// его через SetIDGenerator детерминированной последовательностью
+	generateID func() string
+	mu         sync.RWMutex
 }
 
-// NewSessionManager создает новый менеджер сессий
+// NewSessionManager создает новый менеджер сессий с конфигурацией по умолчанию
 func NewSessionManager() *SessionManager {
-	logger.Session.Info().Msg("Creating new session manager")
+	return NewSessionManagerWithConfig(DefaultSessionConfig())
+}
+
+// NewSessionManagerWithConfig создает новый менеджер сессий с настраиваемой конфигурацией
+func NewSessionManagerWithConfig(config SessionConfig) *SessionManager {
+	logger.Session.Info().
+		Int("buffer_size", config.BufferSize).
+		Str("overflow_policy", string(config.OverflowPolicy)).
+		Dur("block_timeout", config.BlockTimeout).
+		Dur("event_max_age", config.EventMaxAge).
+		Msg("Creating new session manager")
 
 	return &SessionManager{
-		sessions: make(map[string]*Session),
+		sessions:   make(map[string]*Session),
+		config:     config,
+		generateID: generateSessionID,
+	}
+}
+
+// SetIDGenerator подменяет генератор ID сессий. nil возвращает генератор по умолчанию
+func (sm *SessionManager) SetIDGenerator(generate func() string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if generate == nil {
+		generate = generateSessionID
 	}
+	sm.generateID = generate
 }
 
 // CreateSession создает новую сессию
@@ -91,8 +469,16 @@ func (sm *SessionManager) CreateSession() string {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	sessionID := generateSessionID()
-	session := NewSession(sessionID)
+	sessionID := sm.generateID()
+	if _, exists := sm.sessions[sessionID]; exists || sessionID == "" {
+		// Подмененный генератор не должен перезаписать живую сессию
+		logger.Session.Warn().
+			Str("session_id", sessionID).
+			Msg("Session ID generator returned an empty or existing ID, falling back to random ID")
+		sessionID = generateSessionID()
+	}
+	session := NewSessionWithConfig(sessionID, sm.config)
+	session.totals = &sm.totals
 	sm.sessions[sessionID] = session
 
 	logger.Session.Info().
@@ -130,12 +516,86 @@ func (sm *SessionManager) GetSession(sessionID string) (*Session, bool) {
 	return session, exists
 }
 
+// SessionCount возвращает количество активных сессий
+func (sm *SessionManager) SessionCount() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.sessions)
+}
+
+// NotificationTotals возвращает счетчики уведомлений, агрегированные по всем сессиям
+func (sm *SessionManager) NotificationTotals() NotificationStats {
+	return sm.totals.snapshot()
+}
+
+// StartGoroutineMonitor периодически логгирует число горутин процесса и предупреждает
+// при превышении порога, перечисляя сессии с открытыми потоками для поиска утечек
+func (sm *SessionManager) StartGoroutineMonitor(ctx context.Context, interval time.Duration, threshold int) {
+	logger.Session.Info().
+		Dur("interval", interval).
+		Int("threshold", threshold).
+		Msg("Starting goroutine monitor")
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Session.Debug().Msg("Goroutine monitor stopped")
+				return
+			case <-ticker.C:
+				sm.logGoroutineStats(threshold)
+			}
+		}
+	}()
+}
+
+// logGoroutineStats логгирует текущее число горутин и потоки по сессиям
+func (sm *SessionManager) logGoroutineStats(threshold int) {
+	goroutines := runtime.NumGoroutine()
+
+	sm.mu.RLock()
+	streamsBySession := make(map[string]int64)
+	clientsBySession := make(map[string]string)
+	var totalStreams int64
+	for sessionID, session := range sm.sessions {
+		if streams := session.ActiveStreams(); streams > 0 {
+			streamsBySession[sessionID] = streams
+			clientsBySession[sessionID] = session.GetClientType()
+			totalStreams += streams
+		}
+	}
+	totalSessions := len(sm.sessions)
+	sm.mu.RUnlock()
+
+	if goroutines > threshold {
+		logger.Session.Warn().
+			Int("goroutines", goroutines).
+			Int("threshold", threshold).
+			Int("total_sessions", totalSessions).
+			Int64("active_streams", totalStreams).
+			Interface("streams_by_session", streamsBySession).
+			Interface("clients_by_session", clientsBySession).
+			Msg("Goroutine count exceeds threshold - possible leak from abandoned streams")
+		return
+	}
+
+	logger.Session.Info().
+		Int("goroutines", goroutines).
+		Int("total_sessions", totalSessions).
+		Int64("active_streams", totalStreams).
+		Msg("Goroutine stats")
+}
+
 // RemoveSession удаляет сессию
 func (sm *SessionManager) RemoveSession(sessionID string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	if session, exists := sm.sessions[sessionID]; exists {
+		session.RecordEvent("closed", "reason=deleted")
 		session.Close()
 		delete(sm.sessions, sessionID)
 
@@ -160,6 +620,7 @@ func (sm *SessionManager) CleanupExpiredSessions(maxAge time.Duration) {
 
 	for sessionID, session := range sm.sessions {
 		if now.Sub(session.LastActivity) > maxAge {
+			session.RecordEvent("closed", "reason=expired")
 			session.Close()
 			delete(sm.sessions, sessionID)
 			expiredCount++
@@ -180,6 +641,15 @@ func generateSessionID() string {
 	return "session_" + time.Now().Format("20060102_150405_") + randomString(8)
 }
 
+// SequentialIDGenerator возвращает генератор предсказуемых ID вида prefix1, prefix2, ...
+// для тестов и отладочной корреляции логов
+func SequentialIDGenerator(prefix string) func() string {
+	var n atomic.Int64
+	return func() string {
+		return prefix + strconv.FormatInt(n.Add(1), 10)
+	}
+}
+
 // randomString генерирует случайную строку используя crypto/rand
 func randomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateSessionUsesIDGenerator(t *testing.T) {
+	sm := NewSessionManager()
+	sm.SetIDGenerator(SequentialIDGenerator("test_"))
+
+	for _, want := range []string{"test_1", "test_2"} {
+		if got := sm.CreateSession(); got != want {
+			t.Fatalf("CreateSession() = %q, want %q", got, want)
+		}
+	}
+	if _, exists := sm.GetSession("test_2"); !exists {
+		t.Fatal("session test_2 is not registered")
+	}
+}
+
+func TestCreateSessionDoesNotReuseExistingID(t *testing.T) {
+	sm := NewSessionManager()
+	sm.SetIDGenerator(func() string { return "fixed" })
+
+	first := sm.CreateSession()
+	second := sm.CreateSession()
+	if first != "fixed" || second == first {
+		t.Fatalf("CreateSession() = %q, %q: duplicate ID must fall back to a random one", first, second)
+	}
+	if _, exists := sm.GetSession(first); !exists {
+		t.Fatal("first session was overwritten")
+	}
+}
+
+func TestSessionEventsAfter(t *testing.T) {
+	session := NewSessionWithConfig("history", SessionConfig{BufferSize: 3})
+	for _, data := range []string{"one", "two", "three", "four", "five"} {
+		session.RetainEvent([]byte(data))
+	}
+
+	// Сохранены события 3..5
+	events, ok := session.EventsAfter(3)
+	if !ok || len(events) != 2 || events[0].ID != 4 || string(events[1].Data) != "five" {
+		t.Fatalf("EventsAfter(3) = %+v, %v, want events 4 and 5", events, ok)
+	}
+	if events, ok := session.EventsAfter(2); !ok || len(events) != 3 {
+		t.Fatalf("EventsAfter(2) = %+v, %v, want all retained events", events, ok)
+	}
+	if events, ok := session.EventsAfter(5); !ok || len(events) != 0 {
+		t.Fatalf("EventsAfter(5) = %+v, %v, want nothing to replay", events, ok)
+	}
+	if _, ok := session.EventsAfter(1); ok {
+		t.Fatal("EventsAfter(1) must report a gap: event 2 was evicted")
+	}
+	if _, ok := session.EventsAfter(9); ok {
+		t.Fatal("EventsAfter(9) must report a gap: the id was never issued")
+	}
+}
+
+func TestSessionEventsAfterSkipsExpiredEvents(t *testing.T) {
+	session := NewSessionWithConfig("aged", SessionConfig{BufferSize: 10, EventMaxAge: time.Minute})
+	start := time.Date(2025, 3, 26, 14, 0, 0, 0, time.UTC)
+	session.retainEvent([]byte("old"), start)
+	session.retainEvent([]byte("fresh"), start.Add(90*time.Second))
+
+	// Событие 1 удалено при сохранении события 2
+	if len(session.history) != 1 {
+		t.Fatalf("history has %d events, want the expired one pruned", len(session.history))
+	}
+	if _, ok := session.eventsAfter(0, start.Add(90*time.Second)); ok {
+		t.Fatal("eventsAfter(0) must report a gap: event 1 expired")
+	}
+	if events, ok := session.eventsAfter(1, start.Add(90*time.Second)); !ok || len(events) != 1 {
+		t.Fatalf("eventsAfter(1) = %+v, %v, want the fresh event", events, ok)
+	}
+
+	// Без новых событий история устаревает при чтении
+	if _, ok := session.eventsAfter(1, start.Add(3*time.Minute)); ok {
+		t.Fatal("eventsAfter(1) must report a gap once event 2 expired")
+	}
+	if events, ok := session.eventsAfter(2, start.Add(3*time.Minute)); !ok || len(events) != 0 {
+		t.Fatalf("eventsAfter(2) = %+v, %v, want nothing to replay", events, ok)
+	}
+}